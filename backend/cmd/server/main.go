@@ -1,10 +1,18 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"youtube-market/internal/analytics"
 	"youtube-market/internal/db"
+	"youtube-market/internal/federation"
 	"youtube-market/internal/handlers"
+	"youtube-market/internal/lifecycle"
 	"youtube-market/internal/middleware"
 
 	"github.com/gin-gonic/gin"
@@ -21,6 +29,12 @@ func main() {
 		}
 	}
 
+	// SIGINT/SIGTERM запускают плавную остановку
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	app := lifecycle.New(ctx)
+
 	// Initialize database
 	if err := db.Init(); err != nil {
 		log.Fatal("Failed to initialize database:", err)
@@ -28,14 +42,26 @@ func main() {
 
 	// Initialize Redis for rate limiting
 	if err := middleware.InitRedis(); err != nil {
-		log.Printf("Warning: Redis not available, rate limiting disabled: %v", err)
+		log.Printf("Warning: Redis not available, rate limiting and ad stats disabled: %v", err)
+	} else {
+		analytics.Init(middleware.Redis())
+	}
+	// Redis закрываем раньше базы данных
+	app.OnClose("redis", middleware.CloseRedis)
+	app.OnClose("database", db.Close)
+
+	// Initialize blacklist federation with partner marketplaces
+	if err := federation.Init(); err != nil {
+		log.Printf("Warning: federation disabled: %v", err)
+	} else {
+		app.Go("federation subscriber", federation.RunSubscriber)
 	}
 
 	// Setup router
 	r := setupRouter()
 
 	// Start manager bot in background
-	go handlers.RunManagerBot()
+	app.Go("manager bot", handlers.RunManagerBot)
 
 	// Get port from environment or use default
 	port := os.Getenv("PORT")
@@ -43,10 +69,31 @@ func main() {
 		port = "8080"
 	}
 
-	log.Printf("Server starting on port %s", port)
-	if err := r.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	server := &http.Server{Addr: ":" + port, Handler: r}
+	go func() {
+		log.Printf("Server starting on port %s", port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	log.Println("Shutting down...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), lifecycle.ShutdownTimeout())
+	defer cancel()
+
+	// Сначала дожидаемся запросов в работе, затем останавливаем бота и планировщики,
+	// и только после этого закрываем Redis и базу данных
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP server shutdown failed: %v", err)
+	}
+	if !app.Stop(shutdownCtx) {
+		log.Fatal("Server stopped before background tasks finished")
 	}
+
+	log.Println("Server stopped")
 }
 
 func setupRouter() *gin.Engine {
@@ -58,9 +105,10 @@ func setupRouter() *gin.Engine {
 	r := gin.Default()
 
 	// Global middleware
+	r.Use(middleware.MetricsMiddleware())
 	r.Use(middleware.SafeLoggerMiddleware())
 	r.Use(middleware.CORSMiddleware())
-	r.Use(middleware.RateLimitMiddleware())
+	r.Use(middleware.RateLimitMiddleware(handlers.FederationBlacklistPath))
 
 	// Static files
 	r.Static("/static", "./static")
@@ -99,16 +147,41 @@ func setupRouter() *gin.Engine {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
+	// Signed blacklist feed for partner marketplaces (проверка подписи на стороне партнёра)
+	r.GET(handlers.FederationBlacklistPath, handlers.GetFederationBlacklist)
+
 	// API routes with TMA authentication
 	api := r.Group("/api")
 	api.Use(middleware.TMAuthMiddleware())
+	api.Use(middleware.LocaleMiddleware(handlers.UserLanguage))
 	{
 		api.GET("/ads", handlers.GetAds)
+		api.GET("/taxonomy", handlers.GetTaxonomy)
+		api.GET("/ads/:id", handlers.GetAd)
 		api.GET("/ads/:id/photo", handlers.GetAdPhoto)
+		api.POST("/ads/:id/contact", handlers.RecordContactClick)
+		api.POST("/ads/:id/bump", handlers.BumpAd)
+		api.POST("/ads/:id/renew", handlers.RenewMyAd)
+		api.POST("/ads/:id/deactivate", handlers.DeactivateMyAd)
 		api.GET("/myads", handlers.GetMyAds)
+		api.GET("/subscriptions", handlers.GetSubscriptions)
+		api.POST("/subscriptions", handlers.CreateSubscription)
+		api.DELETE("/subscriptions/:id", handlers.DeleteSubscription)
+		api.GET("/favorites", handlers.GetFavorites)
+		api.POST("/favorites/:adId", handlers.AddFavorite)
+		api.DELETE("/favorites/:adId", handlers.RemoveFavorite)
 		api.GET("/profile/:username", handlers.GetProfileAds)
 		api.GET("/scammer/:username", handlers.CheckScammer)
 		api.GET("/blacklist", handlers.GetBlacklist)
+		api.GET("/payments/options", handlers.GetPaymentOptions)
+		api.POST("/payments/invoice", handlers.CreatePaymentInvoice)
+
+		admin := api.Group("/admin")
+		admin.Use(middleware.ManagerOnlyMiddleware(handlers.IsManagerID))
+		{
+			admin.GET("/blacklist/export", handlers.ExportBlacklist)
+			admin.GET("/premium/calendar", handlers.GetPremiumCalendar)
+		}
 	}
 
 	return r
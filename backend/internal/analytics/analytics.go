@@ -0,0 +1,220 @@
+package analytics
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/models"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// События, которые учитываются в статистике объявлений
+const (
+	// EventImpression — объявление попало в выдачу /api/ads
+	EventImpression = "impression"
+	// EventView — объявление открыто целиком
+	EventView = "view"
+	// EventContact — нажата кнопка «Связаться»
+	EventContact = "contact"
+)
+
+const (
+	// pendingKey — хэш несброшенных счётчиков: поле "<день>:<ID объявления>:<событие>"
+	pendingKey = "adstats:pending"
+	// flushingKey — счётчики, которые сейчас переносятся в базу. Если сброс прервался,
+	// следующий запуск сначала допишет их.
+	flushingKey = "adstats:flushing"
+	// flushingBatchKey — ID пачки в flushingKey; по нему база отличает повтор прерванного сброса
+	flushingBatchKey = "adstats:flushing:batch"
+	seenPrefix       = "adstats:seen:"
+	// seenTTL — сколько хранить зрителей дня: сутки и запас на часовые пояса и сброс
+	seenTTL  = 48 * time.Hour
+	dayField = "2006-01-02"
+	// flushHistoryTTL — сколько хранить ID перенесённых пачек в ad_stat_flushes
+	flushHistoryTTL = 7 * 24 * time.Hour
+)
+
+// recordScript учитывает событие для каждого объявления, если зритель ещё не встречался
+// в этот день, и возвращает число учтённых событий.
+// ARGV: день, событие, зритель, TTL в секундах, ID объявлений...
+var recordScript = redis.NewScript(`
+local recorded = 0
+for i = 5, #ARGV do
+  local seen = '` + seenPrefix + `' .. ARGV[1] .. ':' .. ARGV[2] .. ':' .. ARGV[i]
+  if redis.call('SADD', seen, ARGV[3]) == 1 then
+    redis.call('EXPIRE', seen, ARGV[4])
+    redis.call('HINCRBY', KEYS[1], ARGV[1] .. ':' .. ARGV[i] .. ':' .. ARGV[2], 1)
+    recorded = recorded + 1
+  end
+end
+return recorded
+`)
+
+var client *redis.Client
+
+// Init включает учёт статистики. Без Redis события не записываются.
+func Init(redisClient *redis.Client) {
+	client = redisClient
+}
+
+// Enabled — статистика собирается
+func Enabled() bool {
+	return client != nil
+}
+
+// Record учитывает событие зрителя для объявлений. Повторные события того же зрителя
+// за день не учитываются. Счётчики копятся в Redis и переносятся в базу Flush.
+func Record(ctx context.Context, viewerID int64, event string, adIDs ...uint) error {
+	if client == nil || viewerID == 0 || len(adIDs) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(adIDs)+4)
+	args = append(args, time.Now().Format(dayField), event, viewerID, int(seenTTL/time.Second))
+	for _, adID := range adIDs {
+		args = append(args, adID)
+	}
+	return recordScript.Run(ctx, client, []string{pendingKey}, args...).Err()
+}
+
+// Flush переносит накопленные счётчики в ad_stats_daily. Выполняется на одном экземпляре
+// (лидере планировщика), поэтому переименование буфера не пересекается с другим сбросом.
+// Буфер получает ID пачки; счётчики прибавляются в одной транзакции с записью этого ID
+// в ad_stat_flushes, поэтому если сброс упал после коммита, но до удаления буфера,
+// повтор не учтёт те же события дважды.
+func Flush(ctx context.Context) error {
+	if client == nil {
+		return nil
+	}
+
+	pending, err := client.Exists(ctx, flushingKey).Result()
+	if err != nil {
+		return fmt.Errorf("check flushing buffer: %w", err)
+	}
+	if pending == 0 {
+		if err := client.Rename(ctx, pendingKey, flushingKey).Err(); err != nil {
+			if strings.Contains(err.Error(), "no such key") {
+				return nil
+			}
+			return fmt.Errorf("swap stats buffer: %w", err)
+		}
+	}
+	batchID, err := flushingBatch(ctx, pending == 0)
+	if err != nil {
+		return err
+	}
+
+	fields, err := client.HGetAll(ctx, flushingKey).Result()
+	if err != nil {
+		return fmt.Errorf("read stats buffer: %w", err)
+	}
+
+	rows := make(map[string]*models.AdStatDaily)
+	for field, raw := range fields {
+		parts := strings.Split(field, ":")
+		count, countErr := strconv.ParseInt(raw, 10, 64)
+		if len(parts) != 3 || countErr != nil {
+			log.Printf("analytics: skipping malformed counter %q=%q", field, raw)
+			continue
+		}
+		day, dayErr := time.ParseInLocation(dayField, parts[0], time.Local)
+		adID, idErr := strconv.ParseUint(parts[1], 10, 64)
+		if dayErr != nil || idErr != nil {
+			log.Printf("analytics: skipping malformed counter %q", field)
+			continue
+		}
+
+		key := parts[0] + ":" + parts[1]
+		row, ok := rows[key]
+		if !ok {
+			row = &models.AdStatDaily{AdID: uint(adID), Day: day}
+			rows[key] = row
+		}
+		switch parts[2] {
+		case EventImpression:
+			row.Impressions += count
+		case EventView:
+			row.Views += count
+		case EventContact:
+			row.Contacts += count
+		}
+	}
+
+	if err := saveBatch(ctx, batchID, rows); err != nil {
+		return fmt.Errorf("save daily stats: %w", err)
+	}
+
+	if err := client.Del(ctx, flushingKey, flushingBatchKey).Err(); err != nil {
+		return fmt.Errorf("clear flushed buffer: %w", err)
+	}
+	return nil
+}
+
+// flushingBatch возвращает ID пачки в flushingKey. Новой пачке (fresh) назначается новый ID;
+// пачка, оставшаяся от прерванного сброса, сохраняет прежний.
+func flushingBatch(ctx context.Context, fresh bool) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate batch id: %w", err)
+	}
+	batchID := hex.EncodeToString(buf)
+
+	if fresh {
+		if err := client.Set(ctx, flushingBatchKey, batchID, 0).Err(); err != nil {
+			return "", fmt.Errorf("save batch id: %w", err)
+		}
+		return batchID, nil
+	}
+	if err := client.SetNX(ctx, flushingBatchKey, batchID, 0).Err(); err != nil {
+		return "", fmt.Errorf("save batch id: %w", err)
+	}
+	batchID, err := client.Get(ctx, flushingBatchKey).Result()
+	if err != nil {
+		return "", fmt.Errorf("read batch id: %w", err)
+	}
+	return batchID, nil
+}
+
+// saveBatch прибавляет счётчики пачки batchID к ad_stats_daily, если пачка ещё не перенесена
+func saveBatch(ctx context.Context, batchID string, rows map[string]*models.AdStatDaily) error {
+	return db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.AdStatFlush{BatchID: batchID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			log.Printf("analytics: batch %s is already saved, skipping", batchID)
+			return nil
+		}
+		if err := tx.Where("created_at < ?", time.Now().Add(-flushHistoryTTL)).Delete(&models.AdStatFlush{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		batch := make([]*models.AdStatDaily, 0, len(rows))
+		for _, row := range rows {
+			batch = append(batch, row)
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "ad_id"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"impressions": gorm.Expr("ad_stats_daily.impressions + excluded.impressions"),
+				"views":       gorm.Expr("ad_stats_daily.views + excluded.views"),
+				"contacts":    gorm.Expr("ad_stats_daily.contacts + excluded.contacts"),
+				"updated_at":  gorm.Expr("excluded.updated_at"),
+			}),
+		}).CreateInBatches(batch, 500).Error
+	})
+}
@@ -0,0 +1,134 @@
+package analytics
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/db/dbtest"
+	"youtube-market/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func openStatsDB(t *testing.T) {
+	dbtest.Open(t, "ad_stats_daily", "ad_stat_flushes")
+}
+
+func loadDaily(t *testing.T, adID uint) models.AdStatDaily {
+	t.Helper()
+	var row models.AdStatDaily
+	if err := db.DB.Where("ad_id = ?", adID).First(&row).Error; err != nil {
+		t.Fatalf("load stats of ad %d: %v", adID, err)
+	}
+	return row
+}
+
+func TestSaveBatchIsIdempotent(t *testing.T) {
+	openStatsDB(t)
+
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.Local)
+	batch := func() map[string]*models.AdStatDaily {
+		return map[string]*models.AdStatDaily{
+			"2026-03-04:1": {AdID: 1, Day: day, Impressions: 5, Views: 2, Contacts: 1},
+		}
+	}
+
+	if err := saveBatch(context.Background(), "batch-a", batch()); err != nil {
+		t.Fatalf("save batch: %v", err)
+	}
+	// Повтор той же пачки — сброс упал после коммита, но до удаления буфера
+	if err := saveBatch(context.Background(), "batch-a", batch()); err != nil {
+		t.Fatalf("repeat batch: %v", err)
+	}
+	if row := loadDaily(t, 1); row.Impressions != 5 || row.Views != 2 || row.Contacts != 1 {
+		t.Fatalf("after repeated batch got %+v, want counters added once", row)
+	}
+
+	// Следующая пачка прибавляется к уже сохранённым счётчикам
+	if err := saveBatch(context.Background(), "batch-b", batch()); err != nil {
+		t.Fatalf("save next batch: %v", err)
+	}
+	if row := loadDaily(t, 1); row.Impressions != 10 || row.Views != 4 || row.Contacts != 2 {
+		t.Fatalf("after second batch got %+v, want counters doubled", row)
+	}
+}
+
+// openStatsRedis подключает буфер статистики к Redis из TEST_REDIS_URL и очищает его ключи
+func openStatsRedis(t *testing.T) {
+	t.Helper()
+	url := os.Getenv("TEST_REDIS_URL")
+	if url == "" {
+		t.Skip("TEST_REDIS_URL is not set")
+	}
+	opt, err := redis.ParseURL(url)
+	if err != nil {
+		t.Fatalf("parse TEST_REDIS_URL: %v", err)
+	}
+	redisClient := redis.NewClient(opt)
+	reset := func() {
+		// Зрители дня тоже сбрасываются, иначе повторный запуск не учтёт те же события
+		seen := seenPrefix + time.Now().Format(dayField) + ":" + EventView + ":7"
+		if err := redisClient.Del(context.Background(), pendingKey, flushingKey, flushingBatchKey, seen).Err(); err != nil {
+			t.Fatalf("clear stats buffer: %v", err)
+		}
+	}
+	reset()
+	Init(redisClient)
+	t.Cleanup(func() {
+		reset()
+		Init(nil)
+		redisClient.Close()
+	})
+}
+
+func TestFlushResumesInterruptedBatchOnce(t *testing.T) {
+	openStatsDB(t)
+	openStatsRedis(t)
+	ctx := context.Background()
+
+	if err := Record(ctx, 100, EventView, 7); err != nil {
+		t.Fatalf("record view: %v", err)
+	}
+	if err := Record(ctx, 101, EventView, 7); err != nil {
+		t.Fatalf("record view: %v", err)
+	}
+
+	// Сброс упал после коммита: буфер и ID пачки остались в Redis, счётчики уже в базе
+	if err := client.Rename(ctx, pendingKey, flushingKey).Err(); err != nil {
+		t.Fatalf("swap buffer: %v", err)
+	}
+	batchID, err := flushingBatch(ctx, true)
+	if err != nil {
+		t.Fatalf("batch id: %v", err)
+	}
+	day, _ := time.ParseInLocation(dayField, time.Now().Format(dayField), time.Local)
+	if err := saveBatch(ctx, batchID, map[string]*models.AdStatDaily{
+		"today:7": {AdID: 7, Day: day, Views: 2},
+	}); err != nil {
+		t.Fatalf("save batch: %v", err)
+	}
+
+	if err := Flush(ctx); err != nil {
+		t.Fatalf("resume flush: %v", err)
+	}
+	if row := loadDaily(t, 7); row.Views != 2 {
+		t.Fatalf("views = %d after resumed flush, want 2", row.Views)
+	}
+	if left, err := client.Exists(ctx, flushingKey, flushingBatchKey).Result(); err != nil || left != 0 {
+		t.Fatalf("flushing buffer left behind: %d, %v", left, err)
+	}
+
+	// Новые события уходят новой пачкой
+	if err := Record(ctx, 102, EventView, 7); err != nil {
+		t.Fatalf("record view: %v", err)
+	}
+	if err := Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if row := loadDaily(t, 7); row.Views != 3 {
+		t.Fatalf("views = %d after next flush, want 3", row.Views)
+	}
+}
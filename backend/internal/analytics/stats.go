@@ -0,0 +1,64 @@
+package analytics
+
+import (
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/models"
+)
+
+// Totals — сумма счётчиков за период
+type Totals struct {
+	Impressions int64 `json:"impressions"`
+	Views       int64 `json:"views"`
+	Contacts    int64 `json:"contacts"`
+}
+
+// AdTotals — счётчики одного объявления за период
+type AdTotals struct {
+	AdID uint
+	Totals
+}
+
+const totalsSelect = "ad_id, SUM(impressions) AS impressions, SUM(views) AS views, SUM(contacts) AS contacts"
+
+// Since — начало периода из days последних дней, включая сегодняшний
+func Since(days int) time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day()-days+1, 0, 0, 0, 0, time.Local)
+}
+
+// ForAds возвращает счётчики объявлений с since (нулевое время — за всё время).
+// Учитываются только перенесённые в базу события — задержка до минуты.
+func ForAds(adIDs []uint, since time.Time) (map[uint]Totals, error) {
+	result := make(map[uint]Totals, len(adIDs))
+	if len(adIDs) == 0 {
+		return result, nil
+	}
+
+	query := db.DB.Model(&models.AdStatDaily{}).Select(totalsSelect).Where("ad_id IN ?", adIDs)
+	if !since.IsZero() {
+		query = query.Where("day >= ?", since)
+	}
+	var rows []AdTotals
+	if err := query.Group("ad_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.AdID] = row.Totals
+	}
+	return result, nil
+}
+
+// Top — объявления с наибольшим числом нажатий «Связаться», затем открытий и показов с since
+func Top(since time.Time, limit int) ([]AdTotals, error) {
+	var rows []AdTotals
+	err := db.DB.Model(&models.AdStatDaily{}).
+		Select(totalsSelect).
+		Where("day >= ?", since).
+		Group("ad_id").
+		Order("contacts DESC, views DESC, impressions DESC, ad_id").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
@@ -17,7 +17,12 @@ func Init() error {
 	if dsn == "" {
 		return fmt.Errorf("DATABASE_URL environment variable is not set")
 	}
+	return Connect(dsn)
+}
 
+// Connect подключается к базе по dsn, применяет миграции и сохраняет соединение в DB.
+// Тесты вызывают его с TEST_DATABASE_URL.
+func Connect(dsn string) error {
 	config := &gorm.Config{}
 	if os.Getenv("GIN_MODE") != "release" {
 		config.Logger = logger.Default.LogMode(logger.Info)
@@ -29,10 +34,59 @@ func Init() error {
 	}
 
 	// Auto migrate models
-	if err := db.AutoMigrate(&models.User{}, &models.Ad{}); err != nil {
+	if err := db.AutoMigrate(
+		&models.User{},
+		&models.Ad{},
+		&models.PeerBlacklistEntry{},
+		&models.FederationPeerState{},
+		&models.GroupGuardSettings{},
+		&models.Payment{},
+		&models.PremiumBooking{},
+		&models.AdBump{},
+		&models.AdRenewal{},
+		&models.ManagerAction{},
+		&models.JobRun{},
+		&models.AdNotification{},
+		&models.AdRevision{},
+		&models.TaxonomyCategory{},
+		&models.TaxonomyOption{},
+		&models.SavedSearch{},
+		&models.SavedSearchAlert{},
+		&models.Favorite{},
+		&models.AdStatDaily{},
+		&models.AdStatFlush{},
+		&models.Broadcast{},
+		&models.BroadcastDelivery{},
+		&models.OutboundMessage{},
+		&models.AdRequest{},
+	); err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	if err := migrateAdOwners(db); err != nil {
+		return fmt.Errorf("failed to migrate ad owners: %w", err)
+	}
+
+	if err := migrateExpiryReminders(db); err != nil {
+		return fmt.Errorf("failed to migrate expiry reminders: %w", err)
+	}
+
+	if err := seedTaxonomy(db); err != nil {
+		return fmt.Errorf("failed to seed taxonomy: %w", err)
+	}
+
 	DB = db
 	return nil
 }
+
+// Close закрывает пул соединений с базой данных
+func Close() error {
+	if DB == nil {
+		return nil
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
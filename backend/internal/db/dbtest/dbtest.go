@@ -0,0 +1,45 @@
+// Package dbtest подключает тесты к отдельной базе Postgres из TEST_DATABASE_URL.
+// Без этой переменной тесты, которым нужна база, пропускаются.
+package dbtest
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"youtube-market/internal/db"
+)
+
+var (
+	connectOnce sync.Once
+	connectErr  error
+)
+
+// Open подключает db.DB к тестовой базе и очищает перечисленные таблицы
+// до и после теста. Если TEST_DATABASE_URL не задан, тест пропускается.
+func Open(t testing.TB, tables ...string) {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	connectOnce.Do(func() {
+		os.Setenv("GIN_MODE", "release")
+		connectErr = db.Connect(dsn)
+	})
+	if connectErr != nil {
+		t.Fatalf("connect to test database: %v", connectErr)
+	}
+
+	truncate(t, tables)
+	t.Cleanup(func() { truncate(t, tables) })
+}
+
+func truncate(t testing.TB, tables []string) {
+	for _, table := range tables {
+		if err := db.DB.Exec("TRUNCATE TABLE " + table + " RESTART IDENTITY CASCADE").Error; err != nil {
+			t.Fatalf("truncate %s: %v", table, err)
+		}
+	}
+}
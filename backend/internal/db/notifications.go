@@ -0,0 +1,30 @@
+package db
+
+import (
+	"fmt"
+
+	"youtube-market/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// migrateExpiryReminders переносит флаг ads.pre_expiry_notified в ad_notifications
+// (как отправленное напоминание за 24 часа) и удаляет колонку. Повторный запуск ничего не делает.
+func migrateExpiryReminders(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.Ad{}, "pre_expiry_notified") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`INSERT INTO ad_notifications (ad_id, kind, lead_seconds, expires_at, created_at)
+			SELECT id, ?, ?, expires_at, NOW() FROM ads WHERE pre_expiry_notified AND status = ?
+			ON CONFLICT DO NOTHING`,
+			models.AdNotificationExpiryReminder, 24*60*60, models.AdStatusActive).Error; err != nil {
+			return fmt.Errorf("copy pre-expiry flags: %w", err)
+		}
+		if err := tx.Migrator().DropColumn(&models.Ad{}, "pre_expiry_notified"); err != nil {
+			return fmt.Errorf("drop ads.pre_expiry_notified: %w", err)
+		}
+		return nil
+	})
+}
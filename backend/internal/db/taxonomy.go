@@ -0,0 +1,100 @@
+package db
+
+import (
+	"fmt"
+
+	"youtube-market/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type defaultOption struct {
+	Key   string
+	Label string
+}
+
+type defaultCategory struct {
+	Key   string
+	Label string
+	Modes []defaultOption
+	Tags  []defaultOption
+}
+
+// defaultTaxonomy — рубрики, которые раньше были зашиты в код бота и Mini App
+var defaultTaxonomy = []defaultCategory{
+	{
+		Key:   "services",
+		Label: "Услуги",
+		Modes: []defaultOption{{"offer", "Предлагаю услугу"}, {"search", "Ищу услугу"}},
+		Tags: []defaultOption{
+			{"all", "Все"}, {"designer", "Дизайнер"}, {"script", "Сценарист"},
+			{"voice", "Озвучивание"}, {"other", "Другое"},
+		},
+	},
+	{
+		Key:   "buysell",
+		Label: "Купля/Продажа",
+		Modes: []defaultOption{{"sell", "Продаю"}, {"buy", "Покупаю"}},
+		Tags: []defaultOption{
+			{"all", "Все"}, {"konechka", "Конечка"}, {"channel", "Канал"},
+			{"video", "Видео"}, {"adsense", "Адсенс"}, {"templates", "Шаблоны"},
+		},
+	},
+	{
+		Key:   "other",
+		Label: "Другое",
+		Modes: []defaultOption{{"general", "Объявление"}},
+		Tags: []defaultOption{
+			{"all", "Все"}, {"education", "Обучение"}, {"courses", "Курсы"}, {"cheats", "Читы"},
+			{"mods", "Моды"}, {"niche", "Ниша"}, {"schemes", "Схемы"}, {"boost", "Накрутка"},
+		},
+	},
+}
+
+// seedTaxonomy заполняет рубрики значениями по умолчанию, если таблица категорий пуста.
+// Дальше рубрики меняются только менеджерами, повторный запуск ничего не делает.
+func seedTaxonomy(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&models.TaxonomyCategory{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i, category := range defaultTaxonomy {
+			if err := tx.Create(&models.TaxonomyCategory{
+				Key:      category.Key,
+				Label:    category.Label,
+				Position: i + 1,
+				Active:   true,
+			}).Error; err != nil {
+				return fmt.Errorf("create category %s: %w", category.Key, err)
+			}
+			if err := createDefaultOptions(tx, category.Key, models.TaxonomyKindMode, category.Modes); err != nil {
+				return err
+			}
+			if err := createDefaultOptions(tx, category.Key, models.TaxonomyKindTag, category.Tags); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func createDefaultOptions(tx *gorm.DB, category, kind string, options []defaultOption) error {
+	for i, option := range options {
+		if err := tx.Create(&models.TaxonomyOption{
+			Category: category,
+			Kind:     kind,
+			Key:      option.Key,
+			Label:    option.Label,
+			Position: i + 1,
+			Active:   true,
+		}).Error; err != nil {
+			return fmt.Errorf("create %s %s/%s: %w", kind, category, option.Key, err)
+		}
+	}
+	return nil
+}
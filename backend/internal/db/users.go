@@ -0,0 +1,126 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"youtube-market/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// EnsureTelegramUser возвращает пользователя с указанным Telegram ID, создавая его при необходимости.
+// Если пользователь с таким username уже есть без Telegram ID (например, попал в чёрный список
+// по username), Telegram ID привязывается к нему.
+func EnsureTelegramUser(tx *gorm.DB, telegramID int64, username string) (models.User, error) {
+	username = strings.TrimPrefix(strings.TrimSpace(username), "@")
+
+	var user models.User
+	err := tx.Unscoped().Where("telegram_id = ?", telegramID).First(&user).Error
+	if err == nil {
+		return user, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return user, err
+	}
+
+	if username != "" {
+		err = tx.Unscoped().Where("LOWER(username) = LOWER(?)", username).First(&user).Error
+		switch {
+		case err == nil && user.TelegramID == nil:
+			if err := tx.Unscoped().Model(&user).Update("telegram_id", telegramID).Error; err != nil {
+				return user, err
+			}
+			user.TelegramID = &telegramID
+			return user, nil
+		case err == nil:
+			// username уже принадлежит другому Telegram ID — не дублируем его
+			username = ""
+		case err != gorm.ErrRecordNotFound:
+			return user, err
+		}
+	}
+
+	user = models.User{Username: username, TelegramID: &telegramID}
+	if err := tx.Create(&user).Error; err != nil {
+		return user, err
+	}
+	return user, nil
+}
+
+// legacyAdOwner — владелец объявления в старой схеме (ads.user_id и ads.client_id)
+type legacyAdOwner struct {
+	ID       uint
+	UserID   int64
+	ClientID string
+}
+
+// migrateAdOwners переносит владельцев объявлений из ads.user_id/ads.client_id в ads.owner_id
+// (ссылка на users.id) и удаляет старые колонки. Повторный запуск ничего не делает.
+func migrateAdOwners(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	// Старый уникальный индекс не допускает нескольких пользователей без username
+	if migrator.HasIndex(&models.User{}, "idx_users_username") {
+		if err := migrator.DropIndex(&models.User{}, "idx_users_username"); err != nil {
+			return fmt.Errorf("drop idx_users_username: %w", err)
+		}
+	}
+
+	hasUserID := migrator.HasColumn(&models.Ad{}, "user_id")
+	hasClientID := migrator.HasColumn(&models.Ad{}, "client_id")
+	if !hasUserID && !hasClientID {
+		return nil
+	}
+
+	userIDColumn, clientIDColumn := "0", "''"
+	if hasUserID {
+		userIDColumn = "COALESCE(user_id, 0)"
+	}
+	if hasClientID {
+		clientIDColumn = "COALESCE(client_id, '')"
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var rows []legacyAdOwner
+		if err := tx.Raw(fmt.Sprintf("SELECT id, %s AS user_id, %s AS client_id FROM ads WHERE owner_id IS NULL", userIDColumn, clientIDColumn)).
+			Scan(&rows).Error; err != nil {
+			return fmt.Errorf("load legacy ad owners: %w", err)
+		}
+
+		owners := make(map[int64]int64)
+		for _, row := range rows {
+			telegramID := row.UserID
+			if telegramID == 0 {
+				telegramID, _ = strconv.ParseInt(strings.TrimSpace(row.ClientID), 10, 64)
+			}
+			if telegramID == 0 {
+				continue
+			}
+
+			ownerID, ok := owners[telegramID]
+			if !ok {
+				user, err := EnsureTelegramUser(tx, telegramID, "")
+				if err != nil {
+					return fmt.Errorf("ensure owner %d: %w", telegramID, err)
+				}
+				ownerID = user.ID
+				owners[telegramID] = ownerID
+			}
+
+			if err := tx.Exec("UPDATE ads SET owner_id = ? WHERE id = ?", ownerID, row.ID).Error; err != nil {
+				return fmt.Errorf("set owner of ad %d: %w", row.ID, err)
+			}
+		}
+
+		for _, column := range []string{"user_id", "client_id"} {
+			if tx.Migrator().HasColumn(&models.Ad{}, column) {
+				if err := tx.Migrator().DropColumn(&models.Ad{}, column); err != nil {
+					return fmt.Errorf("drop ads.%s: %w", column, err)
+				}
+			}
+		}
+		return nil
+	})
+}
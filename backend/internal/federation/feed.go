@@ -0,0 +1,186 @@
+package federation
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Заголовки подписи. Ответ с фидом и запрос партнёра подписываются ключом Ed25519
+// отправителя вместе с меткой времени, поэтому старую подпись нельзя использовать повторно.
+const (
+	SignatureHeader = "X-Federation-Signature"
+	TimestampHeader = "X-Federation-Timestamp"
+	// PeerHeader — имя биржи, отправившей запрос (её FEDERATION_SOURCE)
+	PeerHeader = "X-Federation-Peer"
+	// MaxClockSkew — насколько метка времени подписи может отличаться от текущего времени
+	MaxClockSkew = 5 * time.Minute
+
+	signaturePrefix = "ed25519="
+	kindFeed        = "feed"
+	kindRequest     = "request"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrStaleSignature   = errors.New("signature timestamp is too old or in the future")
+)
+
+// Entry — запись чёрного списка в федеративном фиде.
+// Removed=true означает, что пользователь исключён из списка источника.
+type Entry struct {
+	Username  string     `json:"username"`
+	Reason    string     `json:"reason,omitempty"`
+	AddedAt   *time.Time `json:"added_at,omitempty"`
+	Removed   bool       `json:"removed,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// Feed — одна страница фида. Next передаётся в следующем запросе как since.
+type Feed struct {
+	Source  string  `json:"source"`
+	Entries []Entry `json:"entries"`
+	Next    string  `json:"next"`
+	HasMore bool    `json:"has_more"`
+}
+
+// ParsePrivateKey разбирает ключ подписи: base64 от 32-байтного seed или 64-байтного ключа
+func ParsePrivateKey(raw string) (ed25519.PrivateKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("private key is not base64")
+	}
+	switch len(data) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(data), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(data), nil
+	default:
+		return nil, fmt.Errorf("private key must be %d or %d bytes", ed25519.SeedSize, ed25519.PrivateKeySize)
+	}
+}
+
+// ParsePublicKey разбирает открытый ключ партнёра (base64 от 32 байт)
+func ParsePublicKey(raw string) (ed25519.PublicKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(raw))
+	if err != nil || len(data) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key must be base64 of %d bytes", ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(data), nil
+}
+
+// EncodePublicKey — открытый ключ в виде, в котором его указывают партнёры
+func EncodePublicKey(key ed25519.PublicKey) string {
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// signedMessage связывает подпись с её назначением и меткой времени: подпись ответа
+// нельзя выдать за подпись запроса и наоборот
+func signedMessage(kind string, timestamp int64, payload []byte) []byte {
+	message := []byte(fmt.Sprintf("%s\n%d\n", kind, timestamp))
+	return append(message, payload...)
+}
+
+func sign(key ed25519.PrivateKey, kind string, now time.Time, payload []byte) (string, string) {
+	timestamp := now.Unix()
+	signature := ed25519.Sign(key, signedMessage(kind, timestamp, payload))
+	return strconv.FormatInt(timestamp, 10), signaturePrefix + base64.StdEncoding.EncodeToString(signature)
+}
+
+func verify(key ed25519.PublicKey, kind, timestamp, signature string, payload []byte, now time.Time) error {
+	if len(key) != ed25519.PublicKeySize || !strings.HasPrefix(signature, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil || !ed25519.Verify(key, signedMessage(kind, unix, payload), raw) {
+		return ErrInvalidSignature
+	}
+	if skew := now.Sub(time.Unix(unix, 0)); skew > MaxClockSkew || skew < -MaxClockSkew {
+		return ErrStaleSignature
+	}
+	return nil
+}
+
+// SignFeed подписывает тело страницы фида: возвращает значения TimestampHeader и SignatureHeader
+func SignFeed(key ed25519.PrivateKey, body []byte, now time.Time) (timestamp, signature string) {
+	return sign(key, kindFeed, now, body)
+}
+
+// VerifyFeed проверяет подпись страницы фида ключом партнёра
+func VerifyFeed(key ed25519.PublicKey, body []byte, timestamp, signature string, now time.Time) error {
+	return verify(key, kindFeed, timestamp, signature, body, now)
+}
+
+// requestPayload — подписываемая часть запроса: метод, путь и параметры (в том числе since)
+func requestPayload(req *http.Request) []byte {
+	return []byte(req.Method + " " + req.URL.RequestURI())
+}
+
+// SignRequest подписывает запрос к фиду партнёра от имени source
+func SignRequest(req *http.Request, source string, key ed25519.PrivateKey, now time.Time) {
+	timestamp, signature := sign(key, kindRequest, now, requestPayload(req))
+	req.Header.Set(PeerHeader, source)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, signature)
+}
+
+// Authenticate проверяет, что запрос подписан одним из партнёров из FEDERATION_PEERS
+func Authenticate(req *http.Request, now time.Time) (Peer, error) {
+	peer, ok := FindPeer(strings.TrimSpace(req.Header.Get(PeerHeader)))
+	if !ok {
+		return Peer{}, fmt.Errorf("unknown peer")
+	}
+	if err := verify(peer.Key, kindRequest, req.Header.Get(TimestampHeader), req.Header.Get(SignatureHeader), requestPayload(req), now); err != nil {
+		return Peer{}, err
+	}
+	return peer, nil
+}
+
+// Cursor — позиция в фиде: последняя отданная запись по (updated_at, id)
+type Cursor struct {
+	UpdatedAt time.Time
+	ID        int64
+}
+
+// EncodeCursor упаковывает курсор в непрозрачный since-токен
+func EncodeCursor(c Cursor) string {
+	raw := fmt.Sprintf("%d:%d", c.UpdatedAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor разбирает since-токен. Пустой токен означает начало фида.
+func DecodeCursor(token string) (Cursor, error) {
+	if token == "" {
+		return Cursor{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid since token")
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return Cursor{}, fmt.Errorf("invalid since token")
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid since token")
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid since token")
+	}
+
+	return Cursor{UpdatedAt: time.Unix(0, nanos), ID: id}, nil
+}
@@ -0,0 +1,81 @@
+package federation
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testKey(seed byte) ed25519.PrivateKey {
+	raw := make([]byte, ed25519.SeedSize)
+	for i := range raw {
+		raw[i] = seed
+	}
+	return ed25519.NewKeyFromSeed(raw)
+}
+
+func TestVerifyFeed(t *testing.T) {
+	key := testKey(1)
+	public := key.Public().(ed25519.PublicKey)
+	body := []byte(`{"source":"partner","entries":[]}`)
+	now := time.Now()
+
+	timestamp, signature := SignFeed(key, body, now)
+	if err := VerifyFeed(public, body, timestamp, signature, now); err != nil {
+		t.Fatalf("valid signature rejected: %v", err)
+	}
+
+	if err := VerifyFeed(public, []byte(`{"source":"partner","entries":[{}]}`), timestamp, signature, now); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("tampered body: got %v, want ErrInvalidSignature", err)
+	}
+
+	other := testKey(2).Public().(ed25519.PublicKey)
+	if err := VerifyFeed(other, body, timestamp, signature, now); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("foreign key: got %v, want ErrInvalidSignature", err)
+	}
+
+	if err := VerifyFeed(public, body, timestamp, signature, now.Add(MaxClockSkew+time.Minute)); !errors.Is(err, ErrStaleSignature) {
+		t.Fatalf("stale timestamp: got %v, want ErrStaleSignature", err)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	partner := testKey(3)
+	SetConfig(Config{
+		Source:     "ours",
+		PrivateKey: testKey(4),
+		Peers:      []Peer{{Name: "partner", Key: partner.Public().(ed25519.PublicKey)}},
+	})
+	t.Cleanup(func() { SetConfig(Config{}) })
+	now := time.Now()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/federation/blacklist?since=abc", nil)
+	SignRequest(req, "partner", partner, now)
+	if peer, err := Authenticate(req, now); err != nil || peer.Name != "partner" {
+		t.Fatalf("signed request: got %q, %v", peer.Name, err)
+	}
+
+	tampered := httptest.NewRequest(http.MethodGet, "/api/federation/blacklist?since=xyz", nil)
+	tampered.Header = req.Header.Clone()
+	if _, err := Authenticate(tampered, now); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("changed query: got %v, want ErrInvalidSignature", err)
+	}
+
+	forged := httptest.NewRequest(http.MethodGet, "/api/federation/blacklist", nil)
+	SignRequest(forged, "partner", testKey(5), now)
+	if _, err := Authenticate(forged, now); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("request signed by another key: got %v, want ErrInvalidSignature", err)
+	}
+
+	if _, err := Authenticate(req, now.Add(-MaxClockSkew-time.Minute)); !errors.Is(err, ErrStaleSignature) {
+		t.Fatalf("replayed request: got %v, want ErrStaleSignature", err)
+	}
+
+	anonymous := httptest.NewRequest(http.MethodGet, "/api/federation/blacklist", nil)
+	if _, err := Authenticate(anonymous, now); err == nil {
+		t.Fatal("unsigned request accepted")
+	}
+}
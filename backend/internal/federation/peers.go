@@ -0,0 +1,166 @@
+package federation
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Уровни доверия к партнёру
+const (
+	// TrustBlock — записи партнёра приравниваются к нашему чёрному списку
+	TrustBlock = "block"
+	// TrustWarn — пользователь считается безопасным, но показывается предупреждение
+	TrustWarn = "warn"
+	// TrustIgnore — записи партнёра синхронизируются, но не учитываются при проверке
+	TrustIgnore = "ignore"
+)
+
+const defaultSyncInterval = 15 * time.Minute
+
+// Peer — партнёрская биржа, чей фид мы забираем и которой отдаём свой.
+// Name совпадает с FEDERATION_SOURCE партнёра, PublicKey — его открытый ключ Ed25519:
+// им проверяются и фид партнёра, и его запросы к нашему фиду.
+type Peer struct {
+	Name      string            `json:"name"`
+	Title     string            `json:"title,omitempty"`
+	URL       string            `json:"url"`
+	PublicKey string            `json:"public_key"`
+	Trust     string            `json:"trust,omitempty"`
+	Key       ed25519.PublicKey `json:"-"`
+}
+
+// Label возвращает название партнёра для показа пользователю
+func (p Peer) Label() string {
+	if p.Title != "" {
+		return p.Title
+	}
+	return p.Name
+}
+
+// Config — настройки федерации из переменных окружения
+type Config struct {
+	Source string
+	// PrivateKey подписывает наш фид и наши запросы к партнёрам; без него федерация выключена
+	PrivateKey   ed25519.PrivateKey
+	Peers        []Peer
+	SyncInterval time.Duration
+}
+
+var (
+	configMu sync.RWMutex
+	config   Config
+)
+
+// Init читает FEDERATION_SOURCE, FEDERATION_PRIVATE_KEY, FEDERATION_PEERS и FEDERATION_SYNC_INTERVAL
+func Init() error {
+	cfg := Config{
+		Source:       strings.TrimSpace(os.Getenv("FEDERATION_SOURCE")),
+		SyncInterval: defaultSyncInterval,
+	}
+	if cfg.Source == "" {
+		cfg.Source = "youtubebirzha"
+	}
+	if os.Getenv("FEDERATION_SECRET") != "" {
+		log.Println("federation: FEDERATION_SECRET is no longer used, configure FEDERATION_PRIVATE_KEY and public_key of each peer")
+	}
+
+	if raw := strings.TrimSpace(os.Getenv("FEDERATION_PRIVATE_KEY")); raw != "" {
+		key, err := ParsePrivateKey(raw)
+		if err != nil {
+			return fmt.Errorf("invalid FEDERATION_PRIVATE_KEY: %w", err)
+		}
+		cfg.PrivateKey = key
+		log.Printf("federation: public key of %s: %s", cfg.Source, EncodePublicKey(key.Public().(ed25519.PublicKey)))
+	}
+
+	if raw := strings.TrimSpace(os.Getenv("FEDERATION_SYNC_INTERVAL")); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil || interval < time.Minute {
+			return fmt.Errorf("invalid FEDERATION_SYNC_INTERVAL: %q", raw)
+		}
+		cfg.SyncInterval = interval
+	}
+
+	peers, err := ParsePeers(os.Getenv("FEDERATION_PEERS"))
+	if err != nil {
+		return err
+	}
+	cfg.Peers = peers
+	if len(peers) > 0 && cfg.PrivateKey == nil {
+		return fmt.Errorf("FEDERATION_PEERS requires FEDERATION_PRIVATE_KEY")
+	}
+
+	SetConfig(cfg)
+	return nil
+}
+
+// ParsePeers разбирает JSON-массив партнёров
+func ParsePeers(raw string) ([]Peer, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	var peers []Peer
+	if err := json.Unmarshal([]byte(raw), &peers); err != nil {
+		return nil, fmt.Errorf("invalid FEDERATION_PEERS: %w", err)
+	}
+
+	seen := make(map[string]struct{}, len(peers))
+	for i := range peers {
+		peer := &peers[i]
+		peer.Name = strings.TrimSpace(peer.Name)
+		if peer.Name == "" || peer.URL == "" || peer.PublicKey == "" {
+			return nil, fmt.Errorf("invalid FEDERATION_PEERS: peer #%d needs name, url and public_key", i+1)
+		}
+		key, err := ParsePublicKey(peer.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FEDERATION_PEERS: peer %q: %w", peer.Name, err)
+		}
+		peer.Key = key
+		if _, ok := seen[peer.Name]; ok {
+			return nil, fmt.Errorf("invalid FEDERATION_PEERS: duplicate peer %q", peer.Name)
+		}
+		seen[peer.Name] = struct{}{}
+
+		switch peer.Trust {
+		case "":
+			peer.Trust = TrustWarn
+		case TrustBlock, TrustWarn, TrustIgnore:
+		default:
+			return nil, fmt.Errorf("invalid FEDERATION_PEERS: unknown trust %q for peer %q", peer.Trust, peer.Name)
+		}
+	}
+
+	return peers, nil
+}
+
+// SetConfig заменяет текущую конфигурацию федерации
+func SetConfig(cfg Config) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	config = cfg
+}
+
+// CurrentConfig возвращает текущую конфигурацию федерации
+func CurrentConfig() Config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return config
+}
+
+// FindPeer ищет партнёра по имени
+func FindPeer(name string) (Peer, bool) {
+	for _, peer := range CurrentConfig().Peers {
+		if peer.Name == name {
+			return peer, true
+		}
+	}
+	return Peer{}, false
+}
@@ -0,0 +1,220 @@
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// maxFeedSize ограничивает размер одной страницы фида (10 МБ)
+	maxFeedSize = 10 << 20
+	// maxPagesPerSync защищает от бесконечной пагинации у некорректного партнёра
+	maxPagesPerSync = 100
+)
+
+// Fetch запрашивает одну страницу фида партнёра, подписав запрос нашим ключом,
+// и проверяет подпись и свежесть ответа ключом партнёра
+func Fetch(ctx context.Context, client *http.Client, peer Peer, since string) (*Feed, error) {
+	feedURL, err := url.Parse(peer.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer url: %w", err)
+	}
+	if since != "" {
+		query := feedURL.Query()
+		query.Set("since", since)
+		feedURL.RawQuery = query.Encode()
+	}
+
+	cfg := CurrentConfig()
+	if cfg.PrivateKey == nil {
+		return nil, fmt.Errorf("federation private key is not configured")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SignRequest(req, cfg.Source, cfg.PrivateKey, time.Now())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxFeedSize {
+		return nil, fmt.Errorf("feed page exceeds %d bytes", maxFeedSize)
+	}
+
+	if err := VerifyFeed(peer.Key, body, resp.Header.Get(TimestampHeader), resp.Header.Get(SignatureHeader), time.Now()); err != nil {
+		return nil, fmt.Errorf("feed of %s: %w", peer.Name, err)
+	}
+
+	var feed Feed
+	if err := json.Unmarshal(body, &feed); err != nil {
+		return nil, fmt.Errorf("invalid feed body: %w", err)
+	}
+	if feed.Source != peer.Name {
+		return nil, fmt.Errorf("feed source %q does not match peer %q", feed.Source, peer.Name)
+	}
+	return &feed, nil
+}
+
+// SyncPeer забирает все новые страницы фида партнёра начиная с сохранённого курсора.
+// Возвращает количество применённых записей.
+func SyncPeer(ctx context.Context, client *http.Client, peer Peer) (int, error) {
+	var state models.FederationPeerState
+	if err := db.DB.Where("peer = ?", peer.Name).First(&state).Error; err != nil && err != gorm.ErrRecordNotFound {
+		return 0, err
+	}
+
+	since := state.Cursor
+	applied := 0
+	for page := 0; page < maxPagesPerSync; page++ {
+		feed, err := Fetch(ctx, client, peer, since)
+		if err != nil {
+			saveState(peer.Name, since, err)
+			return applied, err
+		}
+
+		if err := applyEntries(peer.Name, feed.Entries); err != nil {
+			saveState(peer.Name, since, err)
+			return applied, err
+		}
+		applied += len(feed.Entries)
+
+		if feed.Next != "" {
+			since = feed.Next
+		}
+		if !feed.HasMore || len(feed.Entries) == 0 {
+			break
+		}
+	}
+
+	saveState(peer.Name, since, nil)
+	return applied, nil
+}
+
+func applyEntries(peerName string, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		for _, entry := range entries {
+			username := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(entry.Username), "@"))
+			if username == "" || len(username) > 64 {
+				continue
+			}
+
+			if entry.Removed {
+				if err := tx.Where("peer = ? AND username = ?", peerName, username).
+					Delete(&models.PeerBlacklistEntry{}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+
+			reason := entry.Reason
+			if runes := []rune(reason); len(runes) > 512 {
+				reason = string(runes[:512])
+			}
+
+			record := models.PeerBlacklistEntry{
+				Peer:     peerName,
+				Username: username,
+				Reason:   reason,
+				AddedAt:  entry.AddedAt,
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "peer"}, {Name: "username"}},
+				DoUpdates: clause.AssignmentColumns([]string{"reason", "added_at", "updated_at"}),
+			}).Create(&record).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func saveState(peerName, cursor string, syncErr error) {
+	state := models.FederationPeerState{
+		Peer:       peerName,
+		Cursor:     cursor,
+		LastSyncAt: time.Now(),
+	}
+	if syncErr != nil {
+		state.LastError = syncErr.Error()
+		if runes := []rune(state.LastError); len(runes) > 512 {
+			state.LastError = string(runes[:512])
+		}
+	}
+
+	if err := db.DB.Save(&state).Error; err != nil {
+		log.Printf("federation: failed to save state for peer %s: %v", peerName, err)
+	}
+}
+
+// RunSubscriber периодически синхронизирует фиды всех настроенных партнёров,
+// пока не будет отменён ctx
+func RunSubscriber(ctx context.Context) {
+	cfg := CurrentConfig()
+	if len(cfg.Peers) == 0 {
+		return
+	}
+
+	log.Printf("Federation subscriber started for %d peers, interval %s", len(cfg.Peers), cfg.SyncInterval)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	syncAll(ctx, client, cfg.Peers)
+
+	ticker := time.NewTicker(cfg.SyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			syncAll(ctx, client, CurrentConfig().Peers)
+		}
+	}
+}
+
+func syncAll(ctx context.Context, client *http.Client, peers []Peer) {
+	for _, peer := range peers {
+		if ctx.Err() != nil {
+			return
+		}
+		peerCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+		applied, err := SyncPeer(peerCtx, client, peer)
+		cancel()
+		if err != nil {
+			log.Printf("federation: sync with %s failed after %d entries: %v", peer.Name, applied, err)
+			continue
+		}
+		if applied > 0 {
+			log.Printf("federation: applied %d entries from %s", applied, peer.Name)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package federation
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/db/dbtest"
+	"youtube-market/internal/models"
+)
+
+// fakePeer — партнёрская биржа в httptest: проверяет подпись нашего запроса
+// и отдаёт страницы фида, подписанные ключом партнёра
+type fakePeer struct {
+	key   ed25519.PrivateKey
+	pages map[string]Feed
+	// tamper меняет тело после подписи
+	tamper bool
+	// clock — время, которым партнёр подписывает ответ
+	clock func() time.Time
+}
+
+func (p *fakePeer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, err := Authenticate(r, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	body, _ := json.Marshal(p.pages[r.URL.Query().Get("since")])
+	now := time.Now()
+	if p.clock != nil {
+		now = p.clock()
+	}
+	timestamp, signature := SignFeed(p.key, body, now)
+	if p.tamper {
+		body, _ = json.Marshal(Feed{Source: "partner", Entries: []Entry{{Username: "innocent", UpdatedAt: now}}})
+	}
+	w.Header().Set(TimestampHeader, timestamp)
+	w.Header().Set(SignatureHeader, signature)
+	w.Write(body)
+}
+
+// startPeer поднимает партнёра и настраивает федерацию так, как будто обе биржи знают ключи друг друга
+func startPeer(t *testing.T, peer *fakePeer) Peer {
+	t.Helper()
+
+	server := httptest.NewServer(peer)
+	t.Cleanup(server.Close)
+
+	ours := testKey(10)
+	cfg := Peer{Name: "partner", URL: server.URL + "/api/federation/blacklist", Trust: TrustWarn, Key: peer.key.Public().(ed25519.PublicKey)}
+	SetConfig(Config{
+		Source:     "ours",
+		PrivateKey: ours,
+		// партнёр проверяет наш запрос по той же конфигурации: в ней мы — пир "ours"
+		Peers: []Peer{cfg, {Name: "ours", Key: ours.Public().(ed25519.PublicKey)}},
+	})
+	t.Cleanup(func() { SetConfig(Config{}) })
+	return cfg
+}
+
+func TestFetch(t *testing.T) {
+	now := time.Now().UTC()
+	page := Feed{Source: "partner", Entries: []Entry{{Username: "scammer", Reason: "fraud", UpdatedAt: now}}}
+
+	t.Run("valid signature", func(t *testing.T) {
+		peer := startPeer(t, &fakePeer{key: testKey(11), pages: map[string]Feed{"": page}})
+		feed, err := Fetch(context.Background(), http.DefaultClient, peer, "")
+		if err != nil {
+			t.Fatalf("Fetch: %v", err)
+		}
+		if len(feed.Entries) != 1 || feed.Entries[0].Username != "scammer" {
+			t.Fatalf("unexpected entries: %+v", feed.Entries)
+		}
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		peer := startPeer(t, &fakePeer{key: testKey(11), pages: map[string]Feed{"": page}, tamper: true})
+		if _, err := Fetch(context.Background(), http.DefaultClient, peer, ""); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("got %v, want ErrInvalidSignature", err)
+		}
+	})
+
+	t.Run("signed by another peer", func(t *testing.T) {
+		peer := startPeer(t, &fakePeer{key: testKey(11), pages: map[string]Feed{"": page}})
+		peer.Key = testKey(12).Public().(ed25519.PublicKey)
+		if _, err := Fetch(context.Background(), http.DefaultClient, peer, ""); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("got %v, want ErrInvalidSignature", err)
+		}
+	})
+
+	t.Run("stale timestamp", func(t *testing.T) {
+		stale := func() time.Time { return time.Now().Add(-time.Hour) }
+		peer := startPeer(t, &fakePeer{key: testKey(11), pages: map[string]Feed{"": page}, clock: stale})
+		if _, err := Fetch(context.Background(), http.DefaultClient, peer, ""); !errors.Is(err, ErrStaleSignature) {
+			t.Fatalf("got %v, want ErrStaleSignature", err)
+		}
+	})
+
+	t.Run("source mismatch", func(t *testing.T) {
+		foreign := Feed{Source: "someone-else", Entries: page.Entries}
+		peer := startPeer(t, &fakePeer{key: testKey(11), pages: map[string]Feed{"": foreign}})
+		if _, err := Fetch(context.Background(), http.DefaultClient, peer, ""); err == nil {
+			t.Fatal("feed of another source accepted")
+		}
+	})
+
+	t.Run("peer unavailable", func(t *testing.T) {
+		peer := startPeer(t, &fakePeer{key: testKey(11)})
+		server := httptest.NewServer(http.NotFoundHandler())
+		peer.URL = server.URL
+		server.Close()
+		if _, err := Fetch(context.Background(), http.DefaultClient, peer, ""); err == nil {
+			t.Fatal("Fetch from a stopped server succeeded")
+		}
+	})
+}
+
+func TestSyncPeer(t *testing.T) {
+	dbtest.Open(t, "peer_blacklist_entries", "federation_peer_states")
+
+	now := time.Now().UTC()
+	first := EncodeCursor(Cursor{UpdatedAt: now, ID: 1})
+	second := EncodeCursor(Cursor{UpdatedAt: now, ID: 2})
+	peer := startPeer(t, &fakePeer{key: testKey(11), pages: map[string]Feed{
+		"": {Source: "partner", Next: first, HasMore: true, Entries: []Entry{
+			{Username: "@Scammer", Reason: "fraud", UpdatedAt: now},
+			{Username: "rehabilitated", UpdatedAt: now},
+		}},
+		first: {Source: "partner", Next: second, Entries: []Entry{
+			{Username: "rehabilitated", Removed: true, UpdatedAt: now},
+		}},
+	}})
+
+	applied, err := SyncPeer(context.Background(), http.DefaultClient, peer)
+	if err != nil {
+		t.Fatalf("SyncPeer: %v", err)
+	}
+	if applied != 3 {
+		t.Fatalf("applied %d entries, want 3", applied)
+	}
+
+	var entries []models.PeerBlacklistEntry
+	db.DB.Where("peer = ?", "partner").Order("username").Find(&entries)
+	if len(entries) != 1 || entries[0].Username != "scammer" || entries[0].Reason != "fraud" {
+		t.Fatalf("unexpected peer entries: %+v", entries)
+	}
+
+	var state models.FederationPeerState
+	db.DB.Where("peer = ?", "partner").First(&state)
+	if state.Cursor != second || state.LastError != "" {
+		t.Fatalf("unexpected state: cursor %q, error %q", state.Cursor, state.LastError)
+	}
+}
+
+func TestSyncPeerKeepsCursorOnInvalidSignature(t *testing.T) {
+	dbtest.Open(t, "peer_blacklist_entries", "federation_peer_states")
+
+	peer := startPeer(t, &fakePeer{key: testKey(11), tamper: true, pages: map[string]Feed{
+		"": {Source: "partner", Entries: []Entry{{Username: "scammer", UpdatedAt: time.Now()}}},
+	}})
+
+	if _, err := SyncPeer(context.Background(), http.DefaultClient, peer); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("got %v, want ErrInvalidSignature", err)
+	}
+
+	var count int64
+	db.DB.Model(&models.PeerBlacklistEntry{}).Where("peer = ?", "partner").Count(&count)
+	if count != 0 {
+		t.Fatalf("tampered feed applied %d entries", count)
+	}
+
+	var state models.FederationPeerState
+	db.DB.Where("peer = ?", "partner").First(&state)
+	if state.Cursor != "" || state.LastError == "" {
+		t.Fatalf("unexpected state: cursor %q, error %q", state.Cursor, state.LastError)
+	}
+}
@@ -15,13 +15,13 @@ import (
 func GetAdPhoto(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ad id"})
+		respondError(c, http.StatusBadRequest, "error.invalid_ad_id")
 		return
 	}
 
 	var ad models.Ad
 	if err := db.DB.First(&ad, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "ad not found"})
+		respondError(c, http.StatusNotFound, "error.ad_not_found")
 		return
 	}
 
@@ -42,7 +42,7 @@ func GetAdPhoto(c *gin.Context) {
 		if resp != nil {
 			resp.Body.Close()
 		}
-		c.JSON(http.StatusBadGateway, gin.H{"error": "failed to fetch photo"})
+		respondError(c, http.StatusBadGateway, "error.photo_unavailable")
 		return
 	}
 	defer resp.Body.Close()
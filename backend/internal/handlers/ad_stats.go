@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"youtube-market/internal/analytics"
+	"youtube-market/internal/db"
+	"youtube-market/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	// adStatsRecordTimeout ограничивает запись события, чтобы медленный Redis не копил горутины
+	adStatsRecordTimeout = 2 * time.Second
+	// adStatsRecentDays — период «за последние дни» в статистике владельца
+	adStatsRecentDays = 7
+)
+
+// AdStatsView — статистика объявления для владельца
+type AdStatsView struct {
+	Total    analytics.Totals `json:"total"`
+	LastWeek analytics.Totals `json:"last_7_days"`
+}
+
+// recordAdEvent учитывает событие в фоне, не задерживая ответ
+func recordAdEvent(viewerID int64, event string, adIDs ...uint) {
+	if viewerID == 0 || len(adIDs) == 0 || !analytics.Enabled() {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), adStatsRecordTimeout)
+		defer cancel()
+		if err := analytics.Record(ctx, viewerID, event, adIDs...); err != nil {
+			log.Printf("analytics: failed to record %s for %d ads: %v", event, len(adIDs), err)
+		}
+	}()
+}
+
+// recordImpressions учитывает показ объявлений в ленте
+func recordImpressions(viewerID int64, ads []models.Ad) {
+	adIDs := make([]uint, 0, len(ads))
+	for _, ad := range ads {
+		adIDs = append(adIDs, ad.ID)
+	}
+	recordAdEvent(viewerID, analytics.EventImpression, adIDs...)
+}
+
+// attachAdStats добавляет статистику к объявлениям владельца
+func attachAdStats(views []AdView) {
+	adIDs := make([]uint, 0, len(views))
+	for _, view := range views {
+		adIDs = append(adIDs, view.ID)
+	}
+
+	total, err := analytics.ForAds(adIDs, time.Time{})
+	if err != nil {
+		log.Printf("analytics: failed to load ad totals: %v", err)
+		return
+	}
+	recent, err := analytics.ForAds(adIDs, analytics.Since(adStatsRecentDays))
+	if err != nil {
+		log.Printf("analytics: failed to load recent ad totals: %v", err)
+		return
+	}
+
+	for i := range views {
+		views[i].Stats = &AdStatsView{Total: total[views[i].ID], LastWeek: recent[views[i].ID]}
+	}
+}
+
+// loadViewedAd загружает объявление для просмотра из Mini App
+func loadViewedAd(c *gin.Context) (models.Ad, bool) {
+	var ad models.Ad
+	adID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "error.invalid_ad_id")
+		return ad, false
+	}
+	if err := withOwner(db.DB).First(&ad, adID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(c, http.StatusNotFound, "error.ad_not_found")
+		} else {
+			respondError(c, http.StatusInternalServerError, "error.ads_unavailable")
+		}
+		return ad, false
+	}
+	return ad, true
+}
+
+// GetAd отдаёт объявление и учитывает его открытие (GET /api/ads/:id).
+// Снятое с биржи объявление видит только владелец. Открытия владельцем не учитываются.
+func GetAd(c *gin.Context) {
+	ad, ok := loadViewedAd(c)
+	if !ok {
+		return
+	}
+	if ad.Status == models.AdStatusActive && ad.ExpiresAt.Before(time.Now()) {
+		ad.Status = models.AdStatusExpired
+	}
+
+	userID, _ := contextUserID(c)
+	owner := isAdOwner(ad, userID)
+	if ad.Status == models.AdStatusInactive && !owner {
+		respondError(c, http.StatusNotFound, "error.ad_not_found")
+		return
+	}
+	if !owner {
+		recordAdEvent(userID, analytics.EventView, ad.ID)
+	}
+	c.JSON(http.StatusOK, buildAdViews([]models.Ad{ad}, userID)[0])
+}
+
+// RecordContactClick учитывает нажатие «Связаться» (POST /api/ads/:id/contact)
+func RecordContactClick(c *gin.Context) {
+	ad, ok := loadViewedAd(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := contextUserID(c)
+	owner := isAdOwner(ad, userID)
+	if ad.Status == models.AdStatusInactive && !owner {
+		respondError(c, http.StatusNotFound, "error.ad_not_found")
+		return
+	}
+	if !owner {
+		recordAdEvent(userID, analytics.EventContact, ad.ID)
+	}
+	c.Status(http.StatusNoContent)
+}
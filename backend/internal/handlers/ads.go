@@ -3,7 +3,6 @@ package handlers
 import (
 	"log"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +13,7 @@ import (
 	"gorm.io/gorm"
 )
 
+// maxPremiumActiveAds — число премиум-мест в категории по умолчанию (см. premiumSlots)
 const maxPremiumActiveAds = 3
 
 func GetAds(c *gin.Context) {
@@ -42,11 +42,11 @@ func GetAds(c *gin.Context) {
 		}
 	}
 
-	filteredQuery = filteredQuery.Order("is_premium DESC, updated_at DESC")
+	filteredQuery = filteredQuery.Order(feedOrder)
 
 	var filtered []models.Ad
 	if err := filteredQuery.Find(&filtered).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch ads"})
+		respondError(c, http.StatusInternalServerError, "error.ads_unavailable")
 		return
 	}
 
@@ -65,10 +65,10 @@ func GetAds(c *gin.Context) {
 		premiumQuery = premiumQuery.Where("tag = ?", tag)
 	}
 
-	premiumQuery = premiumQuery.Order("updated_at DESC")
+	premiumQuery = premiumQuery.Order(feedOrder)
 
 	if err := premiumQuery.Find(&premium).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch premium ads"})
+		respondError(c, http.StatusInternalServerError, "error.ads_unavailable")
 		return
 	}
 
@@ -77,57 +77,47 @@ func GetAds(c *gin.Context) {
 	log.Printf("GetAds: category=%s, mode=%s, tag=%s, найдено filtered=%d, premium=%d, combined=%d",
 		category, mode, tag, len(filtered), len(premium), len(combined))
 
-	response := make([]AdView, 0, len(combined))
-	for _, ad := range combined {
-		response = append(response, buildAdView(ad))
-	}
-
-	c.JSON(http.StatusOK, response)
+	userID, _ := contextUserID(c)
+	recordImpressions(userID, combined)
+	c.JSON(http.StatusOK, buildAdViews(combined, userID))
 }
 
+// GetMyAds отдаёт объявления текущего пользователя. Пользователь определяется только
+// по init_data (TMAuthMiddleware), параметр user_id из запроса не учитывается.
 func GetMyAds(c *gin.Context) {
-	userIDStr := c.Query("user_id")
-	log.Printf("GetMyAds: получен запрос с user_id=%s", userIDStr)
-	if userIDStr == "" {
-		log.Printf("GetMyAds: ошибка - user_id не указан")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id parameter is required"})
+	userID, ok := contextUserID(c)
+	if !ok {
+		respondError(c, http.StatusUnauthorized, "error.auth_required")
 		return
 	}
 
-	// Ищем объявления по ClientID (который менеджер вводит во время создания объявления)
-	// ClientID совпадает с user_id из Telegram
-	// Также ищем по UserID на случай если client_id не совпадает
 	var ads []models.Ad
-	query := db.DB.Where("client_id = ?", userIDStr)
-
-	// Также пробуем найти по user_id (на случай если client_id не установлен правильно)
-	userIDInt, err := strconv.ParseInt(userIDStr, 10, 64)
-	if err == nil {
-		query = query.Or("user_id = ?", userIDInt)
-	}
-
-	if err := query.
-		Order(gorm.Expr("CASE WHEN status = ? THEN 0 WHEN status = ? THEN 1 ELSE 2 END, updated_at DESC", models.AdStatusActive, models.AdStatusExpired)).
-		Find(&ads).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch ads"})
+	if err := myAdsQuery(userID).Find(&ads).Error; err != nil {
+		respondError(c, http.StatusInternalServerError, "error.ads_unavailable")
 		return
 	}
 
-	log.Printf("GetMyAds: найдено %d объявлений для user_id=%s", len(ads), userIDStr)
-	for _, ad := range ads {
-		log.Printf("  - Ad ID=%d, ClientID=%s, UserID=%d, Status=%s", ad.ID, ad.ClientID, ad.UserID, ad.Status)
-	}
+	log.Printf("GetMyAds: найдено %d объявлений для user_id=%d", len(ads), userID)
 
-	response := make([]AdView, 0, len(ads))
-	for _, ad := range ads {
-		response = append(response, buildAdView(ad))
-	}
+	views := buildAdViews(ads, userID)
+	attachAdStats(views)
+	c.JSON(http.StatusOK, views)
+}
+
+// myAdsQuery — объявления пользователя: сначала активные, затем истёкшие и снятые.
+// Его же показывает раздел «Мои объявления» в боте.
+func myAdsQuery(telegramID int64) *gorm.DB {
+	return ownedBy(db.DB, telegramID).
+		Order(gorm.Expr("CASE WHEN status = ? THEN 0 WHEN status = ? THEN 1 ELSE 2 END, updated_at DESC", models.AdStatusActive, models.AdStatusExpired))
+}
 
-	c.JSON(http.StatusOK, response)
+// activePremiumCount считает занятые премиум-места в категории
+func activePremiumCount(category string, excludeID *uint) (int64, error) {
+	return activePremiumCountTx(db.DB, category, excludeID)
 }
 
-func activePremiumCount(excludeID *uint) (int64, error) {
-	query := db.DB.Model(&models.Ad{}).Where("status = ? AND is_premium = ? AND expires_at > ?", models.AdStatusActive, true, time.Now())
+func activePremiumCountTx(tx *gorm.DB, category string, excludeID *uint) (int64, error) {
+	query := tx.Model(&models.Ad{}).Where("status = ? AND is_premium = ? AND expires_at > ? AND category = ?", models.AdStatusActive, true, time.Now(), category)
 	if excludeID != nil {
 		query = query.Where("id <> ?", *excludeID)
 	}
@@ -136,3 +126,11 @@ func activePremiumCount(excludeID *uint) (int64, error) {
 	err := query.Count(&count).Error
 	return count, err
 }
+
+// isAdOwner проверяет, что объявление принадлежит пользователю с указанным Telegram ID
+func isAdOwner(ad models.Ad, userID int64) bool {
+	if userID == 0 {
+		return false
+	}
+	return ownerTelegramID(ad) == userID
+}
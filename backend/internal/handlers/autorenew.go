@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// autoRenewActive — объявление будет продлено автоматически, когда истечёт срок at
+func autoRenewActive(ad models.Ad, at time.Time) bool {
+	if ad.AutoRenewDays <= 0 {
+		return false
+	}
+	if ad.AutoRenewLeft != nil && *ad.AutoRenewLeft <= 0 {
+		return false
+	}
+	if ad.AutoRenewUntil != nil && !at.Before(*ad.AutoRenewUntil) {
+		return false
+	}
+	return true
+}
+
+// autoRenewLabel описывает настройку автопродления для менеджера и владельца на языке locale
+func autoRenewLabel(locale string, ad models.Ad) string {
+	if ad.AutoRenewDays <= 0 {
+		return i18n.T(locale, "manager.auto_renew.off")
+	}
+	label := i18n.T(locale, "manager.auto_renew.every", formatDays(locale, ad.AutoRenewDays))
+	switch {
+	case ad.AutoRenewLeft != nil:
+		label += i18n.N(locale, "manager.auto_renew.left", *ad.AutoRenewLeft, *ad.AutoRenewLeft)
+	case ad.AutoRenewUntil != nil:
+		label += i18n.T(locale, "manager.auto_renew.until", formatDate(locale, *ad.AutoRenewUntil))
+	}
+	return label
+}
+
+// clearAutoRenew выключает автопродление
+func clearAutoRenew(ad *models.Ad) {
+	ad.AutoRenewDays = 0
+	ad.AutoRenewUntil = nil
+	ad.AutoRenewLeft = nil
+}
+
+// autoRenewAd продлевает истёкшее объявление на период автопродления и записывает
+// продление в историю. Если лимит продлений исчерпан, автопродление выключается и
+// возвращается false — объявление истекает как обычно.
+func autoRenewAd(adID uint, now time.Time) (models.Ad, bool, error) {
+	var ad models.Ad
+	renewed := false
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ad, adID).Error; err != nil {
+			return err
+		}
+		if ad.Status != models.AdStatusActive || ad.ExpiresAt.After(now) || ad.AutoRenewDays <= 0 {
+			return nil
+		}
+
+		if !autoRenewActive(ad, ad.ExpiresAt) {
+			clearAutoRenew(&ad)
+			return tx.Model(&ad).Updates(map[string]interface{}{
+				"auto_renew_days":  0,
+				"auto_renew_until": nil,
+				"auto_renew_left":  nil,
+			}).Error
+		}
+
+		// Продлеваем от прежнего срока, чтобы не сбивать расписание; если приложение
+		// было остановлено дольше периода, догоняем текущий момент
+		period := time.Duration(ad.AutoRenewDays) * 24 * time.Hour
+		expiresAt := ad.ExpiresAt.Add(period)
+		for !expiresAt.After(now) {
+			expiresAt = expiresAt.Add(period)
+		}
+
+		updates := map[string]interface{}{"expires_at": expiresAt}
+		if ad.AutoRenewLeft != nil {
+			left := *ad.AutoRenewLeft - 1
+			ad.AutoRenewLeft = &left
+			updates["auto_renew_left"] = left
+		}
+		if err := tx.Model(&ad).Updates(updates).Error; err != nil {
+			return err
+		}
+		ad.ExpiresAt = expiresAt
+		renewed = true
+
+		// Автопродление выполняет система, поэтому пользователь не указывается
+		return recordRenewal(tx, ad, 0, ad.AutoRenewDays, models.RenewalSourceAuto)
+	})
+	if err == nil && renewed {
+		observeRenewal(models.RenewalSourceAuto)
+	}
+	return ad, renewed, err
+}
@@ -1,43 +1,137 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"youtube-market/internal/db"
+	"youtube-market/internal/federation"
+	"youtube-market/internal/i18n"
 	"youtube-market/internal/models"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func CheckScammer(c *gin.Context) {
-	username := strings.TrimSpace(strings.TrimPrefix(c.Param("username"), "@"))
-	if username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "username parameter is required"})
-		return
+// partnerFlag — отметка пользователя в чёрном списке партнёрской биржи
+type partnerFlag struct {
+	Peer   string `json:"peer"`
+	Trust  string `json:"trust"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// scamCheckResult — результат проверки пользователя по собственному и партнёрским спискам
+type scamCheckResult struct {
+	Username  string
+	Listed    bool
+	Reason    string
+	Partners  []partnerFlag
+	Blocked   bool // хотя бы один партнёр с доверием block
+	Warning   bool // только отметки партнёров с доверием warn
+	CheckedAt time.Time
+}
+
+// Safe сообщает, можно ли считать пользователя безопасным
+func (r scamCheckResult) Safe() bool {
+	return !r.Listed && !r.Blocked
+}
+
+// Message возвращает текст результата для показа пользователю на языке locale
+func (r scamCheckResult) Message(locale string) string {
+	switch {
+	case r.Listed:
+		return i18n.T(locale, "scam.listed")
+	case r.Blocked:
+		return i18n.T(locale, "scam.blocked", r.partnerNames(federation.TrustBlock))
+	case r.Warning:
+		return i18n.T(locale, "scam.warned", r.partnerNames(federation.TrustWarn))
+	default:
+		return i18n.T(locale, "scam.clean")
 	}
+}
+
+func (r scamCheckResult) partnerNames(trust string) string {
+	names := make([]string, 0, len(r.Partners))
+	for _, flag := range r.Partners {
+		if flag.Trust == trust {
+			names = append(names, flag.Peer)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// checkScamStatus проверяет username по собственному чёрному списку и спискам партнёров
+func checkScamStatus(username string) (scamCheckResult, error) {
+	result := scamCheckResult{Username: username, CheckedAt: time.Now()}
 
 	var user models.User
 	err := db.DB.Where("LOWER(username) = LOWER(?) AND is_scammer = true", username).First(&user).Error
+	if err == nil {
+		result.Listed = true
+		result.Reason = user.ScamReason
+		return result, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return result, err
+	}
 
-	if err == gorm.ErrRecordNotFound {
-		c.JSON(http.StatusOK, gin.H{
-			"safe": true,
-			"msg":  "Юзер не был замечен в мошеннических схемах",
+	var entries []models.PeerBlacklistEntry
+	if err := db.DB.Where("username = ?", strings.ToLower(username)).Order("peer ASC").Find(&entries).Error; err != nil {
+		return result, err
+	}
+
+	for _, entry := range entries {
+		peer, ok := federation.FindPeer(entry.Peer)
+		if !ok || peer.Trust == federation.TrustIgnore {
+			continue
+		}
+		result.Partners = append(result.Partners, partnerFlag{
+			Peer:   peer.Label(),
+			Trust:  peer.Trust,
+			Reason: entry.Reason,
 		})
+		switch peer.Trust {
+		case federation.TrustBlock:
+			result.Blocked = true
+		case federation.TrustWarn:
+			result.Warning = true
+		}
+	}
+	if result.Blocked {
+		result.Warning = false
+	}
+
+	return result, nil
+}
+
+func CheckScammer(c *gin.Context) {
+	username := strings.TrimSpace(strings.TrimPrefix(c.Param("username"), "@"))
+	if username == "" {
+		respondError(c, http.StatusBadRequest, "error.username_required")
 		return
 	}
 
+	result, err := checkScamStatus(username)
+	observeScamCheck(scamCheckSourceAPI, result, err)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check user"})
+		respondError(c, http.StatusInternalServerError, "error.check_failed")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"safe": false,
-		"msg":  "Осторожно! Мошенник",
-	})
+	response := gin.H{
+		"safe": result.Safe(),
+		"msg":  result.Message(contextLocale(c)),
+	}
+	if result.Warning {
+		response["warning"] = true
+	}
+	if len(result.Partners) > 0 {
+		response["flagged_by"] = result.Partners
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 func GetBlacklist(c *gin.Context) {
@@ -46,18 +140,86 @@ func GetBlacklist(c *gin.Context) {
 		Where("is_scammer = ?", true).
 		Order("username ASC").
 		Find(&scammers).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load blacklist"})
+		respondError(c, http.StatusInternalServerError, "error.blacklist_unavailable")
 		return
 	}
 
 	response := make([]gin.H, 0, len(scammers))
 	for _, user := range scammers {
 		response = append(response, gin.H{
-			"username":   user.Username,
-			"created_at": user.CreatedAt,
-			"updated_at": user.UpdatedAt,
+			"username":       user.Username,
+			"reason":         user.ScamReason,
+			"blacklisted_at": user.BlacklistedAt,
+			"created_at":     user.CreatedAt,
+			"updated_at":     user.UpdatedAt,
 		})
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// addToBlacklist помечает пользователя как мошенника, создавая запись при необходимости.
+// Пустая причина не затирает уже сохранённую.
+func addToBlacklist(tx *gorm.DB, username, reason string, addedAt time.Time) error {
+	var user models.User
+	err := tx.Where("LOWER(username) = LOWER(?)", username).First(&user).Error
+	if err == gorm.ErrRecordNotFound {
+		return tx.Create(&models.User{
+			Username:      username,
+			IsScammer:     true,
+			ScamReason:    reason,
+			BlacklistedAt: &addedAt,
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{"is_scammer": true}
+	if reason != "" {
+		updates["scam_reason"] = reason
+	}
+	if !user.IsScammer || user.BlacklistedAt == nil {
+		updates["blacklisted_at"] = addedAt
+	}
+	return tx.Model(&user).Updates(updates).Error
+}
+
+// checkScamStatusByTelegram проверяет участника чата по Telegram ID и username.
+// При совпадении по username запоминает Telegram ID, чтобы узнать мошенника и после смены username.
+func checkScamStatusByTelegram(telegramID int64, username string) (scamCheckResult, error) {
+	if telegramID != 0 {
+		var user models.User
+		err := db.DB.Where("telegram_id = ? AND is_scammer = ?", telegramID, true).First(&user).Error
+		if err == nil {
+			return scamCheckResult{
+				Username:  user.Username,
+				Listed:    true,
+				Reason:    user.ScamReason,
+				CheckedAt: time.Now(),
+			}, nil
+		}
+		if err != gorm.ErrRecordNotFound {
+			return scamCheckResult{}, err
+		}
+	}
+
+	if username == "" {
+		return scamCheckResult{CheckedAt: time.Now()}, nil
+	}
+
+	result, err := checkScamStatus(username)
+	if err != nil {
+		return result, err
+	}
+
+	if result.Listed && telegramID != 0 {
+		if err := db.DB.Model(&models.User{}).
+			Where("LOWER(username) = LOWER(?) AND telegram_id IS NULL", username).
+			Update("telegram_id", telegramID).Error; err != nil {
+			log.Printf("failed to remember telegram_id %d for @%s: %v", telegramID, username, err)
+		}
+	}
+
+	return result, nil
+}
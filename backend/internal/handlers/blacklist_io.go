@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxBlacklistImportSize ограничивает размер загружаемого файла (2 МБ)
+const maxBlacklistImportSize = 2 << 20
+
+// usernamePattern — username Telegram: от 5 до 32 латинских букв, цифр и подчёркиваний
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_]{5,32}$`)
+
+// blacklistRecord — одна запись чёрного списка при импорте/экспорте
+type blacklistRecord struct {
+	Username string     `json:"username"`
+	Reason   string     `json:"reason,omitempty"`
+	AddedAt  *time.Time `json:"added_at,omitempty"`
+}
+
+// blacklistImportDiff — результат пробного импорта (dry-run)
+type blacklistImportDiff struct {
+	New      []blacklistRecord
+	Existing []blacklistRecord
+	Invalid  []string
+}
+
+// parseBlacklistFile разбирает CSV или JSON. Формат определяется по расширению,
+// а если его нет — по первому непробельному символу.
+func parseBlacklistFile(name string, data []byte) ([]blacklistRecord, []string, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return parseBlacklistJSON(data)
+	case ".csv", ".txt":
+		return parseBlacklistCSV(data)
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return parseBlacklistJSON(data)
+	}
+	return parseBlacklistCSV(data)
+}
+
+// parseBlacklistCSV ожидает колонки username, reason, added_at (две последние необязательны).
+// Строка заголовка пропускается, если первая колонка называется "username".
+func parseBlacklistCSV(data []byte) ([]blacklistRecord, []string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	raw := make([]blacklistRecord, 0, len(rows))
+	var invalid []string
+	for i, row := range rows {
+		if len(row) == 0 || strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		if i == 0 && strings.EqualFold(strings.TrimSpace(row[0]), "username") {
+			continue
+		}
+
+		record := blacklistRecord{Username: row[0]}
+		if len(row) > 1 {
+			record.Reason = row[1]
+		}
+		if len(row) > 2 && strings.TrimSpace(row[2]) != "" {
+			addedAt, ok := parseBlacklistDate(row[2])
+			if !ok {
+				invalid = append(invalid, strings.Join(row, ","))
+				continue
+			}
+			record.AddedAt = &addedAt
+		}
+		raw = append(raw, record)
+	}
+
+	records, bad := normalizeBlacklistRecords(raw)
+	return records, append(invalid, bad...), nil
+}
+
+// parseBlacklistJSON принимает массив объектов {username, reason, added_at} или массив строк
+func parseBlacklistJSON(data []byte) ([]blacklistRecord, []string, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON: expected an array: %w", err)
+	}
+
+	raw := make([]blacklistRecord, 0, len(items))
+	var invalid []string
+	for _, item := range items {
+		var username string
+		if err := json.Unmarshal(item, &username); err == nil {
+			raw = append(raw, blacklistRecord{Username: username})
+			continue
+		}
+
+		var record blacklistRecord
+		if err := json.Unmarshal(item, &record); err != nil {
+			invalid = append(invalid, truncate(string(item), 64))
+			continue
+		}
+		raw = append(raw, record)
+	}
+
+	records, bad := normalizeBlacklistRecords(raw)
+	return records, append(invalid, bad...), nil
+}
+
+// normalizeBlacklistRecords приводит username к единому виду, отбрасывает
+// некорректные значения и дубликаты внутри файла
+func normalizeBlacklistRecords(raw []blacklistRecord) ([]blacklistRecord, []string) {
+	seen := make(map[string]struct{}, len(raw))
+	records := make([]blacklistRecord, 0, len(raw))
+	var invalid []string
+
+	for _, record := range raw {
+		username := normalizeUsername(record.Username)
+		if !usernamePattern.MatchString(username) {
+			invalid = append(invalid, record.Username)
+			continue
+		}
+
+		key := strings.ToLower(username)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		record.Username = username
+		record.Reason = truncate(strings.TrimSpace(record.Reason), 512)
+		records = append(records, record)
+	}
+
+	return records, invalid
+}
+
+func parseBlacklistDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339, "2006-01-02", "02.01.2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// diffBlacklist делит записи на новые и уже находящиеся в чёрном списке
+func diffBlacklist(records []blacklistRecord, invalid []string) (blacklistImportDiff, error) {
+	diff := blacklistImportDiff{Invalid: invalid}
+	if len(records) == 0 {
+		return diff, nil
+	}
+
+	usernames := make([]string, 0, len(records))
+	for _, record := range records {
+		usernames = append(usernames, strings.ToLower(record.Username))
+	}
+
+	var existing []string
+	if err := db.DB.Model(&models.User{}).
+		Where("is_scammer = ? AND LOWER(username) IN ?", true, usernames).
+		Pluck("LOWER(username)", &existing).Error; err != nil {
+		return diff, err
+	}
+
+	present := make(map[string]struct{}, len(existing))
+	for _, username := range existing {
+		present[username] = struct{}{}
+	}
+
+	for _, record := range records {
+		if _, ok := present[strings.ToLower(record.Username)]; ok {
+			diff.Existing = append(diff.Existing, record)
+		} else {
+			diff.New = append(diff.New, record)
+		}
+	}
+
+	return diff, nil
+}
+
+// applyBlacklistImport добавляет новые записи одной транзакцией
+func applyBlacklistImport(records []blacklistRecord) error {
+	now := time.Now()
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		for _, record := range records {
+			addedAt := now
+			if record.AddedAt != nil {
+				addedAt = *record.AddedAt
+			}
+			if err := addToBlacklist(tx, record.Username, record.Reason, addedAt); err != nil {
+				return fmt.Errorf("add @%s: %w", record.Username, err)
+			}
+		}
+		return nil
+	})
+}
+
+func loadBlacklistRecords() ([]blacklistRecord, error) {
+	var scammers []models.User
+	if err := db.DB.
+		Where("is_scammer = ?", true).
+		Order("username ASC").
+		Find(&scammers).Error; err != nil {
+		return nil, err
+	}
+
+	records := make([]blacklistRecord, 0, len(scammers))
+	for _, user := range scammers {
+		addedAt := user.BlacklistedAt
+		if addedAt == nil {
+			createdAt := user.CreatedAt
+			addedAt = &createdAt
+		}
+		records = append(records, blacklistRecord{
+			Username: user.Username,
+			Reason:   user.ScamReason,
+			AddedAt:  addedAt,
+		})
+	}
+	return records, nil
+}
+
+func writeBlacklistCSV(w io.Writer, records []blacklistRecord) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"username", "reason", "added_at"}); err != nil {
+		return err
+	}
+	for _, record := range records {
+		addedAt := ""
+		if record.AddedAt != nil {
+			addedAt = record.AddedAt.UTC().Format(time.RFC3339)
+		}
+		if err := writer.Write([]string{record.Username, record.Reason, addedAt}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportBlacklist отдаёт полный чёрный список менеджеру в формате csv или json (?format=)
+func ExportBlacklist(c *gin.Context) {
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	if format != "json" && format != "csv" {
+		respondError(c, http.StatusBadRequest, "error.export_format")
+		return
+	}
+
+	records, err := loadBlacklistRecords()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "error.blacklist_unavailable")
+		return
+	}
+
+	filename := fmt.Sprintf("blacklist-%s.%s", time.Now().Format("20060102"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if format == "json" {
+		c.JSON(http.StatusOK, records)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := writeBlacklistCSV(&buf, records); err != nil {
+		respondError(c, http.StatusInternalServerError, "error.export_failed")
+		return
+	}
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
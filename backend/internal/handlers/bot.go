@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -10,9 +13,13 @@ import (
 	"time"
 
 	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
 	"youtube-market/internal/models"
+	"youtube-market/internal/outbox"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
@@ -23,105 +30,6 @@ const (
 	sessionTimeoutDuration = 30 * time.Minute
 )
 
-// Русские названия для категорий
-var categoryLabels = map[string]string{
-	"services": "Услуги",
-	"buysell":  "Купля/Продажа",
-	"other":    "Другое",
-}
-
-var categoryValues = map[string]string{
-	"Услуги":        "services",
-	"Купля/Продажа": "buysell",
-	"Другое":        "other",
-}
-
-// Русские названия для режимов
-var modeLabels = map[string]map[string]string{
-	"services": {
-		"offer":  "Предлагаю услугу",
-		"search": "Ищу услугу",
-	},
-	"buysell": {
-		"sell": "Продаю",
-		"buy":  "Покупаю",
-	},
-	"other": {
-		"general": "Объявление",
-	},
-}
-
-var modeValues = map[string]map[string]string{
-	"services": {
-		"Предлагаю услугу": "offer",
-		"Ищу услугу":       "search",
-	},
-	"buysell": {
-		"Продаю":  "sell",
-		"Покупаю": "buy",
-	},
-	"other": {
-		"Объявление": "general",
-	},
-}
-
-// Русские названия для тегов
-var tagLabels = map[string]map[string]string{
-	"services": {
-		"all":      "Все",
-		"designer": "Дизайнер",
-		"script":   "Сценарист",
-		"voice":    "Озвучивание",
-		"other":    "Другое",
-	},
-	"buysell": {
-		"all":       "Все",
-		"konechka":  "Конечка",
-		"channel":   "Канал",
-		"video":     "Видео",
-		"adsense":   "Адсенс",
-		"templates": "Шаблоны",
-	},
-	"other": {
-		"all":       "Все",
-		"education": "Обучение",
-		"courses":   "Курсы",
-		"cheats":    "Читы",
-		"mods":      "Моды",
-		"niche":     "Ниша",
-		"schemes":   "Схемы",
-		"boost":     "Накрутка",
-	},
-}
-
-var tagValues = map[string]map[string]string{
-	"services": {
-		"Все":         "all",
-		"Дизайнер":    "designer",
-		"Сценарист":   "script",
-		"Озвучивание": "voice",
-		"Другое":      "other",
-	},
-	"buysell": {
-		"Все":     "all",
-		"Конечка": "konechka",
-		"Канал":   "channel",
-		"Видео":   "video",
-		"Адсенс":  "adsense",
-		"Шаблоны": "templates",
-	},
-	"other": {
-		"Все":      "all",
-		"Обучение": "education",
-		"Курсы":    "courses",
-		"Читы":     "cheats",
-		"Моды":     "mods",
-		"Ниша":     "niche",
-		"Схемы":    "schemes",
-		"Накрутка": "boost",
-	},
-}
-
 type conversationStage int
 
 const (
@@ -142,8 +50,20 @@ const (
 	stageAwaitBlacklistAction
 	stageAwaitBlacklistAdd
 	stageAwaitBlacklistRemove
+	stageAwaitBlacklistImport
+	stageAwaitPremiumStart
 	stageAwaitFindAdID
 	stageAwaitSelectAd
+	stageAwaitAutoRenew
+	stageAwaitAutoRenewUntil
+	stageAwaitPrice
+	stageAwaitBroadcastMessage
+	stageAwaitBroadcastButtons
+	// stageAwaitSettings — показан экран настроек объявления, ждём нажатия кнопки
+	stageAwaitSettings
+	// Стадии пользователя, который не является менеджером
+	stageAwaitUserCheck
+	stageAwaitAdRequest
 )
 
 type adOperation int
@@ -162,6 +82,18 @@ type adSession struct {
 	LastActivity  time.Time
 	ChatID        int64
 	BotMessageIDs []int // ID сообщений бота для удаления
+	// PendingBlacklist — записи импорта, ожидающие подтверждения
+	PendingBlacklist []blacklistRecord
+	// PremiumQueued — поставить объявление в очередь на премиум после сохранения
+	PremiumQueued bool
+	// PremiumEdited, AutoRenewEdited — менеджер менял премиум или автопродление в мастере
+	// редактирования; только тогда эти поля записываются при сохранении
+	PremiumEdited   bool
+	AutoRenewEdited bool
+	// PremiumStartsAt — начало бронируемого премиум-места
+	PremiumStartsAt time.Time
+	// Broadcast — черновик рассылки
+	Broadcast *broadcastDraft
 }
 
 var (
@@ -212,7 +144,35 @@ func isManager(userID int64, managerIDs []int64) bool {
 	return false
 }
 
-func RunManagerBot() {
+// IsManagerID проверяет пользователя по списку MANAGER_ID (для HTTP-эндпоинтов)
+func IsManagerID(userID int64) bool {
+	return isManager(userID, managerIDsFromEnv())
+}
+
+// managerIDsFromEnv возвращает MANAGER_ID для HTTP-обработчиков (пустой список, если не задан)
+func managerIDsFromEnv() []int64 {
+	managerIDs, err := parseManagerIDs(os.Getenv("MANAGER_ID"))
+	if err != nil {
+		return nil
+	}
+	return managerIDs
+}
+
+// newBotAPI создаёт клиент Bot API. Все запросы бота проходят через лимитер и повторы
+// при 429 и ошибках Telegram. TELEGRAM_API_ENDPOINT позволяет направить бота
+// на тестовый сервер Bot API (формат tgbotapi.APIEndpoint).
+func newBotAPI(token string) (*tgbotapi.BotAPI, error) {
+	apiEndpoint := strings.TrimSpace(os.Getenv("TELEGRAM_API_ENDPOINT"))
+	if apiEndpoint == "" {
+		apiEndpoint = tgbotapi.APIEndpoint
+	}
+	return tgbotapi.NewBotAPIWithClient(token, apiEndpoint, outbox.NewClient(&http.Client{}))
+}
+
+// RunManagerBot обрабатывает обновления бота и запускает планировщики объявлений.
+// При отмене ctx перестаёт получать обновления, дожидается текущего обновления и
+// текущего прохода планировщика и возвращается.
+func RunManagerBot(ctx context.Context) {
 	botToken := os.Getenv("BOT_TOKEN")
 	if botToken == "" {
 		log.Println("BOT_TOKEN not set, manager bot disabled")
@@ -226,13 +186,27 @@ func RunManagerBot() {
 		return
 	}
 
-	bot, err := tgbotapi.NewBotAPI(botToken)
+	bot, err := newBotAPI(botToken)
 	if err != nil {
 		log.Fatal("bot init failed:", err)
 	}
 
 	setBotToken(botToken)
-	startAdSchedulers(bot)
+	setBotAPI(bot)
+
+	var schedulers sync.WaitGroup
+	schedulers.Add(2)
+	go func() {
+		defer schedulers.Done()
+		newAdScheduler(bot).Run(ctx)
+	}()
+	go func() {
+		defer schedulers.Done()
+		outbox.Run(ctx, bot)
+	}()
+	defer schedulers.Wait()
+
+	startBroadcastRunner(ctx, bot)
 
 	log.Printf("Manager bot started for user IDs: %v", managerIDs)
 
@@ -240,13 +214,66 @@ func RunManagerBot() {
 	u.Timeout = 60
 	updates := bot.GetUpdatesChan(u)
 
-	for update := range updates {
+	for {
+		var update tgbotapi.Update
+		select {
+		case <-ctx.Done():
+			bot.StopReceivingUpdates()
+			log.Println("Manager bot stopped receiving updates")
+			return
+		case next, ok := <-updates:
+			if !ok {
+				return
+			}
+			update = next
+		}
+
+		startedAt := time.Now()
 		switch {
-		case update.Message != nil:
+		case update.Message != nil && update.Message.SuccessfulPayment != nil:
+			handleSuccessfulPayment(bot, managerIDs, update.Message)
+		case update.PreCheckoutQuery != nil:
+			handlePreCheckoutQuery(bot, update.PreCheckoutQuery)
+		case update.Message != nil && isPaymentCommand(update.Message):
+			handlePaymentCommand(bot, managerIDs, update.Message)
+		case update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, "pay_"):
+			handlePaymentCallback(bot, update.CallbackQuery)
+		case update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, ownerCallbackPrefix):
+			handleOwnerCallback(bot, update.CallbackQuery)
+		case update.Message != nil && isLanguageCommand(update.Message):
+			handleLanguageCommand(bot, update.Message)
+		case update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, languageCallbackPrefix):
+			handleLanguageCallback(bot, update.CallbackQuery)
+		case update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, searchCallbackPrefix):
+			handleSavedSearchCallback(bot, update.CallbackQuery)
+		case update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, favoriteCallbackPrefix):
+			handleFavoriteCallback(bot, update.CallbackQuery)
+		case update.Message != nil && update.Message.IsCommand() && update.Message.Command() == commandCheck:
+			handleCheckCommand(bot, managerIDs, update.Message)
+		case update.Message != nil && (update.Message.Chat.IsGroup() || update.Message.Chat.IsSuperGroup()):
+			handleGroupMessage(bot, managerIDs, update.Message)
+		case update.Message != nil && update.Message.From != nil && isManager(update.Message.From.ID, managerIDs):
 			handleManagerMessage(bot, managerIDs, update.Message)
-		case update.CallbackQuery != nil:
+		case update.Message != nil:
+			handleUserMessage(bot, managerIDs, update.Message)
+		case update.InlineQuery != nil:
+			handleInlineQuery(bot, managerIDs, update.InlineQuery)
+		case update.MyChatMember != nil:
+			handleMyChatMember(bot, update.MyChatMember)
+		case update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, adRequestCallbackPrefix):
+			handleAdRequestCallback(bot, managerIDs, update.CallbackQuery)
+		case update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, userCallbackPrefix):
+			// Меню пользователя доступно и менеджерам: кнопки usr_ не должны уходить в панель менеджера
+			handleUserCallback(bot, update.CallbackQuery)
+		case update.CallbackQuery != nil && update.CallbackQuery.From != nil && isManager(update.CallbackQuery.From.ID, managerIDs):
 			handleCallbackQuery(bot, managerIDs, update.CallbackQuery)
+		case update.CallbackQuery != nil:
+			// Неизвестная или устаревшая кнопка: подтверждаем callback, чтобы у клиента не висели «часики»
+			if _, err := bot.Request(tgbotapi.NewCallback(update.CallbackQuery.ID, "")); err != nil {
+				log.Printf("callback answer error: %v", err)
+			}
 		}
+		observeBotUpdate(update, startedAt)
 	}
 }
 
@@ -268,7 +295,7 @@ func handleManagerMessage(bot *tgbotapi.BotAPI, managerIDs []int64, msg *tgbotap
 	}
 
 	text := strings.TrimSpace(msg.Text)
-	if text == "" && msg.Photo == nil {
+	if text == "" && msg.Photo == nil && msg.Document == nil {
 		return
 	}
 
@@ -283,6 +310,16 @@ func handleManagerMessage(bot *tgbotapi.BotAPI, managerIDs []int64, msg *tgbotap
 		return
 	}
 
+	if isCommand(text, commandTaxonomy) {
+		handleTaxonomyCommand(bot, msg.Chat.ID, text)
+		return
+	}
+
+	if isCommand(text, commandTopAds) {
+		handleTopAdsCommand(bot, msg.Chat.ID, text)
+		return
+	}
+
 	// Обработка текстового ввода в активной сессии
 	if session := getSession(msg.Chat.ID); session != nil && session.Stage != stageNone {
 		handleSessionInput(bot, msg, session)
@@ -295,10 +332,11 @@ func handleManagerMessage(bot *tgbotapi.BotAPI, managerIDs []int64, msg *tgbotap
 
 // handleForwardedMessage обрабатывает пересланные сообщения для получения ID пользователя
 func handleForwardedMessage(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) {
+	locale := userLocale(msg.From)
 	// Проверяем, что сообщение действительно переслано
 	if msg.ForwardFrom == nil {
 		log.Printf("Ошибка: ForwardFrom == nil")
-		sendText(bot, msg.Chat.ID, "❌ Не удалось получить информацию о пользователе. Убедитесь, что пользователь разрешил пересылку сообщений.")
+		sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.forward.no_user"))
 		return
 	}
 
@@ -312,7 +350,7 @@ func handleForwardedMessage(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) {
 			userID = msg.ForwardFromChat.ID
 			log.Printf("Получен ID из ForwardFromChat: %d", userID)
 		} else {
-			sendText(bot, msg.Chat.ID, "❌ Не удалось получить ID пользователя. Убедитесь, что пользователь разрешил пересылку сообщений.")
+			sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.forward.no_id"))
 			return
 		}
 	}
@@ -321,32 +359,28 @@ func handleForwardedMessage(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) {
 
 	session := getSession(msg.Chat.ID)
 
-	// Если нет активной сессии, создаём временную для поиска
+	// Если нет активной сессии, показываем проверку пользователя и его активные объявления
 	if session == nil {
-		session = &adSession{
-			Stage:         stageAwaitFindAdID,
-			LastActivity:  time.Now(),
-			ChatID:        msg.Chat.ID,
-			BotMessageIDs: []int{},
-		}
-		setSession(msg.Chat.ID, session)
+		showForwardedUserLookup(bot, msg.Chat.ID, userID, username)
+		return
 	}
 
-	clientID := strconv.FormatInt(userID, 10)
-
-	log.Printf("Получено пересланное сообщение: UserID=%d, Username=%s, ClientID=%s, Stage=%d", userID, username, clientID, session.Stage)
+	log.Printf("Получено пересланное сообщение: UserID=%d, Username=%s, Stage=%d", userID, username, session.Stage)
 
 	// Если мы ожидаем ID пользователя (при создании объявления)
 	if session.Stage == stageAwaitUserId {
-		// Устанавливаем ID
-		session.Ad.ClientID = clientID
-		session.Ad.UserID = userID
+		// Привязываем объявление к пользователю
+		if err := setAdOwner(&session.Ad, userID, username); err != nil {
+			log.Printf("Ошибка привязки владельца %d: %v", userID, err)
+			sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.owner.save_failed"))
+			return
+		}
 
 		// Устанавливаем username из пересланного сообщения, если он есть
 		if username != "" {
 			session.Ad.Username = username
 			log.Printf("Username получен из пересланного сообщения: %s", username)
-			sendText(bot, msg.Chat.ID, fmt.Sprintf("✅ ID пользователя получен: %d\n✅ Username: @%s", userID, username))
+			sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.owner.received", userID, username))
 			session.Stage = stageAwaitCategory
 			showCategoryPrompt(bot, msg.Chat.ID, session)
 		} else {
@@ -354,16 +388,16 @@ func handleForwardedMessage(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) {
 			log.Printf("Username не найден в пересланном сообщении, запрашиваем отдельно")
 			keyboard := tgbotapi.NewInlineKeyboardMarkup(
 				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData("⏭ Пропустить (без username)", "skip_username"),
+					tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.skip_username"), "skip_username"),
 				),
 				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+					tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 				),
 			)
-			msgText := tgbotapi.NewMessage(msg.Chat.ID, fmt.Sprintf("✅ ID пользователя получен: %d\n\n👤 *Введите username для контакта* (например: @username)\n\nИли нажмите \"Пропустить\", если username не нужен.", userID))
+			msgText := tgbotapi.NewMessage(msg.Chat.ID, i18n.T(locale, "manager.owner.username_prompt", userID))
 			msgText.ParseMode = "Markdown"
 			msgText.ReplyMarkup = keyboard
-			sentMsg, err := bot.Send(msgText)
+			sentMsg, err := sendReply(bot, msgText)
 			if err == nil {
 				addBotMessage(msg.Chat.ID, sentMsg.MessageID)
 			}
@@ -374,22 +408,22 @@ func handleForwardedMessage(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) {
 
 	// Если мы ищем объявление и получили пересланное сообщение
 	if session.Stage == stageAwaitFindAdID {
-		// Ищем все объявления по ClientID
+		// Ищем все объявления владельца
 		var ads []models.Ad
-		if err := db.DB.Where("client_id = ?", clientID).Order("created_at DESC").Find(&ads).Error; err != nil {
-			sendText(bot, msg.Chat.ID, "❌ Ошибка при поиске объявлений.")
+		if err := ownedBy(db.DB, userID).Order("created_at DESC").Find(&ads).Error; err != nil {
+			sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.find.failed"))
 			return
 		}
 
 		if len(ads) == 0 {
 			keyboard := tgbotapi.NewInlineKeyboardMarkup(
 				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "menu_main"),
+					tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_main"),
 				),
 			)
-			msgText := tgbotapi.NewMessage(msg.Chat.ID, fmt.Sprintf("❌ Объявления для пользователя ID %d не найдены.", userID))
+			msgText := tgbotapi.NewMessage(msg.Chat.ID, i18n.T(locale, "manager.find.user_empty", userID))
 			msgText.ReplyMarkup = keyboard
-			sentMsg, err := bot.Send(msgText)
+			sentMsg, err := sendReply(bot, msgText)
 			if err == nil {
 				addBotMessage(msg.Chat.ID, sentMsg.MessageID)
 				go scheduleDeletePreviousMessages(bot, msg.Chat.ID, session, sentMsg.MessageID)
@@ -404,7 +438,7 @@ func handleForwardedMessage(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) {
 	}
 
 	// Если сессия есть, но этап не подходит - показываем сообщение
-	sendText(bot, msg.Chat.ID, fmt.Sprintf("✅ Получен ID пользователя: %d\n\nДля создания объявления используйте /newad", userID))
+	sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.forward.received", userID))
 }
 
 func handleCallbackQuery(bot *tgbotapi.BotAPI, managerIDs []int64, callback *tgbotapi.CallbackQuery) {
@@ -440,6 +474,22 @@ func handleCallbackQuery(bot *tgbotapi.BotAPI, managerIDs []int64, callback *tgb
 		startCreateSession(bot, chatID)
 	case data == "menu_find_ad":
 		startFindAdSession(bot, chatID)
+	case data == "menu_premium":
+		showPremiumCalendar(bot, chatID)
+	case data == "menu_top_ads":
+		showTopAds(bot, chatID, defaultTopAdsDays)
+	case strings.HasPrefix(data, "top_ads_"):
+		handleTopAdsCallback(bot, chatID, data)
+	case data == "menu_stats":
+		handleStatsCallback(bot, chatID, "stats_week")
+	case strings.HasPrefix(data, "stats_"):
+		handleStatsCallback(bot, chatID, data)
+	case data == "menu_broadcast":
+		startBroadcastSession(bot, chatID)
+	case strings.HasPrefix(data, "bcast_"):
+		handleBroadcastCallback(bot, chatID, callback.From.ID, data)
+	case strings.HasPrefix(data, "pslot_"):
+		handlePremiumSlotCallback(bot, chatID, callback.From.ID, data)
 	case data == "menu_blacklist":
 		showBlacklistMenu(bot, chatID)
 	case data == "blacklist_view":
@@ -448,6 +498,12 @@ func handleCallbackQuery(bot *tgbotapi.BotAPI, managerIDs []int64, callback *tgb
 		startBlacklistAdd(bot, chatID)
 	case data == "blacklist_remove":
 		startBlacklistRemove(bot, chatID)
+	case data == "blacklist_import":
+		startBlacklistImport(bot, chatID)
+	case data == "blacklist_import_confirm":
+		handleBlacklistImportConfirm(bot, chatID)
+	case data == "blacklist_export":
+		sendBlacklistExport(bot, chatID)
 	case strings.HasPrefix(data, "ad_action_"):
 		handleAdActionCallback(bot, chatID, data)
 	case data == "category_edit":
@@ -460,6 +516,10 @@ func handleCallbackQuery(bot *tgbotapi.BotAPI, managerIDs []int64, callback *tgb
 		handleEditSetting(bot, chatID, "duration")
 	case data == "premium_edit":
 		handleEditSetting(bot, chatID, "premium")
+	case data == "price_edit":
+		handlePriceEdit(bot, chatID)
+	case strings.HasPrefix(data, "autorenew_"):
+		handleAutoRenewCallback(bot, chatID, data)
 	case strings.HasPrefix(data, "category_"):
 		handleCategoryCallback(bot, chatID, data)
 	case strings.HasPrefix(data, "mode_"):
@@ -494,6 +554,12 @@ func handleCallbackQuery(bot *tgbotapi.BotAPI, managerIDs []int64, callback *tgb
 		handleAdRemove(bot, chatID)
 	case data == "ad_publish":
 		handleAdPublish(bot, chatID)
+	case data == "ad_bump":
+		handleAdBump(bot, chatID, callback.From.ID)
+	case data == "ad_history":
+		showAdHistory(bot, chatID)
+	case strings.HasPrefix(data, "ad_restore_"):
+		handleAdRestore(bot, chatID, callback.From.ID, data)
 	case strings.HasPrefix(data, "select_ad_"):
 		handleSelectAd(bot, chatID, data)
 	case data == "edit_after_preview":
@@ -502,25 +568,38 @@ func handleCallbackQuery(bot *tgbotapi.BotAPI, managerIDs []int64, callback *tgb
 }
 
 func showMainMenu(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	clearSession(chatID)
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("➕ Создать объявление", "menu_new_ad"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.menu.new_ad"), "menu_new_ad"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.menu.find_ad"), "menu_find_ad"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("🔍 Найти объявление", "menu_find_ad"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.menu.blacklist"), "menu_blacklist"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("🚫 Чёрный список", "menu_blacklist"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.menu.premium"), "menu_premium"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.menu.top_ads"), "menu_top_ads"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.menu.stats"), "menu_stats"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.menu.broadcast"), "menu_broadcast"),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "📋 *Меню менеджера*\n\nВыберите действие:")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.menu.title"))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 		// Удаляем предыдущие сообщения после отправки нового меню
@@ -532,24 +611,29 @@ func showMainMenu(bot *tgbotapi.BotAPI, chatID int64) {
 }
 
 func showBlacklistMenu(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("📋 Просмотр", "blacklist_view"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.blacklist.button.view"), "blacklist_view"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("➕ Добавить", "blacklist_add"),
-			tgbotapi.NewInlineKeyboardButtonData("➖ Удалить", "blacklist_remove"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.blacklist.button.add"), "blacklist_add"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.blacklist.button.remove"), "blacklist_remove"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "menu_main"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.blacklist.button.import"), "blacklist_import"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.blacklist.button.export"), "blacklist_export"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_main"),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "🚫 *Управление чёрным списком*")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.blacklist.title"))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 		// Удаляем предыдущие сообщения после отправки нового
@@ -561,23 +645,24 @@ func showBlacklistMenu(bot *tgbotapi.BotAPI, chatID int64) {
 }
 
 func showBlacklist(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	var scammers []models.User
 	if err := db.DB.Where("is_scammer = ?", true).Order("username ASC").Find(&scammers).Error; err != nil {
-		sendText(bot, chatID, "Ошибка загрузки чёрного списка.")
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.load_failed"))
 		return
 	}
 
 	if len(scammers) == 0 {
-		text := "📋 *Чёрный список пуст*"
+		text := i18n.T(locale, "manager.blacklist.empty")
 		keyboard := tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "menu_blacklist"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_blacklist"),
 			),
 		)
 		msg := tgbotapi.NewMessage(chatID, text)
 		msg.ParseMode = "Markdown"
 		msg.ReplyMarkup = keyboard
-		sentMsg, err := bot.Send(msg)
+		sentMsg, err := sendReply(bot, msg)
 		if err == nil {
 			addBotMessage(chatID, sentMsg.MessageID)
 			// Удаляем предыдущие сообщения после отправки нового
@@ -590,10 +675,10 @@ func showBlacklist(bot *tgbotapi.BotAPI, chatID int64) {
 	}
 
 	var text strings.Builder
-	text.WriteString("📋 *Чёрный список:*\n\n")
+	text.WriteString(i18n.T(locale, "manager.blacklist.list"))
 	for i, user := range scammers {
 		if i >= 50 { // Ограничение Telegram на длину сообщения
-			text.WriteString(fmt.Sprintf("\n... и ещё %d пользователей", len(scammers)-50))
+			text.WriteString(i18n.T(locale, "manager.blacklist.more", len(scammers)-50))
 			break
 		}
 		text.WriteString(fmt.Sprintf("• @%s\n", user.Username))
@@ -601,7 +686,7 @@ func showBlacklist(bot *tgbotapi.BotAPI, chatID int64) {
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "menu_blacklist"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_blacklist"),
 		),
 	)
 
@@ -609,7 +694,7 @@ func showBlacklist(bot *tgbotapi.BotAPI, chatID int64) {
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 		// Удаляем предыдущие сообщения после отправки нового
@@ -621,6 +706,7 @@ func showBlacklist(bot *tgbotapi.BotAPI, chatID int64) {
 }
 
 func startBlacklistAdd(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	session := &adSession{
 		Stage:        stageAwaitBlacklistAdd,
 		LastActivity: time.Now(),
@@ -630,21 +716,22 @@ func startBlacklistAdd(bot *tgbotapi.BotAPI, chatID int64) {
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "menu_blacklist"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_blacklist"),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "➕ *Добавить в чёрный список*\n\nОтправьте username (например: @username)")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.blacklist.add_prompt"))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
 }
 
 func startBlacklistRemove(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	session := &adSession{
 		Stage:        stageAwaitBlacklistRemove,
 		LastActivity: time.Now(),
@@ -654,15 +741,15 @@ func startBlacklistRemove(bot *tgbotapi.BotAPI, chatID int64) {
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "menu_blacklist"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_blacklist"),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "➖ *Удалить из чёрного списка*\n\nОтправьте username (например: @username)")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.blacklist.remove_prompt"))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 		// Удаляем предыдущие сообщения после отправки нового
@@ -674,6 +761,7 @@ func startBlacklistRemove(bot *tgbotapi.BotAPI, chatID int64) {
 }
 
 func startFindAdSession(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	session := &adSession{
 		Stage:         stageAwaitFindAdID,
 		LastActivity:  time.Now(),
@@ -684,15 +772,15 @@ func startFindAdSession(bot *tgbotapi.BotAPI, chatID int64) {
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "menu_main"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_main"),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "🔍 *Найти объявления*\n\nОтправьте ID клиента (только цифры) или перешлите любое сообщение от пользователя:")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.find.prompt"))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 		// Удаляем предыдущие сообщения после отправки нового
@@ -704,6 +792,7 @@ func startFindAdSession(bot *tgbotapi.BotAPI, chatID int64) {
 }
 
 func startCreateSession(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	session := &adSession{
 		Operation:     opCreate,
 		Stage:         stageAwaitPhoto,
@@ -716,18 +805,18 @@ func startCreateSession(bot *tgbotapi.BotAPI, chatID int64) {
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("⏭ Пропустить", "skip_photo"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.skip"), "skip_photo"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Отмена", "menu_main"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.cancel"), "menu_main"),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "📸 *Шаг 1: Фото*\n\nОтправьте фото объявления или пропустите этот шаг.")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.step.photo"))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 		// Удаляем предыдущие сообщения после отправки нового
@@ -740,6 +829,7 @@ func handleAdActionCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
 }
 
 func handleAdEdit(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	session := getSession(chatID)
 	if session == nil {
 		return
@@ -747,27 +837,30 @@ func handleAdEdit(bot *tgbotapi.BotAPI, chatID int64) {
 
 	session.Operation = opEdit
 	session.Stage = stageAwaitPhoto
+	session.PremiumEdited = false
+	session.AutoRenewEdited = false
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("⏭ Пропустить", "skip_photo"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.skip"), "skip_photo"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", fmt.Sprintf("ad_action_%d", session.Ad.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), fmt.Sprintf("ad_action_%d", session.Ad.ID)),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "📸 *Шаг 1: Фото*\n\nОтправьте новое фото или пропустите этот шаг.")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.step.photo_edit"))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
 }
 
 func handleAdRenew(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	session := getSession(chatID)
 	if session == nil {
 		return
@@ -778,51 +871,58 @@ func handleAdRenew(bot *tgbotapi.BotAPI, chatID int64) {
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("1 день", "renew_duration_1"),
-			tgbotapi.NewInlineKeyboardButtonData("7 дней", "renew_duration_7"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 1), "renew_duration_1"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 7), "renew_duration_7"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("14 дней", "renew_duration_14"),
-			tgbotapi.NewInlineKeyboardButtonData("30 дней", "renew_duration_30"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 14), "renew_duration_14"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 30), "renew_duration_30"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", fmt.Sprintf("ad_action_%d", session.Ad.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), fmt.Sprintf("ad_action_%d", session.Ad.ID)),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "🔄 *Продлить объявление*\n\nВыберите срок продления:")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.ad.renew_prompt"))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
 }
 
 func handleAdRemove(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	session := getSession(chatID)
 	if session == nil {
 		return
 	}
 
 	if err := setAdStatus(session.Ad.ID, models.AdStatusInactive); err != nil {
-		sendText(bot, chatID, "❌ Не удалось обновить объявление.")
+		sendText(bot, chatID, i18n.T(locale, "manager.ad.update_failed"))
 		return
 	}
+	recordManagerAction(chatID, models.ManagerActionAdRemoved, session.Ad.ID, "")
+
+	removeAdFromChannel(bot, &session.Ad)
+	if session.Ad.IsPremium {
+		processPremiumQueue(bot)
+	}
 
-	notifyUser(bot, session.Ad.UserID, fmt.Sprintf("Ваше объявление «%s» снято с биржи. Свяжитесь с %s для повторной публикации.", session.Ad.Title, managerHelpLink))
+	notifyUser(bot, ownerTelegramID(session.Ad), i18n.T(ownerLocale(session.Ad), "owner.ad_removed", session.Ad.Title, managerHelpLink))
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ В меню", "menu_main"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.menu"), "menu_main"),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("✅ Объявление #%d снято с биржи.", session.Ad.ID))
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.ad.removed", session.Ad.ID))
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 		// Удаляем предыдущие сообщения после отправки результата
@@ -836,6 +936,7 @@ func handleAdRemove(bot *tgbotapi.BotAPI, chatID int64) {
 }
 
 func handleSessionInput(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, session *adSession) {
+	locale := userLocale(msg.From)
 	session.LastActivity = time.Now()
 	text := strings.TrimSpace(msg.Text)
 
@@ -846,6 +947,25 @@ func handleSessionInput(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, session *ad
 		handleBlacklistAddInput(bot, msg.Chat.ID, text)
 	case stageAwaitBlacklistRemove:
 		handleBlacklistRemoveInput(bot, msg.Chat.ID, text)
+	case stageAwaitBlacklistImport:
+		handleBlacklistImportDocument(bot, msg, session)
+	case stageAwaitPremiumStart:
+		handlePremiumStartInput(bot, msg.Chat.ID, text, session)
+	case stageAwaitAutoRenew:
+		// На экране автопродления только кнопки: напоминаем о них и показываем экран снова
+		sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.choose_button"))
+		showAutoRenewPrompt(bot, msg.Chat.ID, session)
+	case stageAwaitAutoRenewUntil:
+		handleAutoRenewUntilInput(bot, msg.Chat.ID, text, session)
+	case stageAwaitSettings:
+		sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.choose_setting"))
+		showAllSettingsPrompt(bot, msg.Chat.ID, session)
+	case stageAwaitPrice:
+		handlePriceInput(bot, msg.Chat.ID, text, session)
+	case stageAwaitBroadcastMessage:
+		handleBroadcastMessageInput(bot, msg, session)
+	case stageAwaitBroadcastButtons:
+		handleBroadcastButtonsInput(bot, msg.Chat.ID, text, session)
 	case stageAwaitPhoto:
 		handlePhotoStage(bot, msg, session)
 	case stageAwaitTitle:
@@ -858,57 +978,73 @@ func handleSessionInput(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, session *ad
 		// Ожидаем пересланное сообщение или ввод ID вручную
 		// Если это текст с числом, считаем его ID
 		if userID, err := strconv.ParseInt(text, 10, 64); err == nil {
-			session.Ad.ClientID = text
-			session.Ad.UserID = userID
+			if err := setAdOwner(&session.Ad, userID, ""); err != nil {
+				log.Printf("Ошибка привязки владельца %d: %v", userID, err)
+				sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.owner.save_failed"))
+				return
+			}
 			// Если username не установлен, запрашиваем его отдельно
 			if session.Ad.Username == "" {
-				log.Printf("ID пользователя введен вручную: UserID=%d, ClientID=%s, Username не установлен", userID, text)
+				log.Printf("ID пользователя введен вручную: UserID=%d, Username не установлен", userID)
 				keyboard := tgbotapi.NewInlineKeyboardMarkup(
 					tgbotapi.NewInlineKeyboardRow(
-						tgbotapi.NewInlineKeyboardButtonData("⏭ Пропустить (без username)", "skip_username"),
+						tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.skip_username"), "skip_username"),
 					),
 					tgbotapi.NewInlineKeyboardRow(
-						tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+						tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 					),
 				)
-				msgText := tgbotapi.NewMessage(msg.Chat.ID, fmt.Sprintf("✅ ID пользователя получен: %d\n\n👤 *Введите username для контакта* (например: @username)\n\nИли нажмите \"Пропустить\", если username не нужен.", userID))
+				msgText := tgbotapi.NewMessage(msg.Chat.ID, i18n.T(locale, "manager.owner.username_prompt", userID))
 				msgText.ParseMode = "Markdown"
 				msgText.ReplyMarkup = keyboard
-				sentMsg, err := bot.Send(msgText)
+				sentMsg, err := sendReply(bot, msgText)
 				if err == nil {
 					addBotMessage(msg.Chat.ID, sentMsg.MessageID)
 				}
 				session.Stage = stageAwaitUsername
 			} else {
-				log.Printf("ID пользователя введен вручную: UserID=%d, ClientID=%s, Username=%s", userID, text, session.Ad.Username)
+				log.Printf("ID пользователя введен вручную: UserID=%d, Username=%s", userID, session.Ad.Username)
 				session.Stage = stageAwaitCategory
 				showCategoryPrompt(bot, msg.Chat.ID, session)
 			}
 		} else {
-			sendText(bot, msg.Chat.ID, "❌ Перешлите сообщение от пользователя или введите ID вручную (только цифры).")
+			sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.forward.invalid"))
 		}
 	}
 }
 
 func handleBlacklistAddInput(bot *tgbotapi.BotAPI, chatID int64, text string) {
+	locale := telegramUserLocale(chatID)
+	// Первое слово — username, остальное — причина (необязательно)
+	var reason string
+	if fields := strings.Fields(text); len(fields) > 1 {
+		text = fields[0]
+		reason = truncate(strings.Join(fields[1:], " "), 512)
+	}
+
 	username := normalizeUsername(text)
 	if username == "" {
-		sendText(bot, chatID, "❌ Введите username в формате @username")
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.invalid_username"))
 		return
 	}
 
-	db.DB.FirstOrCreate(&models.User{}, models.User{Username: username}).Updates(map[string]interface{}{"IsScammer": true})
+	if err := addToBlacklist(db.DB, username, reason, time.Now()); err != nil {
+		log.Printf("blacklist add failed for %s: %v", username, err)
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.update_failed"))
+		return
+	}
+	recordManagerAction(chatID, models.ManagerActionBlacklisted, 0, username)
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "menu_blacklist"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_blacklist"),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("✅ Добавлен в чёрный список: @%s", username))
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.blacklist.added", username))
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 		// Удаляем предыдущие сообщения после отправки результата
@@ -922,35 +1058,40 @@ func handleBlacklistAddInput(bot *tgbotapi.BotAPI, chatID int64, text string) {
 }
 
 func handleBlacklistRemoveInput(bot *tgbotapi.BotAPI, chatID int64, text string) {
+	locale := telegramUserLocale(chatID)
 	username := normalizeUsername(text)
 	if username == "" {
-		sendText(bot, chatID, "❌ Введите username в формате @username")
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.invalid_username"))
 		return
 	}
 
-	result := db.DB.Where("username = ?", username).Updates(&models.User{IsScammer: false})
+	// Обновляем через map: Updates со структурой пропускает нулевое значение false
+	result := db.DB.Model(&models.User{}).
+		Where("LOWER(username) = LOWER(?) AND is_scammer = ?", username, true).
+		Update("is_scammer", false)
 	if result.Error != nil {
-		sendText(bot, chatID, "❌ Ошибка во время обновления чёрного списка.")
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.update_failed"))
 		return
 	}
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "menu_blacklist"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_blacklist"),
 		),
 	)
 
 	var msgText string
 	if result.RowsAffected == 0 {
-		msgText = fmt.Sprintf("❌ Пользователь @%s не найден в чёрном списке", username)
+		msgText = i18n.T(locale, "manager.blacklist.not_listed", username)
 	} else {
-		msgText = fmt.Sprintf("✅ Удалён из чёрного списка: @%s", username)
+		msgText = i18n.T(locale, "manager.blacklist.removed", username)
+		recordManagerAction(chatID, models.ManagerActionUnblacklisted, 0, username)
 	}
 
 	msg := tgbotapi.NewMessage(chatID, msgText)
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 		// Удаляем предыдущие сообщения после отправки результата
@@ -977,11 +1118,12 @@ func handleSkipPhoto(bot *tgbotapi.BotAPI, chatID int64) {
 }
 
 func handlePhotoStage(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, session *adSession) {
+	locale := userLocale(msg.From)
 	if len(msg.Photo) > 0 {
 		photo := msg.Photo[len(msg.Photo)-1]
 		file, err := bot.GetFile(tgbotapi.FileConfig{FileID: photo.FileID})
 		if err != nil {
-			sendText(bot, msg.Chat.ID, "❌ Не удалось сохранить фото, попробуйте ещё раз.")
+			sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.step.photo_failed"))
 			return
 		}
 		session.Ad.PhotoID = photo.FileID
@@ -993,30 +1135,32 @@ func handlePhotoStage(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, session *adSe
 }
 
 func showTitlePrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 		),
 	)
 
-	text := "📝 *Шаг 2: Заголовок*\n\nВведите заголовок объявления (до 128 символов)."
+	text := i18n.T(locale, "manager.step.title")
 	if session.Ad.Title != "" {
-		text += fmt.Sprintf("\n\nТекущий: %s", session.Ad.Title)
+		text += i18n.T(locale, "manager.step.current_m", session.Ad.Title)
 	}
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
 }
 
 func handleTitleInput(bot *tgbotapi.BotAPI, chatID int64, text string, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	if text == "" {
-		sendText(bot, chatID, "❌ Заголовок не может быть пустым.")
+		sendText(bot, chatID, i18n.T(locale, "manager.step.title_empty"))
 		return
 	}
 
@@ -1027,30 +1171,32 @@ func handleTitleInput(bot *tgbotapi.BotAPI, chatID int64, text string, session *
 }
 
 func showDescriptionPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 		),
 	)
 
-	text := "📄 *Шаг 3: Описание*\n\nВведите описание объявления."
+	text := i18n.T(locale, "manager.step.desc")
 	if session.Ad.Desc != "" {
-		text += fmt.Sprintf("\n\nТекущее: %s", truncate(session.Ad.Desc, 100))
+		text += i18n.T(locale, "manager.step.current_n", truncate(session.Ad.Desc, 100))
 	}
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
 }
 
 func handleDescriptionInput(bot *tgbotapi.BotAPI, chatID int64, text string, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	if text == "" {
-		sendText(bot, chatID, "❌ Описание не может быть пустым.")
+		sendText(bot, chatID, i18n.T(locale, "manager.step.desc_empty"))
 		return
 	}
 
@@ -1060,49 +1206,46 @@ func handleDescriptionInput(bot *tgbotapi.BotAPI, chatID int64, text string, ses
 	// Сразу запрашиваем ID пользователя (переслать сообщение)
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("⏭ Пропустить (указать ID вручную)", "skip_user_id"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.skip_user_id"), "skip_user_id"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "🆔 *Шаг 4: ID пользователя*\n\nПерешлите любое сообщение от пользователя, чтобы автоматически получить его ID.\n\nИли нажмите \"Пропустить\", чтобы ввести ID вручную.")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.step.user_id"))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
 }
 
 func showCategoryPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Услуги", "category_services"),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Купля/Продажа", "category_buysell"),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Другое", "category_other"),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
-		),
-	)
+	locale := telegramUserLocale(chatID)
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, category := range selectableCategories() {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(category.Label, fmt.Sprintf("category_%s", category.Key)),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
+	))
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
 
-	text := "📂 *Шаг 5: Категория*\n\nВыберите категорию объявления."
+	text := i18n.T(locale, "manager.step.category")
 	if session.Ad.Category != "" {
-		text += fmt.Sprintf("\n\nТекущая: %s", categoryLabels[session.Ad.Category])
+		text += i18n.T(locale, "manager.step.current_f", escapeMarkdown(categoryName(session.Ad.Category)))
 	}
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
@@ -1120,8 +1263,8 @@ func handleEditSetting(bot *tgbotapi.BotAPI, chatID int64, setting string) {
 		session.Stage = stageAwaitCategory
 		showCategoryPrompt(bot, chatID, session)
 	case "mode":
-		// Для категории "other" режим не редактируется
-		if session.Ad.Category == "other" {
+		// Если у категории один режим (как у «Другое»), он не редактируется
+		if _, single := singleMode(session.Ad.Category); single {
 			showAllSettingsPrompt(bot, chatID, session)
 			return
 		}
@@ -1140,63 +1283,65 @@ func handleEditSetting(bot *tgbotapi.BotAPI, chatID int64, setting string) {
 }
 
 func handleCategoryCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
+	locale := telegramUserLocale(chatID)
 	session := getSession(chatID)
 	if session == nil {
 		return
 	}
 
 	category := strings.TrimPrefix(data, "category_")
+	if _, ok := currentTaxonomy().category(category); !ok {
+		return
+	}
+	if len(currentTaxonomy().activeOptions(category, models.TaxonomyKindMode)) == 0 {
+		sendText(bot, chatID, i18n.T(locale, "manager.step.category_no_modes"))
+		return
+	}
 	session.Ad.Category = category
 
+	// Если у категории один режим (как у «Другое»), пропускаем выбор и устанавливаем его сразу
+	mode, single := singleMode(category)
+	if single {
+		session.Ad.Mode = mode
+	}
+
 	// Если мы редактируем из showAllSettingsPrompt, возвращаемся к нему, иначе продолжаем обычный флоу
 	if session.Stage == stageAwaitCategory {
 		showAllSettingsPrompt(bot, chatID, session)
+	} else if single {
+		session.Stage = stageAwaitTag
+		showTagPrompt(bot, chatID, session)
 	} else {
-		// Для категории "other" пропускаем выбор режима и автоматически устанавливаем "general"
-		if category == "other" {
-			session.Ad.Mode = "general"
-			session.Stage = stageAwaitTag
-			showTagPrompt(bot, chatID, session)
-		} else {
-			session.Stage = stageAwaitMode
-			showModePrompt(bot, chatID, session)
-		}
+		session.Stage = stageAwaitMode
+		showModePrompt(bot, chatID, session)
 	}
 }
 
 func showModePrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	var rows [][]tgbotapi.InlineKeyboardButton
-	modeLabelsMap := modeLabels[session.Ad.Category]
-	modeValuesMap := modeValues[session.Ad.Category]
-
-	// Итерируемся по modeValuesMap (русское название -> английское значение)
-	for label, value := range modeValuesMap {
-		// label - русское название (например "Объявление")
-		// value - английское значение (например "general")
+	for _, mode := range currentTaxonomy().activeOptions(session.Ad.Category, models.TaxonomyKindMode) {
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("mode_%s", value)),
+			tgbotapi.NewInlineKeyboardButtonData(mode.Label, fmt.Sprintf("mode_%s", mode.Key)),
 		))
 	}
 
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 	))
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
 
-	text := "🎯 *Шаг 6: Режим*\n\nВыберите режим объявления."
+	text := i18n.T(locale, "manager.step.mode")
 	if session.Ad.Mode != "" {
-		// Ищем русское название по английскому значению
-		if modeLabel, ok := modeLabelsMap[session.Ad.Mode]; ok {
-			text += fmt.Sprintf("\n\nТекущий: %s", modeLabel)
-		}
+		text += i18n.T(locale, "manager.step.current_m", escapeMarkdown(modeName(session.Ad.Category, session.Ad.Mode)))
 	}
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
@@ -1209,6 +1354,9 @@ func handleModeCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
 	}
 
 	mode := strings.TrimPrefix(data, "mode_")
+	if _, ok := currentTaxonomy().option(session.Ad.Category, models.TaxonomyKindMode, mode); !ok {
+		return
+	}
 	session.Ad.Mode = mode
 
 	// Если мы редактируем из showAllSettingsPrompt, возвращаемся к нему, иначе продолжаем обычный флоу
@@ -1221,17 +1369,13 @@ func handleModeCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
 }
 
 func showTagPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	var rows [][]tgbotapi.InlineKeyboardButton
-	tagLabelsMap := tagLabels[session.Ad.Category]
-	tagValuesMap := tagValues[session.Ad.Category]
 
 	// Разбиваем теги на строки по 2 кнопки
 	var currentRow []tgbotapi.InlineKeyboardButton
-	// Итерируемся по tagValuesMap (русское название -> английское значение)
-	for label, value := range tagValuesMap {
-		// label - русское название (например "Дизайнер")
-		// value - английское значение (например "designer")
-		btn := tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("tag_%s", value))
+	for _, tag := range currentTaxonomy().activeOptions(session.Ad.Category, models.TaxonomyKindTag) {
+		btn := tgbotapi.NewInlineKeyboardButtonData(tag.Label, fmt.Sprintf("tag_%s", tag.Key))
 		currentRow = append(currentRow, btn)
 
 		if len(currentRow) == 2 {
@@ -1245,24 +1389,21 @@ func showTagPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
 	}
 
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 	))
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
 
-	text := "🏷 *Шаг 7: Тег*\n\nВыберите тег объявления."
+	text := i18n.T(locale, "manager.step.tag")
 	if session.Ad.Tag != "" {
-		// Ищем русское название по английскому значению
-		if tagLabel, ok := tagLabelsMap[session.Ad.Tag]; ok {
-			text += fmt.Sprintf("\n\nТекущий: %s", tagLabel)
-		}
+		text += i18n.T(locale, "manager.step.current_m", escapeMarkdown(tagName(session.Ad.Category, session.Ad.Tag)))
 	}
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
@@ -1275,6 +1416,9 @@ func handleTagCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
 	}
 
 	tag := strings.TrimPrefix(data, "tag_")
+	if _, ok := currentTaxonomy().option(session.Ad.Category, models.TaxonomyKindTag, tag); !ok {
+		return
+	}
 	session.Ad.Tag = tag
 
 	// Если мы редактируем из showAllSettingsPrompt, возвращаемся к нему, иначе продолжаем обычный флоу
@@ -1287,33 +1431,35 @@ func handleTagCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
 }
 
 func showDurationPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("1 день", "duration_1"),
-			tgbotapi.NewInlineKeyboardButtonData("7 дней", "duration_7"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 1), "duration_1"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 7), "duration_7"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("14 дней", "duration_14"),
-			tgbotapi.NewInlineKeyboardButtonData("30 дней", "duration_30"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 14), "duration_14"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 30), "duration_30"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 		),
 	)
 
-	text := "⏱ *Шаг 8: Срок действия*\n\nВыберите срок отображения объявления."
+	text := i18n.T(locale, "manager.step.duration")
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
 }
 
 func handleDurationCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
+	locale := telegramUserLocale(chatID)
 	session := getSession(chatID)
 	if session == nil {
 		return
@@ -1322,7 +1468,7 @@ func handleDurationCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
 	daysStr := strings.TrimPrefix(data, "duration_")
 	days, err := strconv.Atoi(daysStr)
 	if err != nil || !isValidDuration(days) {
-		sendText(bot, chatID, "❌ Неверный срок.")
+		sendText(bot, chatID, i18n.T(locale, "manager.duration.invalid"))
 		return
 	}
 
@@ -1338,80 +1484,93 @@ func handleDurationCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
 }
 
 func showPremiumPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	var exclude *uint
 	if session.Operation != opCreate {
 		exclude = &session.Ad.ID
 	}
-	count, err := activePremiumCount(exclude)
+	free, err := premiumSlotFree(db.DB, session.Ad.Category, exclude)
 	if err != nil {
-		sendText(bot, chatID, "❌ Не удалось проверить лимит премиум-объявлений.")
+		sendText(bot, chatID, i18n.T(locale, "manager.step.premium_check_failed"))
 		return
 	}
 
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("✅ Да", "premium_yes"),
-			tgbotapi.NewInlineKeyboardButtonData("❌ Нет", "premium_no"),
-		),
+	rows := [][]tgbotapi.InlineKeyboardButton{
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.yes"), "premium_yes"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.no"), "premium_no"),
 		),
-	)
+	}
 
-	text := "⭐ *Шаг 9: Премиум размещение*\n\nПремиум объявление будет отображаться вверху списка."
-	if count >= 3 {
-		text += "\n\n⚠️ Лимит премиум-объявлений (3) исчерпан. Сначала снимите одно из текущих."
+	text := i18n.T(locale, "manager.step.premium")
+	if !free {
+		text += "\n\n⚠️ " + premiumLimitText(locale, session.Ad.Category) + i18n.T(locale, "manager.step.premium_queue_hint")
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.step.button.queue"), "premium_queue"),
+		))
 	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
+	))
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = "Markdown"
-	msg.ReplyMarkup = keyboard
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
 }
 
 func handlePremiumCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
+	locale := telegramUserLocale(chatID)
 	session := getSession(chatID)
 	if session == nil {
 		return
 	}
 
-	if data == "premium_yes" {
+	switch data {
+	case "premium_yes":
 		var exclude *uint
 		if session.Operation != opCreate {
 			exclude = &session.Ad.ID
 		}
-		count, err := activePremiumCount(exclude)
+		free, err := premiumSlotFree(db.DB, session.Ad.Category, exclude)
 		if err != nil {
-			sendText(bot, chatID, "❌ Не удалось проверить лимит премиум-объявлений.")
+			sendText(bot, chatID, i18n.T(locale, "manager.step.premium_check_failed"))
 			return
 		}
-		if count >= 3 {
-			sendText(bot, chatID, "⚠️ Лимит премиум-объявлений (3) исчерпан. Сначала снимите одно из текущих.")
+		if !free && !session.Ad.IsPremium {
+			sendText(bot, chatID, "⚠️ "+premiumLimitText(locale, session.Ad.Category)+i18n.T(locale, "manager.step.premium_full"))
 			return
 		}
 		session.Ad.IsPremium = true
-	} else {
+		session.PremiumQueued = false
+	case "premium_queue":
 		session.Ad.IsPremium = false
+		session.PremiumQueued = true
+	default:
+		session.Ad.IsPremium = false
+		session.PremiumQueued = false
 	}
+	session.PremiumEdited = true
 
-	// После выбора премиума показываем предпросмотр (если ClientID уже установлен) или продолжаем
-	if session.Ad.ClientID != "" {
+	// После выбора премиума показываем предпросмотр (если владелец уже указан) или продолжаем
+	if session.Ad.OwnerID != nil {
 		session.Stage = stageAwaitConfirmation
 		showConfirmationPrompt(bot, chatID, session)
 	} else if session.Stage == stageAwaitPremium {
 		showAllSettingsPrompt(bot, chatID, session)
 	} else {
-		// Если ClientID не установлен, должны были получить его раньше - возвращаемся к настройкам
+		// Если владелец не указан, должны были получить его раньше - возвращаемся к настройкам
 		showAllSettingsPrompt(bot, chatID, session)
 	}
 }
 
 // handleSkipUserID позволяет пропустить автоматическое получение ID и ввести его вручную
 func handleSkipUserID(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	session := getSession(chatID)
 	if session == nil {
 		return
@@ -1419,15 +1578,15 @@ func handleSkipUserID(bot *tgbotapi.BotAPI, chatID int64) {
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "🆔 *Ввод ID клиента*\n\nВведите ID клиента вручную (только цифры):")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.owner.manual_prompt"))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
@@ -1448,9 +1607,10 @@ func handleSkipUsername(bot *tgbotapi.BotAPI, chatID int64) {
 }
 
 func handleUsernameInput(bot *tgbotapi.BotAPI, chatID int64, text string, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	username := normalizeUsername(text)
 	if username == "" {
-		sendText(bot, chatID, "❌ Введите username в формате @username или нажмите \"Пропустить\".")
+		sendText(bot, chatID, i18n.T(locale, "manager.owner.invalid_username"))
 		return
 	}
 
@@ -1461,27 +1621,27 @@ func handleUsernameInput(bot *tgbotapi.BotAPI, chatID int64, text string, sessio
 }
 
 func handleFindAdIDInput(bot *tgbotapi.BotAPI, chatID int64, text string, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	text = strings.TrimSpace(text)
 	if text == "" {
-		sendText(bot, chatID, "❌ ID клиента не может быть пустым. Введите ID или перешлите сообщение от пользователя.")
+		sendText(bot, chatID, i18n.T(locale, "manager.owner.id_empty"))
 		return
 	}
 
 	// Проверяем, что ID клиента содержит только цифры
 	clientID, err := strconv.ParseInt(text, 10, 64)
 	if err != nil {
-		sendText(bot, chatID, "❌ ID клиента должен быть числом. Введите ID или перешлите сообщение от пользователя.")
+		sendText(bot, chatID, i18n.T(locale, "manager.owner.id_invalid"))
 		return
 	}
 
-	clientIDStr := strconv.FormatInt(clientID, 10)
-	log.Printf("Поиск объявлений для ClientID: %s", clientIDStr)
+	log.Printf("Поиск объявлений для клиента ID %d", clientID)
 
-	// Ищем все объявления по ClientID
+	// Ищем все объявления владельца
 	var ads []models.Ad
-	if err := db.DB.Where("client_id = ?", clientIDStr).Order("created_at DESC").Find(&ads).Error; err != nil {
+	if err := ownedBy(db.DB, clientID).Order("created_at DESC").Find(&ads).Error; err != nil {
 		log.Printf("Ошибка поиска объявлений: %v", err)
-		sendText(bot, chatID, "❌ Ошибка при поиске объявлений.")
+		sendText(bot, chatID, i18n.T(locale, "manager.find.failed"))
 		return
 	}
 
@@ -1490,12 +1650,12 @@ func handleFindAdIDInput(bot *tgbotapi.BotAPI, chatID int64, text string, sessio
 	if len(ads) == 0 {
 		keyboard := tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "menu_main"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_main"),
 			),
 		)
-		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Объявления для клиента ID %s не найдены.", clientIDStr))
+		msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.find.client_empty", clientID))
 		msg.ReplyMarkup = keyboard
-		sentMsg, err := bot.Send(msg)
+		sentMsg, err := sendReply(bot, msg)
 		if err == nil {
 			addBotMessage(chatID, sentMsg.MessageID)
 			// Удаляем предыдущие сообщения после отправки результата
@@ -1514,6 +1674,7 @@ func handleFindAdIDInput(bot *tgbotapi.BotAPI, chatID int64, text string, sessio
 
 // handleFindAdResults показывает результаты поиска объявлений
 func handleFindAdResults(bot *tgbotapi.BotAPI, chatID int64, ads []models.Ad, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	// Если одно объявление - показываем его
 	if len(ads) == 1 {
 		session.Ad = ads[0]
@@ -1525,22 +1686,22 @@ func handleFindAdResults(bot *tgbotapi.BotAPI, chatID int64, ads []models.Ad, se
 	// Если несколько объявлений - показываем список
 	session.Stage = stageAwaitSelectAd
 	var textBuilder strings.Builder
-	textBuilder.WriteString(fmt.Sprintf("📋 *Найдено объявлений: %d*\n\n", len(ads)))
+	textBuilder.WriteString(i18n.T(locale, "manager.find.title", len(ads)))
 
 	var rows [][]tgbotapi.InlineKeyboardButton
 	for i, ad := range ads {
 		if i >= 10 { // Ограничение на количество кнопок
-			textBuilder.WriteString(fmt.Sprintf("\n... и ещё %d объявлений", len(ads)-10))
+			textBuilder.WriteString(i18n.T(locale, "manager.find.more", len(ads)-10))
 			break
 		}
 		var status string
 		switch ad.Status {
 		case models.AdStatusExpired:
-			status = "🔴 Истекло"
+			status = i18n.T(locale, "manager.find.expired")
 		case models.AdStatusInactive:
-			status = "⚫ Снято"
+			status = i18n.T(locale, "manager.find.inactive")
 		default:
-			status = "🟢 Активно"
+			status = i18n.T(locale, "manager.find.active")
 		}
 		textBuilder.WriteString(fmt.Sprintf("%d. %s - %s\n", ad.ID, ad.Title, status))
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
@@ -1552,7 +1713,7 @@ func handleFindAdResults(bot *tgbotapi.BotAPI, chatID int64, ads []models.Ad, se
 	}
 
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "menu_main"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_main"),
 	))
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
@@ -1561,10 +1722,10 @@ func handleFindAdResults(bot *tgbotapi.BotAPI, chatID int64, ads []models.Ad, se
 	msg.ReplyMarkup = keyboard
 
 	log.Printf("Отправка результатов поиска: найдено %d объявлений", len(ads))
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err != nil {
 		log.Printf("Ошибка отправки результатов поиска: %v", err)
-		sendText(bot, chatID, "❌ Не удалось отправить результаты поиска.")
+		sendText(bot, chatID, i18n.T(locale, "manager.find.send_failed"))
 		return
 	}
 
@@ -1576,16 +1737,17 @@ func handleFindAdResults(bot *tgbotapi.BotAPI, chatID int64, ads []models.Ad, se
 }
 
 func handleSelectAd(bot *tgbotapi.BotAPI, chatID int64, data string) {
+	locale := telegramUserLocale(chatID)
 	adIDStr := strings.TrimPrefix(data, "select_ad_")
 	adID, err := strconv.ParseUint(adIDStr, 10, 32)
 	if err != nil {
-		sendText(bot, chatID, "❌ Неверный ID объявления.")
+		sendText(bot, chatID, i18n.T(locale, "manager.ad.invalid_id"))
 		return
 	}
 
 	var ad models.Ad
 	if err := db.DB.First(&ad, uint(adID)).Error; err != nil {
-		sendText(bot, chatID, "❌ Объявление не найдено.")
+		sendText(bot, chatID, i18n.T(locale, "manager.ad.not_found"))
 		return
 	}
 
@@ -1610,30 +1772,38 @@ func handleSelectAd(bot *tgbotapi.BotAPI, chatID int64, data string) {
 }
 
 func showAdDetailsWithActions(bot *tgbotapi.BotAPI, chatID int64, ad models.Ad) {
-	text := renderAdSummaryWithExpiry(ad)
+	locale := telegramUserLocale(chatID)
+	text := renderAdSummaryWithExpiry(locale, ad)
 
 	var rows [][]tgbotapi.InlineKeyboardButton
 
 	// Если объявление не выложено (статус inactive или неактивно)
 	if ad.Status == models.AdStatusInactive || ad.ExpiresAt.Before(time.Now()) {
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("✅ Выложить", "ad_publish"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.ad.button.publish"), "ad_publish"),
 		))
 	}
 
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("✏️ Изменить", "ad_edit"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.edit"), "ad_edit"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.ad.button.history"), "ad_history"),
 	))
 
 	if ad.Status == models.AdStatusActive {
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("🔄 Продлить", "ad_renew"),
-			tgbotapi.NewInlineKeyboardButtonData("❌ Снять", "ad_remove"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.ad.button.renew"), "ad_renew"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.ad.button.remove"), "ad_remove"),
+		))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.ad.button.bump"), "ad_bump"),
+		))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.ad.button.book_premium"), "pslot_book"),
 		))
 	}
 
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "menu_main"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_main"),
 	))
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
@@ -1642,7 +1812,7 @@ func showAdDetailsWithActions(bot *tgbotapi.BotAPI, chatID int64, ad models.Ad)
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 		// Удаляем предыдущие сообщения после отправки деталей объявления
@@ -1653,37 +1823,65 @@ func showAdDetailsWithActions(bot *tgbotapi.BotAPI, chatID int64, ad models.Ad)
 	}
 }
 
+func handleAdBump(bot *tgbotapi.BotAPI, chatID int64, managerID int64) {
+	locale := telegramUserLocale(chatID)
+	session := getSession(chatID)
+	if session == nil {
+		return
+	}
+
+	ad, err := bumpAd(session.Ad.ID, managerID, models.BumpSourceManager)
+	if err != nil {
+		if errors.Is(err, errAdNotBumpable) {
+			sendText(bot, chatID, "❌ "+i18n.Message(locale, err, "manager.ad.bump_failed"))
+		} else {
+			log.Printf("bump: failed to bump ad %d: %v", session.Ad.ID, err)
+			sendText(bot, chatID, i18n.T(locale, "manager.ad.bump_failed"))
+		}
+		return
+	}
+
+	recordManagerAction(managerID, models.ManagerActionAdBumped, ad.ID, "")
+	session.Ad = ad
+	sendText(bot, chatID, i18n.T(locale, "manager.ad.bumped", ad.ID))
+	showAdDetailsWithActions(bot, chatID, ad)
+}
+
 func handleAdPublish(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	session := getSession(chatID)
 	if session == nil {
 		return
 	}
 
-	// Активируем объявление
+	// Активируем объявление и ставим его наверх ленты
+	now := time.Now()
 	session.Ad.Status = models.AdStatusActive
-	session.Ad.PreExpiryNotified = false
+	session.Ad.BumpedAt = &now
 	if session.Ad.ExpiresAt.Before(time.Now()) {
 		// Если срок истёк, устанавливаем новый срок (7 дней по умолчанию)
 		session.Ad.ExpiresAt = time.Now().Add(7 * 24 * time.Hour)
 	}
 
 	if err := db.DB.Save(&session.Ad).Error; err != nil {
-		sendText(bot, chatID, "❌ Не удалось выложить объявление.")
+		sendText(bot, chatID, i18n.T(locale, "manager.ad.publish_failed"))
 		return
 	}
 
-	notifyUser(bot, session.Ad.UserID, fmt.Sprintf("Ваше объявление «%s» выложено на биржу. Свяжитесь с %s для управления.", session.Ad.Title, managerHelpLink))
+	publishAdToChannel(bot, &session.Ad, true)
+
+	notifyUser(bot, ownerTelegramID(session.Ad), i18n.T(ownerLocale(session.Ad), "owner.ad_reposted", session.Ad.Title, managerHelpLink))
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ В меню", "menu_main"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.menu"), "menu_main"),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("✅ Объявление #%d выложено на биржу.", session.Ad.ID))
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.ad.relisted", session.Ad.ID))
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 		// Удаляем предыдущие сообщения после отправки результата
@@ -1697,15 +1895,16 @@ func handleAdPublish(bot *tgbotapi.BotAPI, chatID int64) {
 }
 
 func showConfirmationPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) int {
-	preview := renderAdPreview(session)
+	locale := telegramUserLocale(chatID)
+	preview := renderAdPreview(locale, session)
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("✅ Подтвердить", "confirm_yes"),
-			tgbotapi.NewInlineKeyboardButtonData("✏️ Изменить", "edit_after_preview"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.confirm"), "confirm_yes"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.edit"), "edit_after_preview"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 		),
 	)
 
@@ -1713,7 +1912,7 @@ func showConfirmationPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSessi
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err != nil {
 		log.Printf("Ошибка отправки предпросмотра: %v", err)
 		return 0
@@ -1739,49 +1938,45 @@ func handleEditAfterPreview(bot *tgbotapi.BotAPI, chatID int64) {
 
 // showAllSettingsPrompt показывает все настройки объявления сразу с кнопками для редактирования
 func showAllSettingsPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	var text strings.Builder
-	text.WriteString("⚙️ *Настройки объявления*\n\n")
+	text.WriteString(i18n.T(locale, "manager.settings.title"))
 
 	// Категория
-	categoryLabel := categoryLabels[session.Ad.Category]
-	if categoryLabel == "" {
-		categoryLabel = session.Ad.Category
-	}
-	text.WriteString(fmt.Sprintf("📂 Категория: %s\n", categoryLabel))
+	categoryLabel := categoryName(session.Ad.Category)
+	text.WriteString(i18n.T(locale, "manager.settings.category", categoryLabel))
 
-	// Режим (для категории "other" не показываем, так как он автоматический)
-	if session.Ad.Category != "other" {
-		modeLabel := modeLabels[session.Ad.Category][session.Ad.Mode]
-		if modeLabel == "" {
-			modeLabel = session.Ad.Mode
-		}
-		text.WriteString(fmt.Sprintf("🎯 Режим: %s\n", modeLabel))
+	// Режим не показываем, если у категории он один и выбирается автоматически
+	if _, single := singleMode(session.Ad.Category); !single {
+		modeLabel := modeName(session.Ad.Category, session.Ad.Mode)
+		text.WriteString(i18n.T(locale, "manager.settings.mode", modeLabel))
 	}
 
 	// Тег
-	tagLabel := tagLabels[session.Ad.Category][session.Ad.Tag]
-	if tagLabel == "" {
-		tagLabel = session.Ad.Tag
-	}
-	text.WriteString(fmt.Sprintf("🏷 Тег: %s\n", tagLabel))
+	tagLabel := tagName(session.Ad.Category, session.Ad.Tag)
+	text.WriteString(i18n.T(locale, "manager.settings.tag", tagLabel))
+	text.WriteString(i18n.T(locale, "manager.settings.price", formatPrice(locale, session.Ad.Price)))
 
 	// Премиум
-	premiumLabel := "нет"
+	premiumLabel := i18n.T(locale, "manager.no")
 	if session.Ad.IsPremium {
-		premiumLabel = "да"
+		premiumLabel = i18n.T(locale, "manager.yes")
+	} else if session.PremiumQueued {
+		premiumLabel = i18n.T(locale, "manager.settings.premium_queued")
 	}
-	text.WriteString(fmt.Sprintf("⭐ Премиум: %s\n", premiumLabel))
+	text.WriteString(i18n.T(locale, "manager.settings.premium", premiumLabel))
 
 	// Срок действия
-	durationLabel := "не задан"
+	durationLabel := i18n.T(locale, "manager.settings.duration_none")
 	if session.DurationDays > 0 {
-		durationLabel = fmt.Sprintf("%d дн.", session.DurationDays)
+		durationLabel = formatDays(locale, session.DurationDays)
 	} else if !session.Ad.ExpiresAt.IsZero() {
-		durationLabel = session.Ad.ExpiresAt.Format("02.01.2006")
+		durationLabel = formatDate(locale, session.Ad.ExpiresAt)
 	}
-	text.WriteString(fmt.Sprintf("⏱ Срок действия: %s\n\n", durationLabel))
+	text.WriteString(i18n.T(locale, "manager.settings.duration", durationLabel))
+	text.WriteString(i18n.T(locale, "manager.settings.auto_renew", autoRenewLabel(locale, session.Ad)))
 
-	text.WriteString("Выберите, что хотите изменить:")
+	text.WriteString(i18n.T(locale, "manager.settings.choose"))
 
 	var rows [][]tgbotapi.InlineKeyboardButton
 
@@ -1789,26 +1984,30 @@ func showAllSettingsPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSessio
 	// Для категории "other" не показываем кнопку редактирования режима
 	if session.Ad.Category != "other" {
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("📂 Категория", "category_edit"),
-			tgbotapi.NewInlineKeyboardButtonData("🎯 Режим", "mode_edit"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.settings.button.category"), "category_edit"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.settings.button.mode"), "mode_edit"),
 		))
 	} else {
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("📂 Категория", "category_edit"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.settings.button.category"), "category_edit"),
 		))
 	}
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("🏷 Тег", "tag_edit"),
-		tgbotapi.NewInlineKeyboardButtonData("⏱ Срок", "duration_edit"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.settings.button.tag"), "tag_edit"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.settings.button.duration"), "duration_edit"),
 	))
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("⭐ Премиум", "premium_edit"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.settings.button.premium"), "premium_edit"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.settings.button.auto_renew"), "autorenew_edit"),
 	))
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("✅ Сохранить", "save_from_settings"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.settings.button.price"), "price_edit"),
 	))
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", "back"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.save"), "save_from_settings"),
+	))
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "back"),
 	))
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
@@ -1817,7 +2016,7 @@ func showAllSettingsPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSessio
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 		// Удаляем предыдущие сообщения после отправки настроек
@@ -1827,27 +2026,22 @@ func showAllSettingsPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSessio
 
 // handleSaveFromSettings сохраняет объявление из экрана настроек
 func handleSaveFromSettings(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	session := getSession(chatID)
 	if session == nil {
 		return
 	}
 
-	// Проверяем, что ClientID установлен
-	if session.Ad.ClientID == "" {
-		sendText(bot, chatID, "❌ Необходимо указать ID клиента. Вернитесь к предпросмотру и введите ID клиента.")
+	// Проверяем, что владелец указан
+	if session.Ad.OwnerID == nil {
+		sendText(bot, chatID, i18n.T(locale, "manager.owner.id_required"))
 		return
 	}
 
-	// Если UserID не установлен, устанавливаем его из ClientID
-	if session.Ad.UserID == 0 && session.Ad.ClientID != "" {
-		if userID, err := strconv.ParseInt(session.Ad.ClientID, 10, 64); err == nil {
-			session.Ad.UserID = userID
-		}
-	}
-
 	// Сохраняем объявление
 	if err := persistAd(bot, session); err != nil {
-		sendText(bot, chatID, "❌ Не удалось сохранить объявление: "+err.Error())
+		log.Printf("failed to save ad of manager %d: %v", chatID, err)
+		sendText(bot, chatID, i18n.T(locale, "manager.ad.save_failed", i18n.Message(locale, err, "manager.error.internal")))
 		return
 	}
 
@@ -1856,41 +2050,36 @@ func handleSaveFromSettings(bot *tgbotapi.BotAPI, chatID int64) {
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ В меню", "menu_main"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.menu"), "menu_main"),
 		),
 	)
 
 	var text string
 	if session.Operation == opCreate {
-		text = fmt.Sprintf("✅ Объявление #%d опубликовано.", session.Ad.ID)
+		text = i18n.T(locale, "manager.ad.published", session.Ad.ID)
 	} else {
-		text = fmt.Sprintf("✅ Объявление #%d обновлено.", session.Ad.ID)
+		text = i18n.T(locale, "manager.ad.updated", session.Ad.ID)
 	}
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
 }
 
 func handleConfirmYes(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	session := getSession(chatID)
 	if session == nil {
 		return
 	}
 
-	// Если UserID не установлен, устанавливаем его из ClientID
-	if session.Ad.UserID == 0 && session.Ad.ClientID != "" {
-		if userID, err := strconv.ParseInt(session.Ad.ClientID, 10, 64); err == nil {
-			session.Ad.UserID = userID
-		}
-	}
-
 	if err := persistAd(bot, session); err != nil {
-		sendText(bot, chatID, "❌ Не удалось сохранить объявление: "+err.Error())
+		log.Printf("failed to save ad of manager %d: %v", chatID, err)
+		sendText(bot, chatID, i18n.T(locale, "manager.ad.save_failed", i18n.Message(locale, err, "manager.error.internal")))
 		return
 	}
 
@@ -1899,21 +2088,21 @@ func handleConfirmYes(bot *tgbotapi.BotAPI, chatID int64) {
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ В меню", "menu_main"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.menu"), "menu_main"),
 		),
 	)
 
 	var text string
 	if session.Operation == opCreate {
-		text = fmt.Sprintf("✅ Объявление #%d опубликовано.", session.Ad.ID)
+		text = i18n.T(locale, "manager.ad.published", session.Ad.ID)
 	} else {
-		text = fmt.Sprintf("✅ Объявление #%d обновлено.", session.Ad.ID)
+		text = i18n.T(locale, "manager.ad.updated", session.Ad.ID)
 	}
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
@@ -1929,6 +2118,7 @@ func handleConfirmNo(bot *tgbotapi.BotAPI, chatID int64) {
 }
 
 func handleRenewDurationCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
+	locale := telegramUserLocale(chatID)
 	session := getSession(chatID)
 	if session == nil {
 		return
@@ -1937,39 +2127,52 @@ func handleRenewDurationCallback(bot *tgbotapi.BotAPI, chatID int64, data string
 	daysStr := strings.TrimPrefix(data, "renew_duration_")
 	days, err := strconv.Atoi(daysStr)
 	if err != nil || !isValidDuration(days) {
-		sendText(bot, chatID, "❌ Неверный срок.")
+		sendText(bot, chatID, i18n.T(locale, "manager.duration.invalid"))
 		return
 	}
 
 	session.Ad.Status = models.AdStatusActive
-	session.Ad.PreExpiryNotified = false
+	session.Ad.RemovedAt = nil
 	session.Ad.ExpiresAt = time.Now().Add(time.Duration(days) * 24 * time.Hour)
-	if err := db.DB.Save(&session.Ad).Error; err != nil {
-		sendText(bot, chatID, "❌ Не удалось обновить объявление.")
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&session.Ad).Error; err != nil {
+			return err
+		}
+		return recordRenewal(tx, session.Ad, chatID, days, models.RenewalSourceManager)
+	}); err != nil {
+		log.Printf("failed to renew ad %d: %v", session.Ad.ID, err)
+		sendText(bot, chatID, i18n.T(locale, "manager.ad.update_failed"))
 		return
 	}
+	observeRenewal(models.RenewalSourceManager)
+	recordManagerAction(chatID, models.ManagerActionAdRenewed, session.Ad.ID, "")
+
+	publishAdToChannel(bot, &session.Ad, true)
+	go notifyFavoritesRenewed(bot, session.Ad)
 
-	notifyUser(bot, session.Ad.UserID, fmt.Sprintf("Ваше объявление «%s» продлено до %s.", session.Ad.Title, session.Ad.ExpiresAt.Format("02.01.2006")))
+	recipientLocale := ownerLocale(session.Ad)
+	notifyUser(bot, ownerTelegramID(session.Ad), i18n.T(recipientLocale, "owner.ad_extended", session.Ad.Title, formatDate(recipientLocale, session.Ad.ExpiresAt)))
 
 	deleteBotMessages(bot, chatID, session)
 	clearSession(chatID)
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ В меню", "menu_main"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.menu"), "menu_main"),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("✅ Объявление #%d продлено до %s.", session.Ad.ID, session.Ad.ExpiresAt.Format("02.01.2006 15:04")))
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.ad.renewed", session.Ad.ID, formatDateTime(locale, session.Ad.ExpiresAt)))
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
 }
 
 func handleBack(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
 	session := getSession(chatID)
 	if session == nil {
 		showMainMenu(bot, chatID)
@@ -1989,16 +2192,16 @@ func handleBack(bot *tgbotapi.BotAPI, chatID int64) {
 		// Показываем запрос ID
 		keyboard := tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("⏭ Пропустить (указать ID вручную)", "skip_user_id"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.skip_user_id"), "skip_user_id"),
 			),
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 			),
 		)
-		msg := tgbotapi.NewMessage(chatID, "🆔 *ID пользователя*\n\nПерешлите любое сообщение от пользователя, чтобы автоматически получить его ID.\n\nИли нажмите \"Пропустить\", чтобы ввести ID вручную.")
+		msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.owner.id_prompt"))
 		msg.ParseMode = "Markdown"
 		msg.ReplyMarkup = keyboard
-		sentMsg, err := bot.Send(msg)
+		sentMsg, err := sendReply(bot, msg)
 		if err == nil {
 			addBotMessage(chatID, sentMsg.MessageID)
 		}
@@ -2007,16 +2210,16 @@ func handleBack(bot *tgbotapi.BotAPI, chatID int64) {
 		// Показываем запрос ID вместо username
 		keyboard := tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("⏭ Пропустить (указать ID вручную)", "skip_user_id"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.skip_user_id"), "skip_user_id"),
 			),
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 			),
 		)
-		msg := tgbotapi.NewMessage(chatID, "🆔 *ID пользователя*\n\nПерешлите любое сообщение от пользователя, чтобы автоматически получить его ID.\n\nИли нажмите \"Пропустить\", чтобы ввести ID вручную.")
+		msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.owner.id_prompt"))
 		msg.ParseMode = "Markdown"
 		msg.ReplyMarkup = keyboard
-		sentMsg, err := bot.Send(msg)
+		sentMsg, err := sendReply(bot, msg)
 		if err == nil {
 			addBotMessage(chatID, sentMsg.MessageID)
 		}
@@ -2048,20 +2251,21 @@ func handleBack(bot *tgbotapi.BotAPI, chatID int64) {
 }
 
 func showPhotoPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("⏭ Пропустить", "skip_photo"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.skip"), "skip_photo"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", getBackCallback(session)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), getBackCallback(session)),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "📸 *Шаг 1: Фото*\n\nОтправьте фото объявления или пропустите этот шаг.")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.step.photo"))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sendReply(bot, msg)
 	if err == nil {
 		addBotMessage(chatID, sentMsg.MessageID)
 	}
@@ -2078,18 +2282,18 @@ func getBackCallback(session *adSession) string {
 func persistAd(bot *tgbotapi.BotAPI, session *adSession) error {
 	// Валидация обязательных полей
 	if session.Ad.Title == "" {
-		return fmt.Errorf("заголовок не может быть пустым")
+		return i18n.NewError("manager.error.title_empty")
 	}
 	if session.Ad.Desc == "" {
-		return fmt.Errorf("описание не может быть пустым")
+		return i18n.NewError("manager.error.desc_empty")
 	}
 	// Username опционален - если не указан, оставляем пустым (не используем user_{id})
-	// Это нормально, так как для поиска в профиле используется client_id, а не username
+	// Это нормально, так как для поиска в профиле используется владелец (owner_id), а не username
 	if session.Ad.Username == "" {
-		log.Printf("Предупреждение: Username не указан, оставляем пустым. ClientID=%s", session.Ad.ClientID)
+		log.Printf("Предупреждение: Username не указан, оставляем пустым. OwnerID=%v", session.Ad.OwnerID)
 	}
 	if session.Ad.Category == "" {
-		return fmt.Errorf("категория не может быть пустой")
+		return i18n.NewError("manager.error.category_empty")
 	}
 	// Для категории "other" режим автоматически устанавливается как "general"
 	// Также исправляем, если случайно сохранилось русское название "Объявление"
@@ -2099,25 +2303,16 @@ func persistAd(bot *tgbotapi.BotAPI, session *adSession) error {
 		}
 	}
 	if session.Ad.Mode == "" {
-		return fmt.Errorf("режим не может быть пустым")
+		return i18n.NewError("manager.error.mode_empty")
 	}
 	if session.Ad.Tag == "" {
-		return fmt.Errorf("тег не может быть пустым")
+		return i18n.NewError("manager.error.tag_empty")
 	}
-	if session.Ad.ClientID == "" {
-		return fmt.Errorf("ID клиента не может быть пустым")
+	if session.Ad.OwnerID == nil {
+		return i18n.NewError("manager.error.owner_empty")
 	}
 	if session.DurationDays == 0 && session.Operation == opCreate {
-		return fmt.Errorf("срок действия не может быть пустым")
-	}
-
-	// Если UserID не установлен, устанавливаем его из ClientID
-	if session.Ad.UserID == 0 && session.Ad.ClientID != "" {
-		if userID, err := strconv.ParseInt(session.Ad.ClientID, 10, 64); err == nil {
-			session.Ad.UserID = userID
-		} else {
-			log.Printf("Предупреждение: не удалось преобразовать ClientID %s в UserID: %v", session.Ad.ClientID, err)
-		}
+		return i18n.NewError("manager.error.duration_empty")
 	}
 
 	now := time.Now()
@@ -2128,43 +2323,121 @@ func persistAd(bot *tgbotapi.BotAPI, session *adSession) error {
 		session.Ad.ExpiresAt = now.Add(7 * 24 * time.Hour)
 	}
 
-	session.Ad.PreExpiryNotified = false
 	session.Ad.Status = models.AdStatusActive
+	session.Ad.RemovedAt = nil
 
-	log.Printf("Сохранение объявления: Title=%s, Username=%s, ClientID=%s, UserID=%d, Category=%s, Mode=%s, Tag=%s",
-		session.Ad.Title, session.Ad.Username, session.Ad.ClientID, session.Ad.UserID, session.Ad.Category, session.Ad.Mode, session.Ad.Tag)
+	log.Printf("Сохранение объявления: Title=%s, Username=%s, OwnerID=%d, Category=%s, Mode=%s, Tag=%s",
+		session.Ad.Title, session.Ad.Username, *session.Ad.OwnerID, session.Ad.Category, session.Ad.Mode, session.Ad.Tag)
 
+	// Каждое сохранение записывается новой версией в историю объявления
+	previousPrice := session.Ad.Price
 	switch session.Operation {
 	case opCreate:
-		if err := db.DB.Create(&session.Ad).Error; err != nil {
+		if err := db.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Omit("Owner").Create(&session.Ad).Error; err != nil {
+				return err
+			}
+			return saveAdRevision(tx, session.Ad, session.ChatID, nil)
+		}); err != nil {
 			log.Printf("Ошибка создания объявления: %v", err)
 			return err
 		}
-		log.Printf("Объявление создано: ID=%d, Username=%s, ClientID=%s, UserID=%d", session.Ad.ID, session.Ad.Username, session.Ad.ClientID, session.Ad.UserID)
+		adsCreatedTotal.WithLabelValues(session.Ad.Category).Inc()
+		recordManagerAction(session.ChatID, models.ManagerActionAdCreated, session.Ad.ID, "")
+		log.Printf("Объявление создано: ID=%d, Username=%s, OwnerID=%d", session.Ad.ID, session.Ad.Username, *session.Ad.OwnerID)
 	case opEdit:
-		if err := db.DB.Save(&session.Ad).Error; err != nil {
+		if err := db.DB.Transaction(func(tx *gorm.DB) error {
+			if err := ensureBaseRevision(tx, session.Ad.ID); err != nil {
+				return err
+			}
+			// Сессия хранит копию объявления на момент открытия мастера. Пока менеджер
+			// редактирует, срок, премиум, поднятие и пост в канале могут измениться
+			// продлением, оплатой или очередью премиума, поэтому записываем только поля мастера
+			var current models.Ad
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, session.Ad.ID).Error; err != nil {
+				return err
+			}
+			previousPrice = current.Price
+			if err := tx.Model(&current).Updates(editedAdFields(session)).Error; err != nil {
+				return err
+			}
+			if err := tx.First(&session.Ad, session.Ad.ID).Error; err != nil {
+				return err
+			}
+			return saveAdRevision(tx, session.Ad, session.ChatID, nil)
+		}); err != nil {
 			log.Printf("Ошибка обновления объявления: %v", err)
 			return err
 		}
-		log.Printf("Объявление обновлено: ID=%d, Username=%s, ClientID=%s, UserID=%d", session.Ad.ID, session.Ad.Username, session.Ad.ClientID, session.Ad.UserID)
+		recordManagerAction(session.ChatID, models.ManagerActionAdEdited, session.Ad.ID, "")
+		log.Printf("Объявление обновлено: ID=%d, Username=%s, OwnerID=%d", session.Ad.ID, session.Ad.Username, *session.Ad.OwnerID)
+	}
+
+	publishAdToChannel(bot, &session.Ad, session.Operation == opCreate)
+	go dispatchSavedSearchAlerts(bot, session.Ad)
+	if session.Operation == opEdit {
+		go notifyFavoritesPriceChanged(bot, session.Ad, previousPrice)
+	}
+
+	if session.PremiumQueued && !session.Ad.IsPremium {
+		if _, err := bookPremium(session.Ad, now, 0, session.ChatID); err != nil {
+			log.Printf("Ошибка постановки объявления %d в очередь на премиум: %v", session.Ad.ID, err)
+		} else {
+			processPremiumQueue(bot)
+		}
+		session.PremiumQueued = false
 	}
 
 	// Уведомляем пользователя о публикации объявления
-	if session.Ad.UserID != 0 {
-		message := fmt.Sprintf("✅ Ваше объявление «%s» опубликовано до %s.\n\nДля управления обратитесь к %s.", session.Ad.Title, session.Ad.ExpiresAt.Format("02.01.2006"), managerHelpLink)
-		notifyUser(bot, session.Ad.UserID, message)
+	if ownerID := ownerTelegramID(session.Ad); ownerID != 0 {
+		locale := ownerLocale(session.Ad)
+		message := i18n.T(locale, "owner.ad_published", session.Ad.Title, formatDate(locale, session.Ad.ExpiresAt), managerHelpLink)
+		notifyUser(bot, ownerID, message)
 	} else {
-		log.Printf("Предупреждение: UserID равен 0, уведомление не отправлено. ClientID=%s", session.Ad.ClientID)
+		log.Printf("Предупреждение: у владельца объявления %d нет Telegram ID, уведомление не отправлено", session.Ad.ID)
 	}
 
 	return nil
 }
 
+// editedAdFields — поля, которые меняет мастер редактирования объявления
+func editedAdFields(session *adSession) map[string]interface{} {
+	ad := session.Ad
+	fields := map[string]interface{}{
+		"owner_id":   ad.OwnerID,
+		"username":   ad.Username,
+		"title":      ad.Title,
+		"desc":       ad.Desc,
+		"photo_id":   ad.PhotoID,
+		"photo_path": ad.PhotoPath,
+		"category":   ad.Category,
+		"mode":       ad.Mode,
+		"tag":        ad.Tag,
+		"price":      ad.Price,
+		"status":     ad.Status,
+		"removed_at": ad.RemovedAt,
+	}
+	if session.DurationDays > 0 {
+		fields["expires_at"] = ad.ExpiresAt
+	}
+	if session.PremiumEdited {
+		fields["is_premium"] = ad.IsPremium
+	}
+	if session.AutoRenewEdited {
+		fields["auto_renew_days"] = ad.AutoRenewDays
+		fields["auto_renew_until"] = ad.AutoRenewUntil
+		fields["auto_renew_left"] = ad.AutoRenewLeft
+	}
+	return fields
+}
+
+// setAdStatus меняет статус объявления; при снятии с биржи запоминает время для статистики
 func setAdStatus(adID uint, status string) error {
-	return db.DB.Model(&models.Ad{}).Where("id = ?", adID).Updates(map[string]interface{}{
-		"status":              status,
-		"pre_expiry_notified": false,
-	}).Error
+	updates := map[string]interface{}{"status": status}
+	if status == models.AdStatusInactive {
+		updates["removed_at"] = time.Now()
+	}
+	return db.DB.Model(&models.Ad{}).Where("id = ?", adID).Updates(updates).Error
 }
 
 func notifyUser(bot *tgbotapi.BotAPI, chatID int64, message string) {
@@ -2172,11 +2445,22 @@ func notifyUser(bot *tgbotapi.BotAPI, chatID int64, message string) {
 		return
 	}
 	msg := tgbotapi.NewMessage(chatID, message)
-	if _, err := bot.Send(msg); err != nil {
+	if err := enqueueMessage(bot, msg); err != nil {
 		log.Printf("failed to notify user %d: %v", chatID, err)
 	}
 }
 
+// enqueueMessage ставит уведомление в очередь исходящих сообщений. Если очередь
+// недоступна, сообщение отправляется сразу.
+func enqueueMessage(bot *tgbotapi.BotAPI, c tgbotapi.Chattable) error {
+	if err := outbox.Enqueue(c); err != nil {
+		log.Printf("outbox: failed to enqueue message, sending directly: %v", err)
+		_, err = bot.Request(c)
+		return err
+	}
+	return nil
+}
+
 func persistSessionsCleanup() {
 	sessionRegistry.Lock()
 	defer sessionRegistry.Unlock()
@@ -2191,27 +2475,38 @@ func persistSessionsCleanup() {
 func deleteMessageWithEffect(bot *tgbotapi.BotAPI, chatID int64, messageID int) {
 	// Сначала редактируем сообщение для эффекта "таноса" (постепенное исчезновение)
 	// Эффект "таноса" - постепенное уменьшение текста до точек
-	editMsg1 := tgbotapi.NewEditMessageText(chatID, messageID, ".")
-	bot.Request(editMsg1)
+	// Правки и удаление идут через очередь: при флуд-контроле они подождут, а не потеряются
+	discardRequest(bot, tgbotapi.NewEditMessageText(chatID, messageID, "."))
 	time.Sleep(200 * time.Millisecond)
 
-	editMsg2 := tgbotapi.NewEditMessageText(chatID, messageID, "..")
-	bot.Request(editMsg2)
+	discardRequest(bot, tgbotapi.NewEditMessageText(chatID, messageID, ".."))
 	time.Sleep(200 * time.Millisecond)
 
-	editMsg3 := tgbotapi.NewEditMessageText(chatID, messageID, "...")
-	bot.Request(editMsg3)
+	discardRequest(bot, tgbotapi.NewEditMessageText(chatID, messageID, "..."))
 	time.Sleep(200 * time.Millisecond)
 
-	// Удаляем сообщение
 	time.Sleep(100 * time.Millisecond)
-	_, _ = bot.Request(tgbotapi.NewDeleteMessage(chatID, messageID))
+	discardMessage(bot, chatID, messageID)
 }
 
 // deleteMessage удаляет сообщение без эффекта (для сообщений менеджера)
 func deleteMessage(bot *tgbotapi.BotAPI, chatID int64, messageID int) {
 	time.Sleep(4 * time.Second) // Увеличена задержка для плавного удаления сообщений менеджера
-	_, _ = bot.Request(tgbotapi.NewDeleteMessage(chatID, messageID))
+	discardMessage(bot, chatID, messageID)
+}
+
+// discardMessage ставит удаление служебного сообщения в очередь; неудачное удаление
+// не попадает в dead letter
+func discardMessage(bot *tgbotapi.BotAPI, chatID int64, messageID int) {
+	discardRequest(bot, tgbotapi.NewDeleteMessage(chatID, messageID))
+}
+
+// discardRequest ставит в очередь служебный запрос, который не жалко потерять;
+// если очередь недоступна, запрос отправляется сразу
+func discardRequest(bot *tgbotapi.BotAPI, c tgbotapi.Chattable) {
+	if err := outbox.EnqueueDiscardable(c); err != nil {
+		_, _ = bot.Request(c)
+	}
 }
 
 // deleteBotMessagesWithEffect удаляет все сообщения бота с эффектом "таноса" постепенно
@@ -2364,35 +2659,29 @@ func isValidDuration(days int) bool {
 	}
 }
 
-func renderAdSummaryWithExpiry(ad models.Ad) string {
-	premium := "нет"
+func renderAdSummaryWithExpiry(locale string, ad models.Ad) string {
+	premium := i18n.T(locale, "manager.no")
 	if ad.IsPremium {
-		premium = "да"
+		premium = i18n.T(locale, "manager.yes")
+		if ad.PremiumUntil != nil {
+			premium = i18n.T(locale, "manager.until", formatDateTime(locale, *ad.PremiumUntil))
+		}
 	}
 
-	categoryLabel := categoryLabels[ad.Category]
-	if categoryLabel == "" {
-		categoryLabel = ad.Category
-	}
+	categoryLabel := categoryName(ad.Category)
 
-	modeLabel := modeLabels[ad.Category][ad.Mode]
-	if modeLabel == "" {
-		modeLabel = ad.Mode
-	}
+	modeLabel := modeName(ad.Category, ad.Mode)
 
-	tagLabel := tagLabels[ad.Category][ad.Tag]
-	if tagLabel == "" {
-		tagLabel = ad.Tag
-	}
+	tagLabel := tagName(ad.Category, ad.Tag)
 
 	var statusLabel string
 	switch ad.Status {
 	case models.AdStatusExpired:
-		statusLabel = "Истекло"
+		statusLabel = i18n.T(locale, "manager.ad.status.expired")
 	case models.AdStatusInactive:
-		statusLabel = "Снято"
+		statusLabel = i18n.T(locale, "manager.ad.status.inactive")
 	default:
-		statusLabel = "Активно"
+		statusLabel = i18n.T(locale, "manager.ad.status.active")
 	}
 
 	// Экранируем специальные символы Markdown в описании
@@ -2403,16 +2692,7 @@ func renderAdSummaryWithExpiry(ad models.Ad) string {
 		escapedDesc = escapedDesc[:maxDescLength] + "..."
 	}
 
-	text := fmt.Sprintf(
-		"📋 *Объявление #%d*\n\n"+
-			"📝 Заголовок: %s\n"+
-			"📄 Описание: %s\n"+
-			"👤 Контакт: @%s\n"+
-			"📂 Категория: %s\n"+
-			"🎯 Режим: %s\n"+
-			"🏷 Тег: %s\n"+
-			"⭐ Премиум: %s\n"+
-			"📊 Статус: %s",
+	text := i18n.T(locale, "manager.ad.summary",
 		ad.ID,
 		escapeMarkdown(ad.Title),
 		escapedDesc,
@@ -2420,71 +2700,59 @@ func renderAdSummaryWithExpiry(ad models.Ad) string {
 		categoryLabel,
 		modeLabel,
 		tagLabel,
+		formatPrice(locale, ad.Price),
 		premium,
 		statusLabel,
 	)
 
 	// Если объявление выложено (активно), показываем дату окончания
 	if ad.Status == models.AdStatusActive {
-		text += fmt.Sprintf("\n⏱ *Действительно до:* %s", ad.ExpiresAt.Format("02.01.2006 15:04"))
+		text += i18n.T(locale, "manager.ad.expires", formatDateTime(locale, ad.ExpiresAt))
+	}
+	if ad.BumpedAt != nil {
+		text += i18n.T(locale, "manager.ad.bumped_at", formatDateTime(locale, *ad.BumpedAt))
 	}
 
 	return text
 }
 
-func renderAdPreview(session *adSession) string {
+func renderAdPreview(locale string, session *adSession) string {
 	ad := session.Ad
 	if session.DurationDays > 0 {
 		ad.ExpiresAt = time.Now().Add(time.Duration(session.DurationDays) * 24 * time.Hour)
 	}
 
-	premium := "нет"
+	premium := i18n.T(locale, "manager.no")
 	if ad.IsPremium {
-		premium = "да"
+		premium = i18n.T(locale, "manager.yes")
 	}
 
-	categoryLabel := categoryLabels[ad.Category]
-	if categoryLabel == "" {
-		categoryLabel = ad.Category
-	}
+	categoryLabel := categoryName(ad.Category)
 
-	modeLabel := modeLabels[ad.Category][ad.Mode]
-	if modeLabel == "" {
-		modeLabel = ad.Mode
-	}
+	modeLabel := modeName(ad.Category, ad.Mode)
 
-	tagLabel := tagLabels[ad.Category][ad.Tag]
-	if tagLabel == "" {
-		tagLabel = ad.Tag
-	}
+	tagLabel := tagName(ad.Category, ad.Tag)
 
 	// Экранируем специальные символы Markdown в тексте объявления
 	escapedTitle := escapeMarkdown(ad.Title)
 	escapedDesc := escapeMarkdown(ad.Desc)
 	escapedUsername := escapeMarkdown(ad.Username)
-	escapedClientID := escapeMarkdown(ad.ClientID)
-
-	return fmt.Sprintf(
-		"📋 *Предпросмотр объявления*\n\n"+
-			"📝 Заголовок: %s\n"+
-			"📄 Описание: %s\n"+
-			"👤 Контакт: @%s\n"+
-			"📂 Категория: %s\n"+
-			"🎯 Режим: %s\n"+
-			"🏷 Тег: %s\n"+
-			"⭐ Премиум: %s\n"+
-			"🆔 ID клиента: %s\n"+
-			"⏱ Действительно до: %s\n\n"+
-			"Подтвердите публикацию:",
+	escapedClientID := ""
+	if ownerID := ownerTelegramID(ad); ownerID != 0 {
+		escapedClientID = strconv.FormatInt(ownerID, 10)
+	}
+
+	return i18n.T(locale, "manager.ad.preview",
 		escapedTitle,
 		escapedDesc, // Показываем полный текст описания, без обрезки
 		escapedUsername,
 		categoryLabel,
 		modeLabel,
 		tagLabel,
+		formatPrice(locale, ad.Price),
 		premium,
 		escapedClientID,
-		ad.ExpiresAt.Format("02.01.2006 15:04"),
+		formatDateTime(locale, ad.ExpiresAt),
 	)
 }
 
@@ -2503,74 +2771,88 @@ func escapeMarkdown(text string) string {
 	return text
 }
 
+// sendText отвечает в чат текстом (см. sendReply) и запоминает сообщение, чтобы удалить
+// его вместе с остальными сообщениями сессии
 func sendText(bot *tgbotapi.BotAPI, chatID int64, text string) {
 	if strings.TrimSpace(text) == "" {
 		return
 	}
-	msg := tgbotapi.NewMessage(chatID, text)
-	sentMsg, err := bot.Send(msg)
-	if err != nil {
-		log.Printf("failed to send message: %v", err)
-	} else {
+	sentMsg, err := sendReply(bot, tgbotapi.NewMessage(chatID, text))
+	switch {
+	case err == nil:
 		addBotMessage(chatID, sentMsg.MessageID)
+	case !outbox.Retryable(err):
+		log.Printf("failed to send message: %v", err)
 	}
 }
 
-func startAdSchedulers(bot *tgbotapi.BotAPI) {
-	go func() {
-		ticker := time.NewTicker(time.Minute * 30)
-		defer ticker.Stop()
-		for range ticker.C {
-			persistSessionsCleanup()
-			processPreExpiry(bot)
-			processExpired(bot)
+// sendReply отправляет ответ на действие пользователя или менеджера сразу: его ждут, а ID
+// сообщения нужен, чтобы потом удалить или изменить его. Запрос идёт через HTTP-клиент бота
+// (outbox.Client) с общим лимитом Telegram, который сам повторяет флуд-контроль и сбои
+// сервера. Если и это не помогло, сообщение уходит в очередь: оно будет доставлено, но
+// вызывающий код получает ошибку и не знает его ID.
+func sendReply(bot *tgbotapi.BotAPI, c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	sentMsg, err := bot.Send(c)
+	if err != nil && outbox.Retryable(err) {
+		log.Printf("failed to send reply, queueing: %v", err)
+		if err := outbox.Enqueue(c); err != nil {
+			log.Printf("outbox: failed to enqueue reply: %v", err)
 		}
-	}()
+	}
+	return sentMsg, err
 }
 
-func processPreExpiry(bot *tgbotapi.BotAPI) {
+func processExpired(bot *tgbotapi.BotAPI) error {
 	now := time.Now()
-	cutoff := now.Add(24 * time.Hour)
 
-	var ads []models.Ad
-	if err := db.DB.Where("status = ? AND expires_at BETWEEN ? AND ? AND pre_expiry_notified = ?", models.AdStatusActive, now, cutoff, false).Find(&ads).Error; err != nil {
-		log.Printf("pre-expiry scan failed: %v", err)
-		return
+	// Оплаченный премиум заканчивается раньше самого объявления
+	var premiumAds []models.Ad
+	if err := db.DB.Where("is_premium = ? AND premium_until <= ?", true, now).Find(&premiumAds).Error; err != nil {
+		log.Printf("premium expiry scan failed: %v", err)
 	}
-
-	for _, ad := range ads {
-		if ad.UserID == 0 {
+	for _, ad := range premiumAds {
+		if err := db.DB.Model(&models.Ad{}).Where("id = ?", ad.ID).Updates(map[string]interface{}{
+			"is_premium":    false,
+			"premium_until": nil,
+		}).Error; err != nil {
+			log.Printf("failed to clear premium for ad %d: %v", ad.ID, err)
 			continue
 		}
-		text := fmt.Sprintf("Напоминание: срок действия вашего объявления «%s» истекает %s. Свяжитесь с %s, чтобы продлить размещение.", ad.Title, ad.ExpiresAt.Format("02.01.2006 15:04"), managerHelpLink)
-		notifyUser(bot, ad.UserID, text)
-		if err := db.DB.Model(&models.Ad{}).Where("id = ?", ad.ID).Update("pre_expiry_notified", true).Error; err != nil {
-			log.Printf("pre-expiry flag update failed for ad %d: %v", ad.ID, err)
-		}
+		ad.IsPremium = false
+		ad.PremiumUntil = nil
+		publishAdToChannel(bot, &ad, false)
 	}
-}
-
-func processExpired(bot *tgbotapi.BotAPI) {
-	now := time.Now()
 
 	var ads []models.Ad
-	if err := db.DB.Where("status = ? AND expires_at <= ?", models.AdStatusActive, now).Find(&ads).Error; err != nil {
-		log.Printf("expiry scan failed: %v", err)
-		return
+	if err := withOwner(db.DB).Where("status = ? AND expires_at <= ?", models.AdStatusActive, now).Find(&ads).Error; err != nil {
+		return fmt.Errorf("expiry scan failed: %w", err)
 	}
 
 	for _, ad := range ads {
-		if err := db.DB.Model(&models.Ad{}).Where("id = ?", ad.ID).Updates(map[string]interface{}{
-			"status":              models.AdStatusExpired,
-			"pre_expiry_notified": false,
-		}).Error; err != nil {
+		if ad.AutoRenewDays > 0 {
+			renewed, ok, err := autoRenewAd(ad.ID, now)
+			if err != nil {
+				log.Printf("failed to auto-renew ad %d: %v", ad.ID, err)
+				continue
+			}
+			if ok {
+				renewed.Owner = ad.Owner
+				notifyAutoRenewed(bot, renewed)
+				continue
+			}
+		}
+
+		if err := db.DB.Model(&models.Ad{}).Where("id = ?", ad.ID).Update("status", models.AdStatusExpired).Error; err != nil {
 			log.Printf("failed to mark ad %d expired: %v", ad.ID, err)
 			continue
 		}
+		adsExpiredTotal.WithLabelValues(ad.Category).Inc()
 
-		if ad.UserID != 0 {
-			text := fmt.Sprintf("Ваше объявление «%s» больше не отображается на бирже. Свяжитесь с %s, чтобы поднять его снова.", ad.Title, managerHelpLink)
-			notifyUser(bot, ad.UserID, text)
-		}
+		removeAdFromChannel(bot, &ad)
+
+		locale := ownerLocale(ad)
+		text := i18n.T(locale, "owner.expired", ad.Title, managerHelpLink)
+		notifyAdOwner(bot, ad, models.AdNotificationExpired, 0, text, ownerActionsKeyboard(locale, ad.ID, false))
 	}
+	return nil
 }
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// autoRenewLimits — варианты ограничения числа автопродлений (0 — без ограничений)
+var autoRenewLimits = []int{0, 4, 8, 12}
+
+// handleAutoRenewCallback обрабатывает настройку автопродления из экрана настроек:
+// autorenew_edit, autorenew_days_<N>, autorenew_limit_<N>, autorenew_until, autorenew_off
+func handleAutoRenewCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
+	session := getSession(chatID)
+	if session == nil {
+		return
+	}
+
+	switch {
+	case data == "autorenew_edit":
+		session.Stage = stageAwaitAutoRenew
+		showAutoRenewPrompt(bot, chatID, session)
+	case data == "autorenew_off":
+		clearAutoRenew(&session.Ad)
+		session.AutoRenewEdited = true
+		session.Stage = stageAwaitSettings
+		showAllSettingsPrompt(bot, chatID, session)
+	case data == "autorenew_until":
+		session.Stage = stageAwaitAutoRenewUntil
+		sendText(bot, chatID, i18n.T(telegramUserLocale(chatID), "manager.auto_renew.until_prompt"))
+	case strings.HasPrefix(data, "autorenew_days_"):
+		days, err := strconv.Atoi(strings.TrimPrefix(data, "autorenew_days_"))
+		if err != nil || !isValidDuration(days) {
+			return
+		}
+		clearAutoRenew(&session.Ad)
+		session.Ad.AutoRenewDays = days
+		session.AutoRenewEdited = true
+		showAutoRenewLimitPrompt(bot, chatID, session)
+	case strings.HasPrefix(data, "autorenew_limit_"):
+		limit, err := strconv.Atoi(strings.TrimPrefix(data, "autorenew_limit_"))
+		if err != nil || limit < 0 || session.Ad.AutoRenewDays == 0 {
+			return
+		}
+		session.Ad.AutoRenewUntil = nil
+		session.Ad.AutoRenewLeft = nil
+		session.AutoRenewEdited = true
+		if limit > 0 {
+			session.Ad.AutoRenewLeft = &limit
+		}
+		session.Stage = stageAwaitSettings
+		showAllSettingsPrompt(bot, chatID, session)
+	}
+}
+
+func showAutoRenewPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 7), "autorenew_days_7"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 14), "autorenew_days_14"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 30), "autorenew_days_30"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.auto_renew.button.off"), "autorenew_off"),
+		),
+	)
+
+	text := i18n.T(locale, "manager.auto_renew.prompt", autoRenewLabel(locale, session.Ad))
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = keyboard
+
+	sentMsg, err := sendReply(bot, msg)
+	if err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+	}
+}
+
+func showAutoRenewLimitPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
+	var row []tgbotapi.InlineKeyboardButton
+	for _, limit := range autoRenewLimits {
+		label := i18n.T(locale, "manager.auto_renew.button.unlimited")
+		if limit > 0 {
+			label = i18n.N(locale, "manager.auto_renew.times", limit, limit)
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("autorenew_limit_%d", limit)))
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		row,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.auto_renew.button.until"), "autorenew_until"),
+		),
+	)
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.auto_renew.limit_prompt", formatDays(locale, session.Ad.AutoRenewDays)))
+	msg.ReplyMarkup = keyboard
+
+	sentMsg, err := sendReply(bot, msg)
+	if err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+	}
+}
+
+// handleAutoRenewUntilInput принимает дату окончания автопродления (включительно)
+func handleAutoRenewUntilInput(bot *tgbotapi.BotAPI, chatID int64, text string, session *adSession) {
+	locale := telegramUserLocale(chatID)
+	date, err := time.ParseInLocation("02.01.2006", text, time.Local)
+	if err != nil {
+		sendText(bot, chatID, i18n.T(locale, "manager.auto_renew.until_invalid"))
+		return
+	}
+	until := date.AddDate(0, 0, 1)
+	if !until.After(time.Now()) {
+		sendText(bot, chatID, i18n.T(locale, "manager.auto_renew.until_past"))
+		return
+	}
+	if session.Ad.AutoRenewDays == 0 {
+		session.Stage = stageAwaitAutoRenew
+		showAutoRenewPrompt(bot, chatID, session)
+		return
+	}
+
+	session.AutoRenewEdited = true
+	session.Ad.AutoRenewLeft = nil
+	session.Ad.AutoRenewUntil = &until
+	session.Stage = stageAwaitSettings
+	showAllSettingsPrompt(bot, chatID, session)
+}
+
+// notifyAutoRenewed обновляет пост в канале и сообщает владельцу об автопродлении
+func notifyAutoRenewed(bot *tgbotapi.BotAPI, ad models.Ad) {
+	publishAdToChannel(bot, &ad, true)
+	go notifyFavoritesRenewed(bot, ad)
+
+	locale := ownerLocale(ad)
+	text := i18n.T(locale, "owner.auto_renewed", ad.Title, formatDays(locale, ad.AutoRenewDays), formatDateTime(locale, ad.ExpiresAt))
+	if !autoRenewActive(ad, ad.ExpiresAt) {
+		text += "\n" + i18n.T(locale, "owner.auto_renew_last")
+	} else if ad.AutoRenewLeft != nil {
+		text += "\n" + i18n.T(locale, "owner.auto_renew_left", *ad.AutoRenewLeft)
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "owner.button.auto_renew_off"), fmt.Sprintf("%sautorenewoff_%d", ownerCallbackPrefix, ad.ID)),
+	))
+	notifyAdOwner(bot, ad, models.AdNotificationAutoRenewed, 0, text, keyboard)
+}
+
+// disableAutoRenewByOwner выключает автопродление по кнопке владельца
+func disableAutoRenewByOwner(bot *tgbotapi.BotAPI, chatID int64, messageID int, userID int64, locale string, adID uint) {
+	ad, err := loadOwnedAd(adID, userID)
+	if err != nil {
+		notifyUser(bot, chatID, "❌ "+paymentErrorText(locale, err))
+		return
+	}
+	if err := db.DB.Model(ad).Updates(map[string]interface{}{
+		"auto_renew_days":  0,
+		"auto_renew_until": nil,
+		"auto_renew_left":  nil,
+	}).Error; err != nil {
+		log.Printf("failed to disable auto-renew for ad %d: %v", ad.ID, err)
+		notifyUser(bot, chatID, i18n.T(locale, "owner.auto_renew_disable_failed"))
+		return
+	}
+
+	notifyManagers(bot, managerIDsFromEnv(), func(managerLocale string) string {
+		return i18n.T(managerLocale, "manager.owner.auto_renew_off", ad.ID, ad.Title)
+	})
+	replaceOwnerMessage(bot, chatID, messageID, i18n.T(locale, "owner.auto_renew_disabled", ad.Title, formatDateTime(locale, ad.ExpiresAt)))
+}
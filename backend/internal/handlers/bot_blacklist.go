@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// maxImportPreviewItems — сколько записей каждой группы показывать в предпросмотре импорта
+const maxImportPreviewItems = 20
+
+func startBlacklistImport(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
+	session := &adSession{
+		Stage:        stageAwaitBlacklistImport,
+		LastActivity: time.Now(),
+		ChatID:       chatID,
+	}
+	setSession(chatID, session)
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_blacklist"),
+		),
+	)
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.blacklist.import_prompt"))
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = keyboard
+
+	sentMsg, err := sendReply(bot, msg)
+	if err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+		go scheduleDeletePreviousMessages(bot, chatID, session, sentMsg.MessageID)
+	}
+}
+
+// handleBlacklistImportDocument разбирает присланный файл и показывает dry-run
+func handleBlacklistImportDocument(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, session *adSession) {
+	locale := userLocale(msg.From)
+	chatID := msg.Chat.ID
+	if msg.Document == nil {
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.import_not_document"))
+		return
+	}
+	if msg.Document.FileSize > maxBlacklistImportSize {
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.import_too_large"))
+		return
+	}
+
+	data, err := downloadTelegramFile(bot, msg.Document.FileID, maxBlacklistImportSize)
+	if err != nil {
+		log.Printf("blacklist import download failed: %v", err)
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.import_download_failed"))
+		return
+	}
+
+	records, invalid, err := parseBlacklistFile(msg.Document.FileName, data)
+	if err != nil {
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.import_parse_failed", err.Error()))
+		return
+	}
+
+	diff, err := diffBlacklist(records, invalid)
+	if err != nil {
+		log.Printf("blacklist import diff failed: %v", err)
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.import_diff_failed"))
+		return
+	}
+
+	session.PendingBlacklist = diff.New
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if len(diff.New) > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.blacklist.button.import_confirm", len(diff.New)), "blacklist_import_confirm"),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.cancel"), "menu_blacklist"),
+	))
+
+	reply := tgbotapi.NewMessage(chatID, renderBlacklistImportDiff(locale, diff))
+	reply.ParseMode = "Markdown"
+	reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	sentMsg, err := sendReply(bot, reply)
+	if err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+		go scheduleDeletePreviousMessages(bot, chatID, session, sentMsg.MessageID)
+	}
+}
+
+func renderBlacklistImportDiff(locale string, diff blacklistImportDiff) string {
+	var text strings.Builder
+	text.WriteString(i18n.T(locale, "manager.blacklist.preview"))
+	text.WriteString(i18n.T(locale, "manager.blacklist.preview_new", len(diff.New)))
+	text.WriteString(i18n.T(locale, "manager.blacklist.preview_existing", len(diff.Existing)))
+	text.WriteString(i18n.T(locale, "manager.blacklist.preview_invalid", len(diff.Invalid)))
+
+	writeGroup := func(titleKey string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		text.WriteString("\n" + i18n.T(locale, titleKey) + "\n")
+		for i, item := range items {
+			if i >= maxImportPreviewItems {
+				text.WriteString(i18n.T(locale, "manager.blacklist.preview_more", len(items)-maxImportPreviewItems))
+				break
+			}
+			text.WriteString("• " + escapeMarkdown(item) + "\n")
+		}
+	}
+
+	newItems := make([]string, 0, len(diff.New))
+	for _, record := range diff.New {
+		item := "@" + record.Username
+		if record.Reason != "" {
+			item += " — " + truncate(record.Reason, 60)
+		}
+		newItems = append(newItems, item)
+	}
+	existingItems := make([]string, 0, len(diff.Existing))
+	for _, record := range diff.Existing {
+		existingItems = append(existingItems, "@"+record.Username)
+	}
+
+	writeGroup("manager.blacklist.group_new", newItems)
+	writeGroup("manager.blacklist.group_existing", existingItems)
+	writeGroup("manager.blacklist.group_invalid", diff.Invalid)
+
+	if len(diff.New) == 0 {
+		text.WriteString(i18n.T(locale, "manager.blacklist.nothing_to_import"))
+	}
+
+	return text.String()
+}
+
+func handleBlacklistImportConfirm(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
+	session := getSession(chatID)
+	if session == nil || session.Stage != stageAwaitBlacklistImport || len(session.PendingBlacklist) == 0 {
+		showBlacklistMenu(bot, chatID)
+		return
+	}
+
+	if err := applyBlacklistImport(session.PendingBlacklist); err != nil {
+		log.Printf("blacklist import failed: %v", err)
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.import_failed"))
+		return
+	}
+	recordManagerAction(chatID, models.ManagerActionBlacklistImport, 0, "")
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_blacklist"),
+		),
+	)
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.blacklist.imported", len(session.PendingBlacklist)))
+	msg.ReplyMarkup = keyboard
+
+	sentMsg, err := sendReply(bot, msg)
+	if err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+		go scheduleDeletePreviousMessages(bot, chatID, session, sentMsg.MessageID)
+	}
+
+	clearSession(chatID)
+}
+
+// sendBlacklistExport отправляет менеджеру чёрный список файлами CSV и JSON
+func sendBlacklistExport(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
+	records, err := loadBlacklistRecords()
+	if err != nil {
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.load_failed"))
+		return
+	}
+
+	var csvBuf bytes.Buffer
+	if err := writeBlacklistCSV(&csvBuf, records); err != nil {
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.export_csv_failed"))
+		return
+	}
+	jsonData, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		sendText(bot, chatID, i18n.T(locale, "manager.blacklist.export_json_failed"))
+		return
+	}
+
+	stamp := time.Now().Format("20060102")
+	files := []tgbotapi.FileBytes{
+		{Name: fmt.Sprintf("blacklist-%s.csv", stamp), Bytes: csvBuf.Bytes()},
+		{Name: fmt.Sprintf("blacklist-%s.json", stamp), Bytes: jsonData},
+	}
+	for _, file := range files {
+		doc := tgbotapi.NewDocument(chatID, file)
+		doc.Caption = i18n.T(locale, "manager.blacklist.export_caption", len(records))
+		if _, err := sendReply(bot, doc); err != nil {
+			log.Printf("failed to send blacklist export %s: %v", file.Name, err)
+		}
+	}
+}
+
+// downloadTelegramFile скачивает файл, загруженный в бота, не более limit байт
+func downloadTelegramFile(bot *tgbotapi.BotAPI, fileID string, limit int64) ([]byte, error) {
+	url, err := bot.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("file exceeds %d bytes", limit)
+	}
+	return data, nil
+}
@@ -0,0 +1,673 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// Telegram допускает около 30 сообщений в секунду разным пользователям
+	defaultBroadcastRate = 20
+	maxBroadcastRate     = 30
+	// broadcastMaxAttempts — попыток на получателя при сетевых ошибках
+	broadcastMaxAttempts      = 3
+	broadcastProgressInterval = 5 * time.Second
+	maxBroadcastButtons       = 6
+	maxBroadcastButtonText    = 64
+	maxBroadcastText          = 4096
+	// maxBroadcastCaption — лимит подписи к фото в Telegram
+	maxBroadcastCaption = 1024
+)
+
+// broadcastDraft — рассылка, которую менеджер составляет в боте
+type broadcastDraft struct {
+	Text     string
+	Entities []tgbotapi.MessageEntity
+	PhotoID  string
+	Buttons  []broadcastButton
+	Segment  string
+	Category string
+}
+
+// broadcastButton — кнопка-ссылка под сообщением рассылки
+type broadcastButton struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// broadcastRate — сообщений в секунду (BROADCAST_RATE, не больше 30)
+func broadcastRate() int {
+	if raw := strings.TrimSpace(os.Getenv("BROADCAST_RATE")); raw != "" {
+		if rate, err := strconv.Atoi(raw); err == nil && rate > 0 && rate <= maxBroadcastRate {
+			return rate
+		}
+		log.Printf("broadcast: invalid BROADCAST_RATE=%q, using default %d", raw, defaultBroadcastRate)
+	}
+	return defaultBroadcastRate
+}
+
+// broadcastRunner хранит отмену запущенных рассылок. ctx — контекст бота: при остановке
+// отправка прерывается, а после перезапуска продолжается с недоставленных получателей.
+var broadcastRunner = struct {
+	sync.Mutex
+	ctx     context.Context
+	cancels map[uint]context.CancelFunc
+}{ctx: context.Background(), cancels: make(map[uint]context.CancelFunc)}
+
+// startBroadcastRunner запоминает контекст бота и продолжает рассылки, прерванные остановкой
+func startBroadcastRunner(ctx context.Context, bot *tgbotapi.BotAPI) {
+	broadcastRunner.Lock()
+	broadcastRunner.ctx = ctx
+	broadcastRunner.Unlock()
+
+	var pending []models.Broadcast
+	if err := db.DB.Where("status = ?", models.BroadcastSending).Order("id").Find(&pending).Error; err != nil {
+		log.Printf("broadcast: failed to load unfinished broadcasts: %v", err)
+		return
+	}
+	for _, broadcast := range pending {
+		log.Printf("broadcast: resuming broadcast %d", broadcast.ID)
+		launchBroadcast(bot, broadcast)
+	}
+}
+
+func launchBroadcast(bot *tgbotapi.BotAPI, broadcast models.Broadcast) {
+	broadcastRunner.Lock()
+	ctx, cancel := context.WithCancel(broadcastRunner.ctx)
+	broadcastRunner.cancels[broadcast.ID] = cancel
+	broadcastRunner.Unlock()
+
+	go func() {
+		defer func() {
+			broadcastRunner.Lock()
+			delete(broadcastRunner.cancels, broadcast.ID)
+			broadcastRunner.Unlock()
+			cancel()
+		}()
+		runBroadcast(ctx, bot, broadcast)
+	}()
+}
+
+// cancelBroadcast помечает рассылку отменённой и останавливает отправку
+func cancelBroadcast(broadcastID uint) (bool, error) {
+	result := db.DB.Model(&models.Broadcast{}).
+		Where("id = ? AND status = ?", broadcastID, models.BroadcastSending).
+		Updates(map[string]interface{}{"status": models.BroadcastCancelled, "finished_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	broadcastRunner.Lock()
+	if cancel, ok := broadcastRunner.cancels[broadcastID]; ok {
+		cancel()
+	}
+	broadcastRunner.Unlock()
+	return result.RowsAffected > 0, nil
+}
+
+// broadcastRecipients — Telegram ID владельцев объявлений сегмента. Пользователи из
+// чёрного списка рассылку не получают.
+func broadcastRecipients(segment, category string) ([]int64, error) {
+	query := db.DB.Table("users").
+		Joins("JOIN ads ON ads.owner_id = users.id").
+		Where("users.telegram_id IS NOT NULL AND users.deleted_at IS NULL AND users.is_scammer = ?", false)
+	switch segment {
+	case models.BroadcastSegmentAll:
+	case models.BroadcastSegmentActive:
+		query = query.Where("ads.deleted_at IS NULL AND ads.status = ? AND ads.expires_at > ?", models.AdStatusActive, time.Now())
+	case models.BroadcastSegmentCategory:
+		query = query.Where("ads.category = ?", category)
+	default:
+		return nil, fmt.Errorf("unknown broadcast segment %q", segment)
+	}
+
+	var ids []int64
+	err := query.Distinct().Order("users.telegram_id").Pluck("users.telegram_id", &ids).Error
+	return ids, err
+}
+
+func broadcastSegmentName(locale, segment, category string) string {
+	switch segment {
+	case models.BroadcastSegmentActive:
+		return i18n.T(locale, "manager.broadcast.segment.active")
+	case models.BroadcastSegmentCategory:
+		return i18n.T(locale, "manager.broadcast.segment.category", categoryName(category))
+	default:
+		return i18n.T(locale, "manager.broadcast.segment.all")
+	}
+}
+
+// broadcastMessage собирает сообщение рассылки для получателя
+func broadcastMessage(chatID int64, broadcast models.Broadcast) (tgbotapi.Chattable, error) {
+	var entities []tgbotapi.MessageEntity
+	if broadcast.Entities != "" {
+		if err := json.Unmarshal([]byte(broadcast.Entities), &entities); err != nil {
+			return nil, fmt.Errorf("entities: %w", err)
+		}
+	}
+	var buttons []broadcastButton
+	if broadcast.Buttons != "" {
+		if err := json.Unmarshal([]byte(broadcast.Buttons), &buttons); err != nil {
+			return nil, fmt.Errorf("buttons: %w", err)
+		}
+	}
+	var markup interface{}
+	if len(buttons) > 0 {
+		rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(buttons))
+		for _, button := range buttons {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonURL(button.Text, button.URL)))
+		}
+		markup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	}
+
+	if broadcast.PhotoID != "" {
+		photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileID(broadcast.PhotoID))
+		photo.Caption = broadcast.Text
+		photo.CaptionEntities = entities
+		photo.ReplyMarkup = markup
+		return photo, nil
+	}
+	msg := tgbotapi.NewMessage(chatID, broadcast.Text)
+	msg.Entities = entities
+	msg.ReplyMarkup = markup
+	return msg, nil
+}
+
+// deliverBroadcast отправляет рассылку одному получателю. Рассылка не идёт через очередь
+// исходящих сообщений: результат по каждому получателю нужен сразу для счётчиков и
+// broadcast_deliveries, а остановка должна прекращать отправку. 429 и ошибки сервера
+// Telegram повторяет HTTP-клиент бота (outbox.Client), поэтому здесь повторяются только
+// сетевые ошибки; ответ Telegram с ошибкой окончателен.
+func deliverBroadcast(ctx context.Context, bot *tgbotapi.BotAPI, broadcast models.Broadcast, chatID int64) (int, error) {
+	message, err := broadcastMessage(chatID, broadcast)
+	if err != nil {
+		return 0, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		_, err := bot.Send(message)
+		var apiErr *tgbotapi.Error
+		if err == nil || errors.As(err, &apiErr) || attempt >= broadcastMaxAttempts {
+			return attempt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		case <-time.After(time.Duration(attempt) * 2 * time.Second):
+		}
+	}
+}
+
+// runBroadcast рассылает сообщение получателям, которым оно ещё не доставлялось,
+// не чаще BROADCAST_RATE сообщений в секунду
+func runBroadcast(ctx context.Context, bot *tgbotapi.BotAPI, broadcast models.Broadcast) {
+	recipients, err := broadcastRecipients(broadcast.Segment, broadcast.Category)
+	if err != nil {
+		log.Printf("broadcast: failed to load recipients of broadcast %d: %v", broadcast.ID, err)
+		return
+	}
+	var delivered []int64
+	if err := db.DB.Model(&models.BroadcastDelivery{}).Where("broadcast_id = ?", broadcast.ID).
+		Pluck("chat_id", &delivered).Error; err != nil {
+		log.Printf("broadcast: failed to load deliveries of broadcast %d: %v", broadcast.ID, err)
+		return
+	}
+	done := make(map[int64]bool, len(delivered))
+	for _, chatID := range delivered {
+		done[chatID] = true
+	}
+	if broadcast.Total == 0 {
+		broadcast.Total = len(recipients)
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(broadcastRate()))
+	defer ticker.Stop()
+	lastProgress := time.Now()
+
+	for _, chatID := range recipients {
+		if done[chatID] {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			saveBroadcastProgress(bot, broadcast)
+			return
+		case <-ticker.C:
+		}
+
+		attempts, err := deliverBroadcast(ctx, bot, broadcast, chatID)
+		if errors.Is(err, context.Canceled) {
+			saveBroadcastProgress(bot, broadcast)
+			return
+		}
+		delivery := models.BroadcastDelivery{
+			BroadcastID: broadcast.ID,
+			ChatID:      chatID,
+			Status:      models.BroadcastDeliverySent,
+			Attempts:    attempts,
+		}
+		if err != nil {
+			delivery.Status = models.BroadcastDeliveryFailed
+			delivery.Error = truncate(err.Error(), 256)
+			broadcast.Failed++
+		} else {
+			broadcast.Sent++
+		}
+		if err := db.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&delivery).Error; err != nil {
+			log.Printf("broadcast: failed to record delivery of broadcast %d to %d: %v", broadcast.ID, chatID, err)
+		}
+
+		if time.Since(lastProgress) >= broadcastProgressInterval {
+			saveBroadcastProgress(bot, broadcast)
+			lastProgress = time.Now()
+		}
+	}
+
+	now := time.Now()
+	result := db.DB.Model(&models.Broadcast{}).
+		Where("id = ? AND status = ?", broadcast.ID, models.BroadcastSending).
+		Updates(map[string]interface{}{
+			"status":      models.BroadcastCompleted,
+			"total":       broadcast.Total,
+			"sent":        broadcast.Sent,
+			"failed":      broadcast.Failed,
+			"finished_at": now,
+		})
+	if result.Error != nil {
+		log.Printf("broadcast: failed to complete broadcast %d: %v", broadcast.ID, result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		// Рассылку отменили, пока отправлялось последнее сообщение
+		saveBroadcastProgress(bot, broadcast)
+		return
+	}
+	broadcast.Status = models.BroadcastCompleted
+	broadcast.FinishedAt = &now
+	log.Printf("broadcast: broadcast %d completed, sent %d, failed %d", broadcast.ID, broadcast.Sent, broadcast.Failed)
+	updateBroadcastProgress(bot, broadcast)
+}
+
+// saveBroadcastProgress сохраняет счётчики и обновляет сообщение о ходе рассылки
+func saveBroadcastProgress(bot *tgbotapi.BotAPI, broadcast models.Broadcast) {
+	if err := db.DB.Model(&models.Broadcast{}).Where("id = ?", broadcast.ID).Updates(map[string]interface{}{
+		"total":  broadcast.Total,
+		"sent":   broadcast.Sent,
+		"failed": broadcast.Failed,
+	}).Error; err != nil {
+		log.Printf("broadcast: failed to save progress of broadcast %d: %v", broadcast.ID, err)
+	}
+	// Статус мог смениться: рассылку отменили кнопкой
+	if err := db.DB.Model(&models.Broadcast{}).Where("id = ?", broadcast.ID).Select("status").Scan(&broadcast.Status).Error; err != nil {
+		log.Printf("broadcast: failed to reload status of broadcast %d: %v", broadcast.ID, err)
+	}
+	updateBroadcastProgress(bot, broadcast)
+}
+
+func renderBroadcastProgress(locale string, broadcast models.Broadcast) string {
+	var status string
+	switch broadcast.Status {
+	case models.BroadcastCompleted:
+		status = i18n.T(locale, "manager.broadcast.status.completed")
+	case models.BroadcastCancelled:
+		status = i18n.T(locale, "manager.broadcast.status.cancelled")
+	default:
+		status = i18n.T(locale, "manager.broadcast.status.sending")
+	}
+	return i18n.T(locale, "manager.broadcast.progress",
+		broadcast.ID, status, broadcastSegmentName(locale, broadcast.Segment, broadcast.Category),
+		broadcast.Sent, broadcast.Total, broadcast.Failed)
+}
+
+// updateBroadcastProgress обновляет сообщение менеджеру о ходе рассылки
+func updateBroadcastProgress(bot *tgbotapi.BotAPI, broadcast models.Broadcast) {
+	if broadcast.ProgressChatID == 0 || broadcast.ProgressMessageID == 0 {
+		return
+	}
+	locale := telegramUserLocale(broadcast.ProgressChatID)
+	edit := tgbotapi.NewEditMessageText(broadcast.ProgressChatID, broadcast.ProgressMessageID, renderBroadcastProgress(locale, broadcast))
+	if broadcast.Status == models.BroadcastSending {
+		markup := broadcastCancelKeyboard(locale, broadcast.ID)
+		edit.ReplyMarkup = &markup
+	}
+	if _, err := bot.Send(edit); err != nil && !strings.Contains(err.Error(), "message is not modified") {
+		log.Printf("broadcast: failed to update progress of broadcast %d: %v", broadcast.ID, err)
+	}
+}
+
+func broadcastCancelKeyboard(locale string, broadcastID uint) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.broadcast.button.cancel"), fmt.Sprintf("bcast_cancel_%d", broadcastID)),
+		),
+	)
+}
+
+// startBroadcastSession начинает составление рассылки
+func startBroadcastSession(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
+	session := &adSession{
+		Stage:        stageAwaitBroadcastMessage,
+		LastActivity: time.Now(),
+		ChatID:       chatID,
+		Broadcast:    &broadcastDraft{},
+	}
+	setSession(chatID, session)
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.menu"), "menu_main"),
+		),
+	)
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.broadcast.start"))
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = keyboard
+
+	sentMsg, err := sendReply(bot, msg)
+	if err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+		go scheduleDeletePreviousMessages(bot, chatID, session, sentMsg.MessageID)
+	}
+}
+
+// handleBroadcastMessageInput принимает текст или фото с подписью
+func handleBroadcastMessageInput(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, session *adSession) {
+	locale := userLocale(msg.From)
+	draft := session.Broadcast
+	if len(msg.Photo) > 0 {
+		if len([]rune(msg.Caption)) > maxBroadcastCaption {
+			sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.broadcast.caption_too_long", maxBroadcastCaption))
+			return
+		}
+		draft.PhotoID = msg.Photo[len(msg.Photo)-1].FileID
+		draft.Text = msg.Caption
+		draft.Entities = msg.CaptionEntities
+	} else {
+		if strings.TrimSpace(msg.Text) == "" {
+			sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.broadcast.empty"))
+			return
+		}
+		if len([]rune(msg.Text)) > maxBroadcastText {
+			sendText(bot, msg.Chat.ID, i18n.T(locale, "manager.broadcast.text_too_long", maxBroadcastText))
+			return
+		}
+		draft.PhotoID = ""
+		draft.Text = msg.Text
+		draft.Entities = msg.Entities
+	}
+
+	session.Stage = stageAwaitBroadcastButtons
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.broadcast.button.no_buttons"), "bcast_nobuttons"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.menu"), "menu_main"),
+		),
+	)
+	prompt := tgbotapi.NewMessage(msg.Chat.ID, i18n.T(locale, "manager.broadcast.buttons_prompt", maxBroadcastButtons))
+	prompt.ParseMode = "Markdown"
+	prompt.ReplyMarkup = keyboard
+	if sentMsg, err := sendReply(bot, prompt); err == nil {
+		addBotMessage(msg.Chat.ID, sentMsg.MessageID)
+	}
+}
+
+// parseBroadcastButtons разбирает строки «Текст | ссылка»
+func parseBroadcastButtons(text string) ([]broadcastButton, error) {
+	var buttons []broadcastButton
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		label, link, ok := strings.Cut(line, "|")
+		label, link = strings.TrimSpace(label), strings.TrimSpace(link)
+		if !ok || label == "" || link == "" {
+			return nil, i18n.NewError("manager.error.broadcast_button_format", truncate(line, 40))
+		}
+		if len([]rune(label)) > maxBroadcastButtonText {
+			return nil, i18n.NewError("manager.error.broadcast_button_text", truncate(label, 40), maxBroadcastButtonText)
+		}
+		parsed, err := url.Parse(link)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http" && parsed.Scheme != "tg") || (parsed.Scheme != "tg" && parsed.Host == "") {
+			return nil, i18n.NewError("manager.error.broadcast_button_link", truncate(link, 40))
+		}
+		buttons = append(buttons, broadcastButton{Text: label, URL: link})
+	}
+	if len(buttons) == 0 {
+		return nil, i18n.NewError("manager.error.broadcast_no_buttons")
+	}
+	if len(buttons) > maxBroadcastButtons {
+		return nil, i18n.NewError("manager.error.broadcast_too_many_buttons", maxBroadcastButtons)
+	}
+	return buttons, nil
+}
+
+func handleBroadcastButtonsInput(bot *tgbotapi.BotAPI, chatID int64, text string, session *adSession) {
+	locale := telegramUserLocale(chatID)
+	buttons, err := parseBroadcastButtons(text)
+	if err != nil {
+		sendText(bot, chatID, "❌ "+i18n.Message(locale, err, "manager.error.broadcast_no_buttons"))
+		return
+	}
+	session.Broadcast.Buttons = buttons
+	showBroadcastAudience(bot, chatID, session)
+}
+
+// showBroadcastAudience предлагает выбрать получателей
+func showBroadcastAudience(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
+	session.Stage = stageNone
+
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.broadcast.button.all"), "bcast_aud_all"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.broadcast.button.active"), "bcast_aud_active"),
+		),
+	}
+	for _, key := range activeCategoryKeys() {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("📂 "+categoryName(key), "bcast_aud_cat_"+key),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.menu"), "menu_main"),
+	))
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.broadcast.audience_prompt"))
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if sentMsg, err := sendReply(bot, msg); err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+	}
+}
+
+// draftBroadcast переводит черновик в запись рассылки
+func draftBroadcast(draft *broadcastDraft, createdBy int64) (models.Broadcast, error) {
+	broadcast := models.Broadcast{
+		CreatedBy: createdBy,
+		Text:      draft.Text,
+		PhotoID:   draft.PhotoID,
+		Segment:   draft.Segment,
+		Category:  draft.Category,
+		Status:    models.BroadcastSending,
+	}
+	if len(draft.Entities) > 0 {
+		entities, err := json.Marshal(draft.Entities)
+		if err != nil {
+			return broadcast, err
+		}
+		broadcast.Entities = string(entities)
+	}
+	if len(draft.Buttons) > 0 {
+		buttons, err := json.Marshal(draft.Buttons)
+		if err != nil {
+			return broadcast, err
+		}
+		broadcast.Buttons = string(buttons)
+	}
+	return broadcast, nil
+}
+
+// showBroadcastPreview присылает сообщение в том виде, в каком его получат пользователи
+func showBroadcastPreview(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
+	draft := session.Broadcast
+	recipients, err := broadcastRecipients(draft.Segment, draft.Category)
+	if err != nil {
+		log.Printf("broadcast: failed to count recipients: %v", err)
+		sendText(bot, chatID, i18n.T(locale, "manager.broadcast.count_failed"))
+		return
+	}
+
+	preview, err := draftBroadcast(draft, chatID)
+	if err == nil {
+		var message tgbotapi.Chattable
+		if message, err = broadcastMessage(chatID, preview); err == nil {
+			var sentMsg tgbotapi.Message
+			if sentMsg, err = sendReply(bot, message); err == nil {
+				addBotMessage(chatID, sentMsg.MessageID)
+			}
+		}
+	}
+	if err != nil {
+		log.Printf("broadcast: failed to send preview: %v", err)
+		sendText(bot, chatID, i18n.T(locale, "manager.broadcast.preview_failed"))
+		return
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	if len(recipients) > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.broadcast.button.send", len(recipients)), "bcast_send"),
+		))
+	}
+	rows = append(rows,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.broadcast.button.audience"), "bcast_audience"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.broadcast.button.cancel_draft"), "menu_main"),
+		),
+	)
+
+	text := i18n.T(locale, "manager.broadcast.preview",
+		broadcastSegmentName(locale, draft.Segment, draft.Category), len(recipients), broadcastRate())
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if sentMsg, err := sendReply(bot, msg); err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+	}
+}
+
+// sendBroadcast сохраняет рассылку и запускает отправку
+func sendBroadcast(bot *tgbotapi.BotAPI, chatID int64, managerID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
+	broadcast, err := draftBroadcast(session.Broadcast, managerID)
+	if err != nil {
+		log.Printf("broadcast: failed to prepare broadcast: %v", err)
+		sendText(bot, chatID, i18n.T(locale, "manager.broadcast.prepare_failed"))
+		return
+	}
+
+	progress := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.broadcast.starting"))
+	sentMsg, err := sendReply(bot, progress)
+	if err == nil {
+		broadcast.ProgressChatID = chatID
+		broadcast.ProgressMessageID = sentMsg.MessageID
+	}
+
+	if err := db.DB.Create(&broadcast).Error; err != nil {
+		log.Printf("broadcast: failed to create broadcast: %v", err)
+		sendText(bot, chatID, i18n.T(locale, "manager.broadcast.save_failed"))
+		return
+	}
+	recordManagerAction(managerID, models.ManagerActionBroadcast, 0, "")
+	log.Printf("broadcast: broadcast %d started by %d (%s)", broadcast.ID, managerID, broadcast.Segment)
+
+	deleteBotMessages(bot, chatID, session)
+	clearSession(chatID)
+	updateBroadcastProgress(bot, broadcast)
+	launchBroadcast(bot, broadcast)
+}
+
+// handleBroadcastCallback — кнопки составления рассылки и остановки bcast_cancel_<ID>
+func handleBroadcastCallback(bot *tgbotapi.BotAPI, chatID int64, managerID int64, data string) {
+	locale := telegramUserLocale(chatID)
+	if idStr, ok := strings.CutPrefix(data, "bcast_cancel_"); ok {
+		broadcastID, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			return
+		}
+		cancelled, err := cancelBroadcast(uint(broadcastID))
+		if err != nil {
+			log.Printf("broadcast: failed to cancel broadcast %d: %v", broadcastID, err)
+			sendText(bot, chatID, i18n.T(locale, "manager.broadcast.cancel_failed"))
+			return
+		}
+		if !cancelled {
+			sendText(bot, chatID, i18n.T(locale, "manager.broadcast.already_finished"))
+			return
+		}
+		var broadcast models.Broadcast
+		if err := db.DB.First(&broadcast, broadcastID).Error; err == nil {
+			updateBroadcastProgress(bot, broadcast)
+		}
+		log.Printf("broadcast: broadcast %d cancelled by %d", broadcastID, managerID)
+		return
+	}
+
+	session := getSession(chatID)
+	if session == nil || session.Broadcast == nil {
+		sendText(bot, chatID, i18n.T(locale, "manager.broadcast.draft_missing"))
+		return
+	}
+	session.LastActivity = time.Now()
+
+	switch {
+	case data == "bcast_nobuttons":
+		session.Broadcast.Buttons = nil
+		showBroadcastAudience(bot, chatID, session)
+	case data == "bcast_audience":
+		showBroadcastAudience(bot, chatID, session)
+	case data == "bcast_aud_all":
+		session.Broadcast.Segment, session.Broadcast.Category = models.BroadcastSegmentAll, ""
+		showBroadcastPreview(bot, chatID, session)
+	case data == "bcast_aud_active":
+		session.Broadcast.Segment, session.Broadcast.Category = models.BroadcastSegmentActive, ""
+		showBroadcastPreview(bot, chatID, session)
+	case strings.HasPrefix(data, "bcast_aud_cat_"):
+		category := strings.TrimPrefix(data, "bcast_aud_cat_")
+		if _, ok := currentTaxonomy().category(category); !ok {
+			return
+		}
+		session.Broadcast.Segment, session.Broadcast.Category = models.BroadcastSegmentCategory, category
+		showBroadcastPreview(bot, chatID, session)
+	case data == "bcast_send":
+		if session.Broadcast.Segment == "" {
+			showBroadcastAudience(bot, chatID, session)
+			return
+		}
+		sendBroadcast(bot, chatID, managerID, session)
+	}
+}
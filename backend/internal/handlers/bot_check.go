@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"youtube-market/internal/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const commandCheck = "check"
+
+// handleCheckCommand обрабатывает /check @username (или /check <ID>, или ответом на сообщение).
+// В личном чате доступно всем, в группах — менеджерам и администраторам группы.
+func handleCheckCommand(bot *tgbotapi.BotAPI, managerIDs []int64, msg *tgbotapi.Message) {
+	if msg.From == nil {
+		return
+	}
+	manager := isManager(msg.From.ID, managerIDs)
+	if !manager && !msg.Chat.IsPrivate() && !isChatAdmin(bot, msg.Chat.ID, msg.From.ID) {
+		return
+	}
+
+	locale := userLocale(msg.From)
+	if !msg.Chat.IsPrivate() {
+		locale = groupLocale(msg.Chat, locale)
+	}
+
+	var username string
+	var userID int64
+	arg := strings.TrimSpace(msg.CommandArguments())
+	switch {
+	case arg == "" && msg.ReplyToMessage != nil && msg.ReplyToMessage.From != nil:
+		username = msg.ReplyToMessage.From.UserName
+		userID = msg.ReplyToMessage.From.ID
+	case arg != "":
+		if id, err := strconv.ParseInt(arg, 10, 64); err == nil {
+			userID = id
+		} else {
+			username = normalizeUsername(strings.Fields(arg)[0])
+		}
+	}
+
+	if username == "" && userID == 0 {
+		reply := tgbotapi.NewMessage(msg.Chat.ID, i18n.T(locale, "check.usage"))
+		reply.ReplyToMessageID = msg.MessageID
+		if _, err := sendReply(bot, reply); err != nil {
+			log.Printf("failed to send check usage: %v", err)
+		}
+		return
+	}
+
+	lookup, err := lookupUser(username, userID)
+	if err != nil {
+		log.Printf("user lookup failed for %q/%d: %v", username, userID, err)
+		sendText(bot, msg.Chat.ID, i18n.T(locale, "check.failed"))
+		return
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, renderUserLookup(locale, lookup))
+	reply.ParseMode = "Markdown"
+	reply.ReplyToMessageID = msg.MessageID
+	if msg.Chat.IsPrivate() && manager {
+		if keyboard, ok := lookupAdsKeyboard(lookup); ok {
+			reply.ReplyMarkup = keyboard
+		}
+	}
+	if _, err := sendReply(bot, reply); err != nil {
+		log.Printf("failed to send check result: %v", err)
+	}
+}
+
+// showForwardedUserLookup показывает менеджеру проверку автора пересланного сообщения
+func showForwardedUserLookup(bot *tgbotapi.BotAPI, chatID int64, userID int64, username string) {
+	locale := telegramUserLocale(chatID)
+	lookup, err := lookupUser(username, userID)
+	if err != nil {
+		log.Printf("user lookup failed for %q/%d: %v", username, userID, err)
+		sendText(bot, chatID, i18n.T(locale, "check.failed"))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, renderUserLookup(locale, lookup))
+	msg.ParseMode = "Markdown"
+	if keyboard, ok := lookupAdsKeyboard(lookup); ok {
+		msg.ReplyMarkup = keyboard
+	}
+	if _, err := sendReply(bot, msg); err != nil {
+		log.Printf("failed to send check result: %v", err)
+	}
+}
+
+// lookupAdsKeyboard добавляет кнопки перехода к управлению найденными объявлениями
+func lookupAdsKeyboard(lookup userLookup) (tgbotapi.InlineKeyboardMarkup, bool) {
+	if len(lookup.ActiveAds) == 0 {
+		return tgbotapi.InlineKeyboardMarkup{}, false
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, ad := range lookup.ActiveAds {
+		if i >= 10 {
+			break
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("#%d: %s", ad.ID, truncate(ad.Title, 30)),
+				fmt.Sprintf("select_ad_%d", ad.ID),
+			),
+		))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...), true
+}
+
+// handleInlineQuery отвечает на inline-запрос «@bot username» карточкой проверки (только для менеджеров)
+func handleInlineQuery(bot *tgbotapi.BotAPI, managerIDs []int64, query *tgbotapi.InlineQuery) {
+	answer := tgbotapi.InlineConfig{
+		InlineQueryID: query.ID,
+		IsPersonal:    true,
+		Results:       []interface{}{},
+	}
+
+	username := ""
+	if fields := strings.Fields(query.Query); len(fields) > 0 {
+		username = normalizeUsername(fields[0])
+	}
+
+	if query.From != nil && isManager(query.From.ID, managerIDs) && usernamePattern.MatchString(username) {
+		lookup, err := lookupUser(username, 0)
+		if err != nil {
+			log.Printf("inline lookup failed for %s: %v", username, err)
+		} else {
+			title := "✅ @" + username
+			if lookup.Scam != nil && !lookup.Scam.Safe() {
+				title = "🚫 @" + username
+			} else if lookup.Scam != nil && lookup.Scam.Warning {
+				title = "⚠️ @" + username
+			}
+
+			locale := userLocale(query.From)
+			article := tgbotapi.NewInlineQueryResultArticleMarkdown("check_"+strings.ToLower(username), title, renderUserLookup(locale, lookup))
+			description := i18n.T(locale, "check.inline_clean")
+			if lookup.Scam != nil {
+				description = lookup.Scam.Message(locale)
+			}
+			article.Description = i18n.T(locale, "check.inline_summary", description, len(lookup.ActiveAds))
+			answer.Results = append(answer.Results, article)
+		}
+	}
+
+	if _, err := bot.Request(answer); err != nil {
+		log.Printf("failed to answer inline query: %v", err)
+	}
+}
+
+// isChatAdmin проверяет, что пользователь — администратор или создатель группы
+func isChatAdmin(bot *tgbotapi.BotAPI, chatID, userID int64) bool {
+	member, err := bot.GetChatMember(tgbotapi.GetChatMemberConfig{
+		ChatConfigWithUser: tgbotapi.ChatConfigWithUser{ChatID: chatID, UserID: userID},
+	})
+	if err != nil {
+		log.Printf("failed to get chat member %d in %d: %v", userID, chatID, err)
+		return false
+	}
+	return member.IsAdministrator() || member.IsCreator()
+}
@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	commandGuard = "guard"
+	// guardSettingsTTL — сколько держать настройки группы в памяти
+	guardSettingsTTL = time.Minute
+	// guardCleanTTL — через сколько перепроверять участника, который не найден в чёрном списке
+	guardCleanTTL = 10 * time.Minute
+	// guardWarnCooldown — не предупреждать о том же участнике в том же чате чаще
+	guardWarnCooldown = 24 * time.Hour
+)
+
+type guardKey struct {
+	ChatID int64
+	UserID int64
+}
+
+type guardSettingsEntry struct {
+	Settings models.GroupGuardSettings
+	LoadedAt time.Time
+}
+
+var guardCache = struct {
+	sync.Mutex
+	settings map[int64]guardSettingsEntry
+	checked  map[guardKey]time.Time
+	warned   map[guardKey]time.Time
+}{
+	settings: make(map[int64]guardSettingsEntry),
+	checked:  make(map[guardKey]time.Time),
+	warned:   make(map[guardKey]time.Time),
+}
+
+// handleMyChatMember реагирует на добавление бота в группу и удаление из неё
+func handleMyChatMember(bot *tgbotapi.BotAPI, update *tgbotapi.ChatMemberUpdated) {
+	chat := update.Chat
+	if !chat.IsGroup() && !chat.IsSuperGroup() {
+		return
+	}
+
+	switch update.NewChatMember.Status {
+	case "left", "kicked":
+		if err := db.DB.Model(&models.GroupGuardSettings{}).
+			Where("chat_id = ?", chat.ID).
+			Update("enabled", false).Error; err != nil {
+			log.Printf("guard: failed to disable chat %d: %v", chat.ID, err)
+		}
+		invalidateGuardSettings(chat.ID)
+	case "member", "administrator":
+		wasMember := update.OldChatMember.Status == "member" || update.OldChatMember.Status == "administrator"
+		invalidateGuardSettings(chat.ID)
+		settings, err := loadGuardSettings(chat.ID, chat.Title)
+		if err != nil {
+			log.Printf("guard: failed to load settings for chat %d: %v", chat.ID, err)
+			return
+		}
+		changed := false
+		if !settings.Enabled && !wasMember {
+			settings.Enabled = true
+			changed = true
+		}
+		// Язык сообщений в группе по умолчанию — язык администратора, который добавил бота
+		if settings.Language == "" {
+			if language := i18n.Normalize(update.From.LanguageCode); language != "" {
+				settings.Language = language
+				changed = true
+			}
+		}
+		if changed {
+			if err := saveGuardSettings(&settings); err != nil {
+				log.Printf("guard: failed to save settings for chat %d: %v", chat.ID, err)
+			}
+		}
+		if wasMember {
+			return
+		}
+
+		sendGroupText(bot, chat.ID, 0, i18n.T(guardLocale(settings), "guard.welcome"))
+	}
+}
+
+// handleGroupMessage проверяет участников группы и обрабатывает команду /guard
+func handleGroupMessage(bot *tgbotapi.BotAPI, managerIDs []int64, msg *tgbotapi.Message) {
+	if msg.IsCommand() && msg.Command() == commandGuard {
+		handleGuardCommand(bot, managerIDs, msg)
+		return
+	}
+
+	settings, err := loadGuardSettings(msg.Chat.ID, msg.Chat.Title)
+	if err != nil {
+		log.Printf("guard: failed to load settings for chat %d: %v", msg.Chat.ID, err)
+		return
+	}
+	if !settings.Enabled {
+		return
+	}
+
+	members := msg.NewChatMembers
+	if len(members) == 0 && msg.From != nil {
+		members = []tgbotapi.User{*msg.From}
+	}
+
+	for _, member := range members {
+		if member.IsBot || isManager(member.ID, managerIDs) {
+			continue
+		}
+		guardCheckMember(bot, settings, msg, member)
+	}
+}
+
+func guardCheckMember(bot *tgbotapi.BotAPI, settings models.GroupGuardSettings, msg *tgbotapi.Message, member tgbotapi.User) {
+	locale := guardLocale(settings)
+	key := guardKey{ChatID: msg.Chat.ID, UserID: member.ID}
+	now := time.Now()
+
+	guardCache.Lock()
+	if checkedAt, ok := guardCache.checked[key]; ok && now.Sub(checkedAt) < guardCleanTTL {
+		guardCache.Unlock()
+		return
+	}
+	if warnedAt, ok := guardCache.warned[key]; ok && now.Sub(warnedAt) < guardWarnCooldown {
+		guardCache.Unlock()
+		return
+	}
+	guardCache.Unlock()
+
+	result, err := checkScamStatusByTelegram(member.ID, member.UserName)
+	observeScamCheck(scamCheckSourceGuard, result, err)
+	if err != nil {
+		log.Printf("guard: check failed for user %d in chat %d: %v", member.ID, msg.Chat.ID, err)
+		return
+	}
+
+	guardCache.Lock()
+	if result.Safe() && !result.Warning {
+		guardCache.checked[key] = now
+		guardCache.Unlock()
+		return
+	}
+	guardCache.warned[key] = now
+	delete(guardCache.checked, key)
+	guardCache.Unlock()
+
+	mention := fmt.Sprintf("[%s](tg://user?id=%d)", escapeMarkdown(memberDisplayName(member)), member.ID)
+	if member.UserName != "" {
+		mention += " (@" + escapeMarkdown(member.UserName) + ")"
+	}
+
+	var text strings.Builder
+	switch {
+	case result.Listed:
+		text.WriteString(i18n.T(locale, "guard.listed", mention))
+		if result.Reason != "" {
+			text.WriteString("\n" + i18n.T(locale, "check.reason", escapeMarkdown(result.Reason)))
+		}
+	default:
+		text.WriteString(i18n.T(locale, "guard.flagged", mention, escapeMarkdown(result.Message(locale))))
+	}
+	text.WriteString("\n" + i18n.T(locale, "guard.careful"))
+
+	// Ограничиваем только подтверждённых мошенников — отметки партнёров с доверием warn лишь предупреждение
+	if !result.Safe() && settings.Action != models.GuardActionWarn {
+		if err := applyGuardAction(bot, msg.Chat.ID, member.ID, settings.Action); err != nil {
+			log.Printf("guard: %s failed for user %d in chat %d: %v", settings.Action, member.ID, msg.Chat.ID, err)
+			text.WriteString("\n\n" + i18n.T(locale, "guard.no_rights"))
+		} else if settings.Action == models.GuardActionBan {
+			text.WriteString("\n\n" + i18n.T(locale, "guard.banned"))
+		} else {
+			text.WriteString("\n\n" + i18n.T(locale, "guard.restricted"))
+		}
+	}
+
+	sendGroupText(bot, msg.Chat.ID, msg.MessageID, text.String())
+}
+
+func applyGuardAction(bot *tgbotapi.BotAPI, chatID, userID int64, action string) error {
+	member := tgbotapi.ChatMemberConfig{ChatID: chatID, UserID: userID}
+
+	var request tgbotapi.Chattable
+	switch action {
+	case models.GuardActionRestrict:
+		request = tgbotapi.RestrictChatMemberConfig{
+			ChatMemberConfig: member,
+			Permissions:      &tgbotapi.ChatPermissions{},
+		}
+	case models.GuardActionBan:
+		request = tgbotapi.BanChatMemberConfig{ChatMemberConfig: member}
+	default:
+		return nil
+	}
+
+	_, err := bot.Request(request)
+	return err
+}
+
+// handleGuardCommand — /guard [status|on|off|action <warn|restrict|ban>|lang <ru|en|uk>] для администраторов группы
+func handleGuardCommand(bot *tgbotapi.BotAPI, managerIDs []int64, msg *tgbotapi.Message) {
+	if msg.From == nil {
+		return
+	}
+
+	settings, err := loadGuardSettings(msg.Chat.ID, msg.Chat.Title)
+	if err != nil {
+		log.Printf("guard: failed to load settings for chat %d: %v", msg.Chat.ID, err)
+		return
+	}
+	locale := guardLocale(settings)
+
+	if !isManager(msg.From.ID, managerIDs) && !isChatAdmin(bot, msg.Chat.ID, msg.From.ID) {
+		sendGroupText(bot, msg.Chat.ID, msg.MessageID, i18n.T(locale, "guard.admins_only"))
+		return
+	}
+
+	args := strings.Fields(strings.ToLower(msg.CommandArguments()))
+	switch {
+	case len(args) == 0 || args[0] == "status":
+	case args[0] == "on":
+		settings.Enabled = true
+	case args[0] == "off":
+		settings.Enabled = false
+	case args[0] == "action" && len(args) == 2 && isValidGuardAction(args[1]):
+		settings.Action = args[1]
+	case args[0] == "lang" && len(args) == 2 && i18n.Normalize(args[1]) != "":
+		settings.Language = i18n.Normalize(args[1])
+		locale = settings.Language
+	default:
+		sendGroupText(bot, msg.Chat.ID, msg.MessageID, i18n.T(locale, "guard.usage"))
+		return
+	}
+
+	if len(args) > 0 && args[0] != "status" {
+		if err := saveGuardSettings(&settings); err != nil {
+			log.Printf("guard: failed to save settings for chat %d: %v", msg.Chat.ID, err)
+			sendGroupText(bot, msg.Chat.ID, msg.MessageID, i18n.T(locale, "guard.save_failed"))
+			return
+		}
+	}
+
+	status := i18n.T(locale, "guard.disabled")
+	if settings.Enabled {
+		status = i18n.T(locale, "guard.enabled")
+	}
+
+	sendGroupText(bot, msg.Chat.ID, msg.MessageID, i18n.T(locale, "guard.status",
+		status, i18n.T(locale, "guard.action."+settings.Action), i18n.Name(locale)))
+}
+
+// guardLocale — язык сообщений бота в группе (/guard lang), по умолчанию DEFAULT_LOCALE
+func guardLocale(settings models.GroupGuardSettings) string {
+	return i18n.Resolve(settings.Language)
+}
+
+// groupLocale — язык группы, если он задан, иначе fallback (например, язык автора команды)
+func groupLocale(chat *tgbotapi.Chat, fallback string) string {
+	settings, err := loadGuardSettings(chat.ID, chat.Title)
+	if err != nil || settings.Language == "" {
+		return fallback
+	}
+	return guardLocale(settings)
+}
+
+func isValidGuardAction(action string) bool {
+	switch action {
+	case models.GuardActionWarn, models.GuardActionRestrict, models.GuardActionBan:
+		return true
+	default:
+		return false
+	}
+}
+
+// loadGuardSettings возвращает настройки группы, создавая их по умолчанию (охрана включена, только предупреждение)
+func loadGuardSettings(chatID int64, title string) (models.GroupGuardSettings, error) {
+	guardCache.Lock()
+	entry, ok := guardCache.settings[chatID]
+	guardCache.Unlock()
+	if ok && time.Since(entry.LoadedAt) < guardSettingsTTL {
+		return entry.Settings, nil
+	}
+
+	var settings models.GroupGuardSettings
+	err := db.DB.Where("chat_id = ?", chatID).First(&settings).Error
+	if err == gorm.ErrRecordNotFound {
+		settings = models.GroupGuardSettings{
+			ChatID:  chatID,
+			Title:   truncate(title, 256),
+			Enabled: true,
+			Action:  models.GuardActionWarn,
+		}
+		err = db.DB.Create(&settings).Error
+	}
+	if err != nil {
+		return settings, err
+	}
+
+	guardCache.Lock()
+	guardCache.settings[chatID] = guardSettingsEntry{Settings: settings, LoadedAt: time.Now()}
+	guardCache.Unlock()
+	return settings, nil
+}
+
+func saveGuardSettings(settings *models.GroupGuardSettings) error {
+	if err := db.DB.Save(settings).Error; err != nil {
+		return err
+	}
+	guardCache.Lock()
+	guardCache.settings[settings.ChatID] = guardSettingsEntry{Settings: *settings, LoadedAt: time.Now()}
+	guardCache.Unlock()
+	return nil
+}
+
+func invalidateGuardSettings(chatID int64) {
+	guardCache.Lock()
+	delete(guardCache.settings, chatID)
+	guardCache.Unlock()
+}
+
+// guardCacheCleanup удаляет устаревшие отметки проверок, чтобы кэш не рос бесконечно
+func guardCacheCleanup() {
+	now := time.Now()
+	guardCache.Lock()
+	defer guardCache.Unlock()
+	for key, checkedAt := range guardCache.checked {
+		if now.Sub(checkedAt) > guardCleanTTL {
+			delete(guardCache.checked, key)
+		}
+	}
+	for key, warnedAt := range guardCache.warned {
+		if now.Sub(warnedAt) > guardWarnCooldown {
+			delete(guardCache.warned, key)
+		}
+	}
+}
+
+func memberDisplayName(user tgbotapi.User) string {
+	name := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	if name == "" {
+		name = user.UserName
+	}
+	return name
+}
+
+// sendGroupText отправляет сообщение в группу; replyTo=0 — без ответа на сообщение
+func sendGroupText(bot *tgbotapi.BotAPI, chatID int64, replyTo int, text string) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "Markdown"
+	msg.ReplyToMessageID = replyTo
+	msg.AllowSendingWithoutReply = true
+	if _, err := sendReply(bot, msg); err != nil {
+		log.Printf("guard: failed to send message to chat %d: %v", chatID, err)
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"log"
+	"strings"
+
+	"youtube-market/internal/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	commandLanguage = "language"
+	// languageCallbackPrefix — кнопки выбора языка: lang_<ru|en|uk|auto>; доступны всем пользователям
+	languageCallbackPrefix = "lang_"
+	languageAuto           = "auto"
+)
+
+// isLanguageCommand — /language доступна всем пользователям в личном чате с ботом
+func isLanguageCommand(msg *tgbotapi.Message) bool {
+	return msg.IsCommand() && msg.Command() == commandLanguage && msg.Chat.IsPrivate()
+}
+
+// handleLanguageCommand показывает текущий язык и кнопки выбора
+func handleLanguageCommand(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) {
+	if msg.From == nil {
+		return
+	}
+	locale := userLocale(msg.From)
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, i18n.T(locale, "language.prompt", i18n.Name(locale)))
+	reply.ReplyMarkup = languageKeyboard(locale)
+	if _, err := sendReply(bot, reply); err != nil {
+		log.Printf("failed to send language prompt: %v", err)
+	}
+}
+
+func languageKeyboard(locale string) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, language := range i18n.Supported {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(i18n.Name(language), languageCallbackPrefix+language))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "language.auto"), languageCallbackPrefix+languageAuto),
+	))
+}
+
+// handleLanguageCallback сохраняет выбранный язык; «auto» — снова следовать языку Telegram
+func handleLanguageCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) {
+	if _, err := bot.Request(tgbotapi.NewCallback(callback.ID, "")); err != nil {
+		log.Printf("callback answer error: %v", err)
+	}
+	if callback.Message == nil || callback.From == nil {
+		return
+	}
+
+	choice := strings.TrimPrefix(callback.Data, languageCallbackPrefix)
+	language := i18n.Normalize(choice)
+	if language == "" && choice != languageAuto {
+		return
+	}
+
+	chatID := callback.Message.Chat.ID
+	if err := setUserLanguage(callback.From, language); err != nil {
+		log.Printf("failed to save language of user %d: %v", callback.From.ID, err)
+		notifyUser(bot, chatID, i18n.T(userLocale(callback.From), "language.save_failed"))
+		return
+	}
+
+	locale := i18n.Resolve(language, callback.From.LanguageCode)
+	edit := tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, i18n.T(locale, "language.set", i18n.Name(locale)))
+	if _, err := sendReply(bot, edit); err != nil {
+		log.Printf("failed to update language prompt: %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	commandRenew   = "renew"
+	commandPremium = "premium"
+	commandRefund  = "refund"
+)
+
+// isPaymentCommand — команды оплаты доступны всем пользователям в личном чате с ботом
+func isPaymentCommand(msg *tgbotapi.Message) bool {
+	if !msg.IsCommand() || !msg.Chat.IsPrivate() {
+		return false
+	}
+	switch msg.Command() {
+	case commandRenew, commandPremium, commandRefund:
+		return true
+	}
+	return false
+}
+
+// handlePaymentCommand обрабатывает /renew <ID>, /premium <ID> (владелец объявления)
+// и /refund <ID платежа> [причина] (менеджер)
+func handlePaymentCommand(bot *tgbotapi.BotAPI, managerIDs []int64, msg *tgbotapi.Message) {
+	if msg.From == nil {
+		return
+	}
+
+	if msg.Command() == commandRefund {
+		if isManager(msg.From.ID, managerIDs) {
+			handleRefundCommand(bot, msg)
+		}
+		return
+	}
+
+	locale := userLocale(msg.From)
+	product := models.PaymentProductRenew
+	if msg.Command() == commandPremium {
+		product = models.PaymentProductPremium
+	}
+
+	adID, err := strconv.ParseUint(strings.TrimSpace(msg.CommandArguments()), 10, 64)
+	if err != nil || adID == 0 {
+		notifyUser(bot, msg.Chat.ID, i18n.T(locale, "payment.usage", msg.Command()))
+		return
+	}
+
+	ad, err := loadOwnedAd(uint(adID), msg.From.ID)
+	if err == nil {
+		err = validatePaymentTarget(db.DB, ad, product)
+	}
+	if err != nil {
+		notifyUser(bot, msg.Chat.ID, "❌ "+paymentErrorText(locale, err))
+		return
+	}
+
+	price := paymentPricePerDay(product)
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, days := range []int{1, 7, 14, 30} {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, "payment.option", i18n.T(locale, "unit.days_short", days), price*days),
+				fmt.Sprintf("pay_%s_%d_%d", product, ad.ID, days),
+			),
+		))
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, i18n.T(locale, "payment.choose_period", paymentProductLabel(locale, product), ad.Title))
+	reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if _, err := sendReply(bot, reply); err != nil {
+		log.Printf("failed to send payment options: %v", err)
+	}
+}
+
+// handlePaymentCallback выставляет счёт по кнопке pay_<товар>_<ID объявления>_<дни>
+func handlePaymentCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) {
+	if _, err := bot.Request(tgbotapi.NewCallback(callback.ID, "")); err != nil {
+		log.Printf("callback answer error: %v", err)
+	}
+	if callback.Message == nil || callback.From == nil {
+		return
+	}
+
+	product, adID, days, ok := parsePaymentCallback(callback.Data)
+	if !ok {
+		return
+	}
+
+	locale := userLocale(callback.From)
+	payment, ad, err := createPayment(callback.From.ID, adID, product, days)
+	if err != nil {
+		notifyUser(bot, callback.Message.Chat.ID, "❌ "+paymentErrorText(locale, err))
+		return
+	}
+
+	if err := sendStarsInvoice(bot, callback.Message.Chat.ID, locale, payment, ad); err != nil {
+		log.Printf("payments: sendInvoice failed for payment %d: %v", payment.ID, err)
+		notifyUser(bot, callback.Message.Chat.ID, i18n.T(locale, "payment.invoice_failed"))
+	}
+}
+
+// parsePaymentCallback разбирает данные кнопки pay_<товар>_<ID объявления>_<дни>.
+// Товар и срок проверяет createPayment.
+func parsePaymentCallback(data string) (product string, adID uint, days int, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(data, "pay_"), "_")
+	if len(parts) != 3 {
+		return "", 0, 0, false
+	}
+	id, errAd := strconv.ParseUint(parts[1], 10, 64)
+	days, errDays := strconv.Atoi(parts[2])
+	if errAd != nil || errDays != nil {
+		return "", 0, 0, false
+	}
+	return parts[0], uint(id), days, true
+}
+
+// handlePreCheckoutQuery подтверждает или отклоняет оплату до списания звёзд
+func handlePreCheckoutQuery(bot *tgbotapi.BotAPI, query *tgbotapi.PreCheckoutQuery) {
+	answer := tgbotapi.PreCheckoutConfig{PreCheckoutQueryID: query.ID, OK: true}
+
+	var payerID int64
+	if query.From != nil {
+		payerID = query.From.ID
+	}
+	locale := userLocale(query.From)
+	if err := checkPreCheckout(query.InvoicePayload, payerID, query.TotalAmount, query.Currency); err != nil {
+		log.Printf("payments: pre-checkout rejected for %s: %v", query.InvoicePayload, err)
+		answer.OK = false
+		answer.ErrorMessage = paymentErrorText(locale, err)
+	}
+
+	if _, err := bot.Request(answer); err != nil {
+		log.Printf("payments: failed to answer pre-checkout query: %v", err)
+	}
+}
+
+// handleSuccessfulPayment применяет оплаченный товар; если это невозможно — возвращает звёзды
+func handleSuccessfulPayment(bot *tgbotapi.BotAPI, managerIDs []int64, msg *tgbotapi.Message) {
+	if msg.From == nil {
+		return
+	}
+
+	locale := userLocale(msg.From)
+	payment, ad, already, err := fulfilPayment(msg.From.ID, msg.SuccessfulPayment)
+	if already {
+		return
+	}
+	if err != nil {
+		log.Printf("payments: fulfilment failed for %s: %v", msg.SuccessfulPayment.InvoicePayload, err)
+		if payment.Status != models.PaymentStatusPaid {
+			notifyManagers(bot, managerIDs, func(locale string) string {
+				return i18n.T(locale, "manager.payment.failed", msg.SuccessfulPayment.TelegramPaymentChargeID, msg.From.ID, err)
+			})
+			return
+		}
+		if _, refundErr := refundPayment(bot, payment.ID, i18n.T(i18n.Default(), "payment.refund_reason_auto", err)); refundErr != nil {
+			log.Printf("payments: auto-refund failed for payment %d: %v", payment.ID, refundErr)
+			notifyManagers(bot, managerIDs, func(locale string) string {
+				return i18n.T(locale, "manager.payment.not_refunded", payment.ID, refundErr)
+			})
+			return
+		}
+		notifyUser(bot, msg.Chat.ID, i18n.T(locale, "payment.refunded_auto", paymentErrorText(locale, err)))
+		return
+	}
+
+	publishAdToChannel(bot, &ad, true)
+	if payment.Product == models.PaymentProductRenew {
+		go notifyFavoritesRenewed(bot, ad)
+	}
+
+	notifyUser(bot, msg.Chat.ID, i18n.T(locale, "payment.paid",
+		paymentProductLabel(locale, payment.Product), ad.Title, formatDays(locale, payment.Days), formatDateTime(locale, ad.ExpiresAt)))
+	notifyManagers(bot, managerIDs, func(locale string) string {
+		return i18n.T(locale, "manager.payment.paid", payment.ID, strings.ToLower(paymentProductLabel(locale, payment.Product)),
+			ad.ID, formatDays(locale, payment.Days), payment.Amount, payment.UserID)
+	})
+}
+
+func handleRefundCommand(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) {
+	locale := userLocale(msg.From)
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		notifyUser(bot, msg.Chat.ID, i18n.T(locale, "manager.refund.usage"))
+		return
+	}
+	paymentID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil || paymentID == 0 {
+		notifyUser(bot, msg.Chat.ID, i18n.T(locale, "manager.refund.invalid_id"))
+		return
+	}
+
+	reason := strings.Join(args[1:], " ")
+	if reason == "" {
+		reason = i18n.T(locale, "manager.refund.default_reason")
+	}
+
+	payment, err := refundPayment(bot, uint(paymentID), reason)
+	if err != nil {
+		log.Printf("payments: refund of %d failed: %v", paymentID, err)
+		notifyUser(bot, msg.Chat.ID, "❌ "+paymentErrorText(locale, err))
+		return
+	}
+
+	var ad models.Ad
+	if err := db.DB.First(&ad, payment.AdID).Error; err == nil {
+		publishAdToChannel(bot, &ad, false)
+	}
+
+	notifyUser(bot, msg.Chat.ID, i18n.T(locale, "manager.refund.done", payment.ID, payment.Amount))
+	payerLocale := telegramUserLocale(payment.UserID)
+	notifyUser(bot, payment.UserID, i18n.T(payerLocale, "payment.refunded", payment.Amount, strings.ToLower(paymentProductLabel(payerLocale, payment.Product))))
+}
+
+// paymentErrorText — понятное пользователю описание ошибки оплаты на языке locale
+func paymentErrorText(locale string, err error) string {
+	return i18n.Message(locale, err, "error.payment_failed")
+}
+
+// notifyManagers отправляет уведомление всем менеджерам, каждому на его языке
+func notifyManagers(bot *tgbotapi.BotAPI, managerIDs []int64, render func(locale string) string) {
+	for _, managerID := range managerIDs {
+		notifyUser(bot, managerID, render(telegramUserLocale(managerID)))
+	}
+}
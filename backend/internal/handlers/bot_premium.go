@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// premiumStartLayouts — форматы даты начала брони, которые понимает бот
+var premiumStartLayouts = []string{"02.01.2006 15:04", "02.01.2006"}
+
+// premiumLimitText описывает на языке locale занятость мест в категории и ближайшее освобождение
+func premiumLimitText(locale, category string) string {
+	now := time.Now()
+	label := categoryName(category)
+
+	text := i18n.T(locale, "manager.premium.full", premiumSlots(category), label)
+	calendars, err := loadPremiumCalendar(now)
+	if err != nil {
+		log.Printf("premium: failed to load calendar: %v", err)
+		return text
+	}
+	if freeAt := findPremiumCalendar(calendars, category).FreeAt(now); freeAt.After(now) {
+		text += i18n.T(locale, "manager.premium.free_at", formatDateTime(locale, freeAt))
+	}
+	return text
+}
+
+// showPremiumCalendar показывает менеджеру занятость премиум-мест и очередь броней
+func showPremiumCalendar(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
+	calendars, err := loadPremiumCalendar(time.Now())
+	if err != nil {
+		log.Printf("premium: failed to load calendar: %v", err)
+		sendText(bot, chatID, i18n.T(locale, "manager.premium.load_failed"))
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(i18n.T(locale, "manager.premium.title"))
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, calendar := range calendars {
+		text.WriteString(i18n.T(locale, "manager.premium.category", escapeMarkdown(categoryName(calendar.Category)), len(calendar.Active), calendar.Slots))
+		for _, entry := range calendar.Active {
+			text.WriteString(i18n.T(locale, "manager.premium.active_entry", entry.AdID, escapeMarkdown(truncate(entry.Title, 30)), entry.EndsAt.Format("02.01 15:04")))
+		}
+		if len(calendar.Upcoming) == 0 {
+			continue
+		}
+
+		text.WriteString(i18n.T(locale, "manager.premium.queue"))
+		for _, entry := range calendar.Upcoming {
+			text.WriteString(i18n.T(locale, "manager.premium.queue_entry",
+				entry.BookingID, entry.AdID, escapeMarkdown(truncate(entry.Title, 30)),
+				entry.StartsAt.Format("02.01 15:04"), entry.EndsAt.Format("02.01 15:04")))
+			if len(rows) < 20 {
+				rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+					tgbotapi.NewInlineKeyboardButtonData(
+						i18n.T(locale, "manager.premium.button.cancel_booking", entry.BookingID, entry.AdID),
+						fmt.Sprintf("pslot_cancel_%d", entry.BookingID),
+					),
+				))
+			}
+		}
+	}
+	text.WriteString(i18n.T(locale, "manager.premium.queue_hint"))
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), "menu_main"),
+	))
+
+	msg := tgbotapi.NewMessage(chatID, text.String())
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	sentMsg, err := sendReply(bot, msg)
+	if err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+		if session := getSession(chatID); session != nil {
+			go scheduleDeletePreviousMessages(bot, chatID, session, sentMsg.MessageID)
+		}
+	}
+}
+
+func handlePremiumSlotCallback(bot *tgbotapi.BotAPI, chatID int64, managerID int64, data string) {
+	locale := telegramUserLocale(chatID)
+	switch {
+	case strings.HasPrefix(data, "pslot_cancel_"):
+		bookingID, err := strconv.ParseUint(strings.TrimPrefix(data, "pslot_cancel_"), 10, 64)
+		if err != nil {
+			return
+		}
+		if _, err := cancelPremiumBooking(uint(bookingID)); err != nil {
+			if errors.Is(err, errBookingNotFound) {
+				sendText(bot, chatID, "⚠️ "+i18n.Message(locale, err, "manager.premium.cancel_failed"))
+			} else {
+				log.Printf("premium: failed to cancel booking %d: %v", bookingID, err)
+				sendText(bot, chatID, i18n.T(locale, "manager.premium.cancel_failed"))
+			}
+		}
+		showPremiumCalendar(bot, chatID)
+		return
+	}
+
+	session := getSession(chatID)
+	if session == nil || session.Ad.ID == 0 {
+		return
+	}
+
+	switch {
+	case data == "pslot_book":
+		session.Stage = stageAwaitPremiumStart
+		showPremiumStartPrompt(bot, chatID, session)
+	case data == "pslot_now":
+		session.PremiumStartsAt = time.Now()
+		showPremiumDaysPrompt(bot, chatID, session)
+	case strings.HasPrefix(data, "pslot_days_"):
+		days, err := strconv.Atoi(strings.TrimPrefix(data, "pslot_days_"))
+		if err != nil || (days != 0 && !isValidDuration(days)) {
+			return
+		}
+		handlePremiumBooking(bot, chatID, managerID, days, session)
+	}
+}
+
+func showPremiumStartPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.premium.button.asap"), "pslot_now"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), fmt.Sprintf("select_ad_%d", session.Ad.ID)),
+		),
+	)
+
+	text := i18n.T(locale, "manager.premium.start_prompt", premiumLimitTextOrFree(locale, session.Ad.Category))
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = keyboard
+
+	sentMsg, err := sendReply(bot, msg)
+	if err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+	}
+}
+
+func premiumLimitTextOrFree(locale, category string) string {
+	free, err := premiumSlotFree(db.DB, category, nil)
+	if err == nil && free {
+		return i18n.T(locale, "manager.premium.has_free")
+	}
+	return premiumLimitText(locale, category)
+}
+
+func handlePremiumStartInput(bot *tgbotapi.BotAPI, chatID int64, text string, session *adSession) {
+	locale := telegramUserLocale(chatID)
+	var startsAt time.Time
+	var err error
+	for _, layout := range premiumStartLayouts {
+		startsAt, err = time.ParseInLocation(layout, text, time.Local)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		sendText(bot, chatID, i18n.T(locale, "manager.premium.start_invalid"))
+		return
+	}
+	if startsAt.Before(time.Now().Add(-time.Minute)) {
+		sendText(bot, chatID, i18n.T(locale, "manager.premium.start_past"))
+		return
+	}
+	if !startsAt.Before(session.Ad.ExpiresAt) {
+		sendText(bot, chatID, i18n.T(locale, "manager.premium.start_after_expiry", formatDateTime(locale, session.Ad.ExpiresAt)))
+		return
+	}
+
+	session.PremiumStartsAt = startsAt
+	showPremiumDaysPrompt(bot, chatID, session)
+}
+
+func showPremiumDaysPrompt(bot *tgbotapi.BotAPI, chatID int64, session *adSession) {
+	locale := telegramUserLocale(chatID)
+	session.Stage = stageAwaitAction
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 1), "pslot_days_1"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 7), "pslot_days_7"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 14), "pslot_days_14"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 30), "pslot_days_30"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.premium.button.until_end"), "pslot_days_0"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), fmt.Sprintf("select_ad_%d", session.Ad.ID)),
+		),
+	)
+
+	msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.premium.days_prompt", formatDateTime(locale, session.PremiumStartsAt)))
+	msg.ReplyMarkup = keyboard
+
+	sentMsg, err := sendReply(bot, msg)
+	if err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+	}
+}
+
+func handlePremiumBooking(bot *tgbotapi.BotAPI, chatID int64, managerID int64, days int, session *adSession) {
+	locale := telegramUserLocale(chatID)
+	startsAt := session.PremiumStartsAt
+	if startsAt.IsZero() {
+		startsAt = time.Now()
+	}
+
+	booking, err := bookPremium(session.Ad, startsAt, days, managerID)
+	if err != nil {
+		if errors.Is(err, errAdNotBookable) {
+			sendText(bot, chatID, "❌ "+i18n.Message(locale, err, "manager.premium.book_failed"))
+		} else {
+			log.Printf("premium: failed to book ad %d: %v", session.Ad.ID, err)
+			sendText(bot, chatID, i18n.T(locale, "manager.premium.book_failed"))
+		}
+		return
+	}
+	recordManagerAction(managerID, models.ManagerActionPremiumBooked, session.Ad.ID, "")
+
+	processPremiumQueue(bot)
+
+	result := i18n.T(locale, "manager.premium.booked", booking.ID, session.Ad.ID)
+	if calendars, err := loadPremiumCalendar(time.Now()); err == nil {
+		calendar := findPremiumCalendar(calendars, booking.Category)
+		for _, entry := range calendar.Upcoming {
+			if entry.BookingID == booking.ID {
+				result += i18n.T(locale, "manager.premium.expected_start", formatDateTime(locale, *entry.StartsAt))
+			}
+		}
+		for _, entry := range calendar.Active {
+			if entry.AdID == booking.AdID {
+				result += i18n.T(locale, "manager.premium.already_until", formatDateTime(locale, entry.EndsAt))
+			}
+		}
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.premium.button.slots"), "menu_premium"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.menu"), "menu_main"),
+		),
+	)
+
+	msg := tgbotapi.NewMessage(chatID, result)
+	msg.ReplyMarkup = keyboard
+
+	sentMsg, err := sendReply(bot, msg)
+	if err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+		go scheduleDeletePreviousMessages(bot, chatID, session, sentMsg.MessageID)
+	}
+
+	clearSession(chatID)
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"youtube-market/internal/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// maxAdPrice — верхняя граница цены, чтобы опечатка не превратилась в бессмысленное число
+const maxAdPrice = 1_000_000_000
+
+var errInvalidPrice = i18n.NewError("manager.error.invalid_price")
+
+// parsePrice разбирает цену в рублях: «15000», «15 000», «15 000 ₽». 0 — цена не указана.
+func parsePrice(text string) (int64, error) {
+	text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "₽"))
+	text = strings.NewReplacer(" ", "", " ", "", "_", "").Replace(text)
+	price, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || price < 0 || price > maxAdPrice {
+		return 0, errInvalidPrice
+	}
+	return price, nil
+}
+
+// formatPrice — «15 000 ₽» или «не указана» на языке locale
+func formatPrice(locale string, price int64) string {
+	if price <= 0 {
+		return i18n.T(locale, "format.price_none")
+	}
+	digits := strconv.FormatInt(price, 10)
+	var out strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out.WriteRune(' ')
+		}
+		out.WriteRune(digit)
+	}
+	return i18n.T(locale, "format.price", out.String())
+}
+
+// handlePriceEdit запрашивает цену объявления из экрана настроек
+func handlePriceEdit(bot *tgbotapi.BotAPI, chatID int64) {
+	session := getSession(chatID)
+	if session == nil {
+		return
+	}
+	session.Stage = stageAwaitPrice
+	locale := telegramUserLocale(chatID)
+	sendText(bot, chatID, i18n.T(locale, "manager.price.prompt", formatPrice(locale, session.Ad.Price)))
+}
+
+// handlePriceInput принимает цену и возвращает к настройкам объявления
+func handlePriceInput(bot *tgbotapi.BotAPI, chatID int64, text string, session *adSession) {
+	price, err := parsePrice(text)
+	if err != nil {
+		locale := telegramUserLocale(chatID)
+		sendText(bot, chatID, i18n.T(locale, "manager.price.invalid", i18n.Message(locale, err, "manager.error.invalid_price")))
+		return
+	}
+	session.Ad.Price = price
+	// как и после ввода даты автопродления, возвращаемся к экрану настроек
+	session.Stage = stageAwaitSettings
+	showAllSettingsPrompt(bot, chatID, session)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// ownerCallbackPrefix — кнопки в уведомлениях владельцу; доступны не только менеджерам
+const ownerCallbackPrefix = "own_"
+
+// ownerRenewDays — сроки продления, которые предлагаются в напоминании
+var ownerRenewDays = []int{7, 14, 30}
+
+// ownerActionsKeyboard — кнопки продления и (для активного объявления) снятия с биржи
+func ownerActionsKeyboard(locale string, adID uint, withRemove bool) tgbotapi.InlineKeyboardMarkup {
+	var renew []tgbotapi.InlineKeyboardButton
+	for _, days := range ownerRenewDays {
+		renew = append(renew, tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(locale, "owner.button.renew", i18n.T(locale, "unit.days_short", days)),
+			fmt.Sprintf("%srenew_%d_%d", ownerCallbackPrefix, adID, days),
+		))
+	}
+	rows := [][]tgbotapi.InlineKeyboardButton{renew}
+	if withRemove {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "owner.button.remove"), fmt.Sprintf("%sremove_%d", ownerCallbackPrefix, adID)),
+		))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// handleOwnerCallback обрабатывает кнопки владельца:
+// own_renew_<ID>_<дни>, own_remove_<ID>, own_confirmremove_<ID>, own_keep_<ID>, own_autorenewoff_<ID>
+func handleOwnerCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) {
+	if _, err := bot.Request(tgbotapi.NewCallback(callback.ID, "")); err != nil {
+		log.Printf("callback answer error: %v", err)
+	}
+	if callback.Message == nil || callback.From == nil {
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(callback.Data, ownerCallbackPrefix), "_")
+	if len(parts) < 2 {
+		return
+	}
+	adID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return
+	}
+
+	chatID := callback.Message.Chat.ID
+	locale := userLocale(callback.From)
+	messageID := callback.Message.MessageID
+	userID := callback.From.ID
+
+	switch parts[0] {
+	case "renew":
+		if len(parts) != 3 {
+			return
+		}
+		days, err := strconv.Atoi(parts[2])
+		if err != nil || !isValidDuration(days) {
+			return
+		}
+		handleOwnerRenew(bot, chatID, messageID, userID, locale, uint(adID), days)
+	case "remove":
+		ad, err := loadOwnedAd(uint(adID), userID)
+		if err != nil {
+			notifyUser(bot, chatID, "❌ "+paymentErrorText(locale, err))
+			return
+		}
+		confirm := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "owner.button.confirm_remove"), fmt.Sprintf("%sconfirmremove_%d", ownerCallbackPrefix, ad.ID)),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "owner.button.keep"), fmt.Sprintf("%skeep_%d", ownerCallbackPrefix, ad.ID)),
+			),
+		))
+		if _, err := sendReply(bot, confirm); err != nil {
+			log.Printf("failed to show removal confirmation: %v", err)
+		}
+	case "autorenewoff":
+		disableAutoRenewByOwner(bot, chatID, messageID, userID, locale, uint(adID))
+	case "keep":
+		restore := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, ownerActionsKeyboard(locale, uint(adID), true))
+		if _, err := sendReply(bot, restore); err != nil {
+			log.Printf("failed to restore owner actions: %v", err)
+		}
+	case "confirmremove":
+		ad, err := loadOwnedAd(uint(adID), userID)
+		if err != nil {
+			notifyUser(bot, chatID, "❌ "+paymentErrorText(locale, err))
+			return
+		}
+		if err := deactivateAdByOwner(bot, ad); err != nil {
+			log.Printf("owner ads: failed to deactivate ad %d: %v", ad.ID, err)
+			notifyUser(bot, chatID, i18n.T(locale, "owner.remove_failed"))
+			return
+		}
+		replaceOwnerMessage(bot, chatID, messageID, i18n.T(locale, "owner.removed", ad.Title, managerHelpLink))
+	}
+}
+
+// handleOwnerRenew продлевает объявление по кнопке; когда бесплатные продления
+// закончились, предлагает оплатить продление звёздами
+func handleOwnerRenew(bot *tgbotapi.BotAPI, chatID int64, messageID int, userID int64, locale string, adID uint, days int) {
+	ad, remaining, err := renewAdByOwner(adID, userID, days)
+	switch {
+	case errors.Is(err, errFreeRenewalsUsed):
+		price := paymentPricePerDay(models.PaymentProductRenew)
+		reply := tgbotapi.NewMessage(chatID, "ℹ️ "+paymentErrorText(locale, err)+".")
+		reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				i18n.T(locale, "payment.renew_offer_button", i18n.T(locale, "unit.days_short", days), price*days),
+				fmt.Sprintf("pay_%s_%d_%d", models.PaymentProductRenew, adID, days),
+			),
+		))
+		if _, err := sendReply(bot, reply); err != nil {
+			log.Printf("failed to send renewal payment offer: %v", err)
+		}
+		return
+	case err != nil:
+		if !errors.Is(err, errAdNotOwned) && !errors.Is(err, errAdNotRenewable) {
+			log.Printf("owner ads: failed to renew ad %d: %v", adID, err)
+		}
+		notifyUser(bot, chatID, "❌ "+paymentErrorText(locale, err))
+		return
+	}
+
+	announceOwnerRenewal(bot, ad, days)
+	replaceOwnerMessage(bot, chatID, messageID, i18n.T(locale, "owner.renewed",
+		ad.Title, formatDays(locale, days), formatDateTime(locale, ad.ExpiresAt), remaining))
+}
+
+// replaceOwnerMessage заменяет текст уведомления и убирает кнопки, чтобы их не нажали повторно
+func replaceOwnerMessage(bot *tgbotapi.BotAPI, chatID int64, messageID int, text string) {
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	if err := enqueueMessage(bot, edit); err != nil {
+		log.Printf("failed to update owner message: %v", err)
+		notifyUser(bot, chatID, text)
+	}
+}
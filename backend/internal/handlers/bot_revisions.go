@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// adHistoryLimit — сколько последних версий показывать в истории
+const adHistoryLimit = 10
+
+// showAdHistory показывает последние версии выбранного объявления с изменениями
+// и кнопками отката к каждой из прежних версий
+func showAdHistory(bot *tgbotapi.BotAPI, chatID int64) {
+	locale := telegramUserLocale(chatID)
+	session := getSession(chatID)
+	if session == nil || session.Ad.ID == 0 {
+		return
+	}
+
+	// Запрашиваем на одну версию больше, чтобы показать изменения и у самой старой из списка
+	revisions, err := loadAdRevisions(session.Ad.ID, adHistoryLimit+1)
+	if err != nil {
+		log.Printf("revisions: failed to load history of ad %d: %v", session.Ad.ID, err)
+		sendText(bot, chatID, i18n.T(locale, "manager.history.load_failed"))
+		return
+	}
+
+	back := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.back"), fmt.Sprintf("select_ad_%d", session.Ad.ID)),
+	)
+	if len(revisions) == 0 {
+		msg := tgbotapi.NewMessage(chatID, i18n.T(locale, "manager.history.empty", session.Ad.ID))
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(back)
+		if sentMsg, err := sendReply(bot, msg); err == nil {
+			addBotMessage(chatID, sentMsg.MessageID)
+		}
+		return
+	}
+
+	shown := revisions
+	if len(shown) > adHistoryLimit {
+		shown = shown[:adHistoryLimit]
+	}
+
+	var text strings.Builder
+	text.WriteString(i18n.T(locale, "manager.history.title", session.Ad.ID))
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, revision := range shown {
+		fmt.Fprintf(&text, "\nv%d — %s, %s", revision.Version, formatDateTime(locale, revision.CreatedAt), revisionAuthor(locale, revision))
+		if i == 0 {
+			text.WriteString(i18n.T(locale, "manager.history.current"))
+		}
+		text.WriteString("\n")
+
+		switch {
+		case revision.RestoredFrom != nil:
+			text.WriteString(i18n.T(locale, "manager.history.restored_from", *revision.RestoredFrom))
+		case i+1 < len(revisions):
+			changes := revisionDiff(locale, revisions[i+1], revision)
+			if len(changes) == 0 {
+				text.WriteString(i18n.T(locale, "manager.history.no_changes"))
+			}
+			for _, change := range changes {
+				text.WriteString("• " + change + "\n")
+			}
+		case revision.Version == 1:
+			text.WriteString(i18n.T(locale, "manager.history.first"))
+		}
+
+		if i > 0 {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					i18n.T(locale, "manager.history.button.restore", revision.Version),
+					fmt.Sprintf("ad_restore_%d", revision.ID),
+				),
+			))
+		}
+	}
+	rows = append(rows, back)
+
+	msg := tgbotapi.NewMessage(chatID, text.String())
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if sentMsg, err := sendReply(bot, msg); err == nil {
+		addBotMessage(chatID, sentMsg.MessageID)
+	} else {
+		log.Printf("revisions: failed to send history of ad %d: %v", session.Ad.ID, err)
+	}
+}
+
+// revisionAuthor — кто сохранил версию
+func revisionAuthor(locale string, revision models.AdRevision) string {
+	if revision.AuthorID == 0 {
+		return i18n.T(locale, "manager.history.author_legacy")
+	}
+	return i18n.T(locale, "manager.history.author", revision.AuthorID)
+}
+
+// handleAdRestore откатывает выбранное объявление к версии ad_restore_<ID версии>
+func handleAdRestore(bot *tgbotapi.BotAPI, chatID int64, managerID int64, data string) {
+	locale := telegramUserLocale(chatID)
+	session := getSession(chatID)
+	if session == nil || session.Ad.ID == 0 {
+		return
+	}
+	revisionID, err := strconv.ParseUint(strings.TrimPrefix(data, "ad_restore_"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	previousPrice := session.Ad.Price
+	ad, revision, err := restoreAdRevision(session.Ad.ID, uint(revisionID), managerID)
+	if err != nil {
+		if errors.Is(err, errRevisionNotFound) {
+			sendText(bot, chatID, "❌ "+i18n.Message(locale, err, "manager.history.restore_failed"))
+		} else {
+			log.Printf("revisions: failed to restore ad %d to revision %d: %v", session.Ad.ID, revisionID, err)
+			sendText(bot, chatID, i18n.T(locale, "manager.history.restore_failed"))
+		}
+		return
+	}
+
+	recordManagerAction(managerID, models.ManagerActionAdRestored, ad.ID, "")
+	publishAdToChannel(bot, &ad, false)
+	go notifyFavoritesPriceChanged(bot, ad, previousPrice)
+	session.Ad = ad
+	log.Printf("Объявление восстановлено: ID=%d, версия=%d, менеджер=%d", ad.ID, revision.Version, managerID)
+	sendText(bot, chatID, i18n.T(locale, "manager.history.restored", ad.ID, revision.Version))
+	showAdDetailsWithActions(bot, chatID, ad)
+}
@@ -0,0 +1,459 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+)
+
+// statsTopLimit — сколько категорий и тегов показывать в сводке
+const statsTopLimit = 5
+
+// statsPeriod — период сводки: сегодня, 7 или 30 дней, включая сегодняшний
+type statsPeriod struct {
+	Key  string
+	Days int
+}
+
+var statsPeriods = []statsPeriod{
+	{Key: "day", Days: 1},
+	{Key: "week", Days: 7},
+	{Key: "month", Days: 30},
+}
+
+func findStatsPeriod(key string) (statsPeriod, bool) {
+	for _, period := range statsPeriods {
+		if period.Key == key {
+			return period, true
+		}
+	}
+	return statsPeriod{}, false
+}
+
+// label — название периода на языке locale
+func (p statsPeriod) label(locale string) string {
+	return i18n.T(locale, "manager.stats.period."+p.Key)
+}
+
+// start — полночь первого дня периода
+func (p statsPeriod) start(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day()-p.Days+1, 0, 0, 0, 0, now.Location())
+}
+
+// Показатели сводки; они же значения колонки metric в CSV
+const (
+	statsMetricNewAds          = "new_ads"
+	statsMetricRenewals        = "renewals"
+	statsMetricExpired         = "expired"
+	statsMetricRemoved         = "removed"
+	statsMetricPremiumPayments = "premium_payments"
+	statsMetricPremiumRevenue  = "premium_revenue"
+	statsMetricBlacklisted     = "blacklisted"
+	statsMetricManagerAction   = "manager_action"
+)
+
+// statsRow — значение показателя за один день. Key и Detail уточняют разрез:
+// категория и тег для new_ads, источник для renewals, ID менеджера и действие для manager_action.
+type statsRow struct {
+	Day    string
+	Metric string
+	Key    string
+	Detail string
+	Value  int64
+}
+
+// loadStatsRows собирает показатели биржи по дням с from
+func loadStatsRows(from time.Time) ([]statsRow, error) {
+	const day = "TO_CHAR(%s, 'YYYY-MM-DD') AS day"
+	var rows []statsRow
+
+	collect := func(metric string, query *gorm.DB) error {
+		var scanned []statsRow
+		if err := query.Scan(&scanned).Error; err != nil {
+			return fmt.Errorf("%s: %w", metric, err)
+		}
+		for _, row := range scanned {
+			row.Metric = metric
+			rows = append(rows, row)
+		}
+		return nil
+	}
+
+	// Удалённые объявления тоже учитываются: они были созданы, истекли или сняты в этот период
+	ads := func() *gorm.DB { return db.DB.Unscoped().Model(&models.Ad{}) }
+
+	if err := collect(statsMetricNewAds, ads().
+		Select(fmt.Sprintf(day, "created_at")+", category AS key, tag AS detail, COUNT(*) AS value").
+		Where("created_at >= ?", from).
+		Group("day, category, tag")); err != nil {
+		return nil, err
+	}
+	if err := collect(statsMetricRenewals, db.DB.Model(&models.AdRenewal{}).
+		Select(fmt.Sprintf(day, "created_at")+", source AS key, COUNT(*) AS value").
+		Where("created_at >= ?", from).
+		Group("day, source")); err != nil {
+		return nil, err
+	}
+	// Истёкшее и затем продлённое объявление получает новый срок, поэтому здесь не учитывается
+	if err := collect(statsMetricExpired, ads().
+		Select(fmt.Sprintf(day, "expires_at")+", COUNT(*) AS value").
+		Where("status = ? AND expires_at >= ?", models.AdStatusExpired, from).
+		Group("day")); err != nil {
+		return nil, err
+	}
+	// Снятия считаются по журналу менеджеров: ads.removed_at сбрасывается при повторной
+	// публикации и продлении, и снятие пропало бы из статистики
+	if err := collect(statsMetricRemoved, db.DB.Model(&models.ManagerAction{}).
+		Select(fmt.Sprintf(day, "created_at")+", COUNT(*) AS value").
+		Where("action = ? AND created_at >= ?", models.ManagerActionAdRemoved, from).
+		Group("day")); err != nil {
+		return nil, err
+	}
+
+	paidPremium := func() *gorm.DB {
+		return db.DB.Model(&models.Payment{}).
+			Where("product = ? AND status IN ? AND paid_at >= ?", models.PaymentProductPremium,
+				[]string{models.PaymentStatusPaid, models.PaymentStatusFulfilled}, from).
+			Group("day")
+	}
+	if err := collect(statsMetricPremiumPayments, paidPremium().
+		Select(fmt.Sprintf(day, "paid_at")+", COUNT(*) AS value")); err != nil {
+		return nil, err
+	}
+	if err := collect(statsMetricPremiumRevenue, paidPremium().
+		Select(fmt.Sprintf(day, "paid_at")+", SUM(amount) AS value")); err != nil {
+		return nil, err
+	}
+
+	if err := collect(statsMetricBlacklisted, db.DB.Unscoped().Model(&models.User{}).
+		Select(fmt.Sprintf(day, "blacklisted_at")+", COUNT(*) AS value").
+		Where("blacklisted_at >= ?", from).
+		Group("day")); err != nil {
+		return nil, err
+	}
+	if err := collect(statsMetricManagerAction, db.DB.Model(&models.ManagerAction{}).
+		Select(fmt.Sprintf(day, "created_at")+", CAST(manager_id AS TEXT) AS key, action AS detail, COUNT(*) AS value").
+		Where("created_at >= ?", from).
+		Group("day, manager_id, action")); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Day < rows[j].Day })
+	return rows, nil
+}
+
+// statsCount — значение в разрезе (категории, тега, источника)
+type statsCount struct {
+	Key    string
+	Detail string
+	Value  int64
+}
+
+// managerActivity — действия одного менеджера за период
+type managerActivity struct {
+	ManagerID int64
+	Actions   map[string]int64
+	Total     int64
+}
+
+// statsSummary — сводка за период
+type statsSummary struct {
+	Totals        map[string]int64
+	Renewals      map[string]int64
+	TopCategories []statsCount
+	TopTags       []statsCount
+	Managers      []managerActivity
+}
+
+func summarizeStats(rows []statsRow) statsSummary {
+	summary := statsSummary{
+		Totals:   make(map[string]int64),
+		Renewals: make(map[string]int64),
+	}
+	categories := make(map[string]int64)
+	tags := make(map[statsCount]int64)
+	managers := make(map[int64]*managerActivity)
+
+	for _, row := range rows {
+		summary.Totals[row.Metric] += row.Value
+		switch row.Metric {
+		case statsMetricNewAds:
+			categories[row.Key] += row.Value
+			tags[statsCount{Key: row.Key, Detail: row.Detail}] += row.Value
+		case statsMetricRenewals:
+			summary.Renewals[row.Key] += row.Value
+		case statsMetricManagerAction:
+			managerID, err := strconv.ParseInt(row.Key, 10, 64)
+			if err != nil {
+				continue
+			}
+			activity, ok := managers[managerID]
+			if !ok {
+				activity = &managerActivity{ManagerID: managerID, Actions: make(map[string]int64)}
+				managers[managerID] = activity
+			}
+			activity.Actions[row.Detail] += row.Value
+			activity.Total += row.Value
+		}
+	}
+
+	for key, value := range categories {
+		summary.TopCategories = append(summary.TopCategories, statsCount{Key: key, Value: value})
+	}
+	for key, value := range tags {
+		key.Value = value
+		summary.TopTags = append(summary.TopTags, key)
+	}
+	summary.TopCategories = topStatsCounts(summary.TopCategories)
+	summary.TopTags = topStatsCounts(summary.TopTags)
+
+	for _, activity := range managers {
+		summary.Managers = append(summary.Managers, *activity)
+	}
+	sort.Slice(summary.Managers, func(i, j int) bool {
+		if summary.Managers[i].Total != summary.Managers[j].Total {
+			return summary.Managers[i].Total > summary.Managers[j].Total
+		}
+		return summary.Managers[i].ManagerID < summary.Managers[j].ManagerID
+	})
+	return summary
+}
+
+func topStatsCounts(counts []statsCount) []statsCount {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Value != counts[j].Value {
+			return counts[i].Value > counts[j].Value
+		}
+		if counts[i].Key != counts[j].Key {
+			return counts[i].Key < counts[j].Key
+		}
+		return counts[i].Detail < counts[j].Detail
+	})
+	if len(counts) > statsTopLimit {
+		counts = counts[:statsTopLimit]
+	}
+	return counts
+}
+
+// renewalSourceKeys — подписи источников продления в сводке (ключи каталога)
+var renewalSourceKeys = map[string]string{
+	models.RenewalSourceManager: "manager.stats.source.manager",
+	models.RenewalSourceOwner:   "manager.stats.source.owner",
+	models.RenewalSourcePayment: "manager.stats.source.payment",
+	models.RenewalSourceAuto:    "manager.stats.source.auto",
+}
+
+// managerActionNames — порядок и подписи действий менеджера в сводке (ключи каталога)
+var managerActionNames = []struct {
+	Action   string
+	LabelKey string
+}{
+	{models.ManagerActionAdCreated, "manager.stats.action.ad_created"},
+	{models.ManagerActionAdEdited, "manager.stats.action.ad_edited"},
+	{models.ManagerActionAdRestored, "manager.stats.action.ad_restored"},
+	{models.ManagerActionAdRenewed, "manager.stats.action.ad_renewed"},
+	{models.ManagerActionAdRemoved, "manager.stats.action.ad_removed"},
+	{models.ManagerActionAdBumped, "manager.stats.action.ad_bumped"},
+	{models.ManagerActionPremiumBooked, "manager.stats.action.premium_booked"},
+	{models.ManagerActionBlacklisted, "manager.stats.action.blacklisted"},
+	{models.ManagerActionUnblacklisted, "manager.stats.action.unblacklisted"},
+	{models.ManagerActionBlacklistImport, "manager.stats.action.blacklist_import"},
+	{models.ManagerActionBroadcast, "manager.stats.action.broadcast"},
+	{models.ManagerActionAdRequest, "manager.stats.action.ad_request"},
+}
+
+// recordManagerAction записывает действие менеджера в журнал. Ошибка только логируется:
+// журнал нужен для статистики и не должен мешать самому действию.
+func recordManagerAction(managerID int64, action string, adID uint, username string) {
+	if managerID == 0 {
+		return
+	}
+	entry := models.ManagerAction{ManagerID: managerID, Action: action, AdID: adID, Username: username}
+	if err := db.DB.Create(&entry).Error; err != nil {
+		log.Printf("stats: failed to record manager action %s by %d: %v", action, managerID, err)
+	}
+}
+
+// managerUsernames — username менеджеров, которые уже писали в бот
+func managerUsernames(managers []managerActivity) map[int64]string {
+	ids := make([]int64, 0, len(managers))
+	for _, manager := range managers {
+		ids = append(ids, manager.ManagerID)
+	}
+	result := make(map[int64]string, len(ids))
+	if len(ids) == 0 {
+		return result
+	}
+	var users []models.User
+	if err := db.DB.Select("telegram_id", "username").Where("telegram_id IN ?", ids).Find(&users).Error; err != nil {
+		log.Printf("stats: failed to load manager usernames: %v", err)
+		return result
+	}
+	for _, user := range users {
+		if user.TelegramID != nil && user.Username != "" {
+			result[*user.TelegramID] = user.Username
+		}
+	}
+	return result
+}
+
+// showStats показывает менеджеру сводку по бирже за период
+func showStats(bot *tgbotapi.BotAPI, chatID int64, period statsPeriod) {
+	locale := telegramUserLocale(chatID)
+	from := period.start(time.Now())
+	rows, err := loadStatsRows(from)
+	if err != nil {
+		log.Printf("stats: failed to load stats: %v", err)
+		sendText(bot, chatID, i18n.T(locale, "manager.stats.load_failed"))
+		return
+	}
+	summary := summarizeStats(rows)
+
+	var text strings.Builder
+	text.WriteString(i18n.T(locale, "manager.stats.title", period.label(locale), formatDate(locale, from)))
+	text.WriteString(i18n.T(locale, "manager.stats.new_ads", summary.Totals[statsMetricNewAds]))
+	text.WriteString(i18n.T(locale, "manager.stats.renewals", summary.Totals[statsMetricRenewals]))
+	var sources []string
+	for _, source := range []string{models.RenewalSourceManager, models.RenewalSourceOwner, models.RenewalSourcePayment, models.RenewalSourceAuto} {
+		if count := summary.Renewals[source]; count > 0 {
+			sources = append(sources, fmt.Sprintf("%s %d", i18n.T(locale, renewalSourceKeys[source]), count))
+		}
+	}
+	if len(sources) > 0 {
+		text.WriteString(" (" + strings.Join(sources, " · ") + ")")
+	}
+	text.WriteString("\n")
+	text.WriteString(i18n.T(locale, "manager.stats.expired", summary.Totals[statsMetricExpired]))
+	text.WriteString(i18n.T(locale, "manager.stats.removed", summary.Totals[statsMetricRemoved]))
+	text.WriteString(i18n.T(locale, "manager.stats.premium",
+		summary.Totals[statsMetricPremiumRevenue], summary.Totals[statsMetricPremiumPayments]))
+	text.WriteString(i18n.T(locale, "manager.stats.blacklisted", summary.Totals[statsMetricBlacklisted]))
+
+	if len(summary.TopCategories) > 0 {
+		text.WriteString(i18n.T(locale, "manager.stats.top_categories"))
+		for i, row := range summary.TopCategories {
+			text.WriteString(fmt.Sprintf("%d. %s — %d\n", i+1, escapeMarkdown(categoryName(row.Key)), row.Value))
+		}
+		text.WriteString(i18n.T(locale, "manager.stats.top_tags"))
+		for i, row := range summary.TopTags {
+			text.WriteString(fmt.Sprintf("%d. %s / %s — %d\n", i+1,
+				escapeMarkdown(categoryName(row.Key)), escapeMarkdown(tagName(row.Key, row.Detail)), row.Value))
+		}
+	}
+
+	text.WriteString(i18n.T(locale, "manager.stats.managers"))
+	if len(summary.Managers) == 0 {
+		text.WriteString(i18n.T(locale, "manager.stats.no_actions"))
+	}
+	usernames := managerUsernames(summary.Managers)
+	for _, manager := range summary.Managers {
+		name := i18n.T(locale, "manager.history.author", manager.ManagerID)
+		if username := usernames[manager.ManagerID]; username != "" {
+			name += " (@" + username + ")"
+		}
+		var actions []string
+		for _, action := range managerActionNames {
+			if count := manager.Actions[action.Action]; count > 0 {
+				actions = append(actions, fmt.Sprintf("%s %d", i18n.T(locale, action.LabelKey), count))
+			}
+		}
+		text.WriteString(fmt.Sprintf("• %s: %d — %s\n", escapeMarkdown(name), manager.Total, strings.Join(actions, ", ")))
+	}
+
+	periodButtons := make([]tgbotapi.InlineKeyboardButton, 0, len(statsPeriods))
+	for _, option := range statsPeriods {
+		label := option.label(locale)
+		if option.Key == period.Key {
+			label = "• " + label
+		}
+		periodButtons = append(periodButtons, tgbotapi.NewInlineKeyboardButtonData(label, "stats_"+option.Key))
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		periodButtons,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.stats.button.csv"), "stats_csv_"+period.Key),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.button.menu"), "menu_main"),
+		),
+	)
+
+	msg := tgbotapi.NewMessage(chatID, text.String())
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = keyboard
+	sentMsg, err := sendReply(bot, msg)
+	if err != nil {
+		log.Printf("failed to send stats: %v", err)
+		return
+	}
+	addBotMessage(chatID, sentMsg.MessageID)
+}
+
+// writeStatsCSV пишет показатели по дням в длинном формате: одна строка — одно значение
+func writeStatsCSV(w io.Writer, rows []statsRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"date", "metric", "key", "detail", "value"}); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writer.Write([]string{row.Day, row.Metric, row.Key, row.Detail, strconv.FormatInt(row.Value, 10)}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// sendStatsExport отправляет менеджеру показатели периода файлом CSV
+func sendStatsExport(bot *tgbotapi.BotAPI, chatID int64, period statsPeriod) {
+	locale := telegramUserLocale(chatID)
+	now := time.Now()
+	from := period.start(now)
+	rows, err := loadStatsRows(from)
+	if err != nil {
+		log.Printf("stats: failed to load stats for export: %v", err)
+		sendText(bot, chatID, i18n.T(locale, "manager.stats.load_failed"))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := writeStatsCSV(&buf, rows); err != nil {
+		sendText(bot, chatID, i18n.T(locale, "manager.stats.csv_failed"))
+		return
+	}
+
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{
+		Name:  fmt.Sprintf("stats-%s-%s.csv", from.Format("20060102"), now.Format("20060102")),
+		Bytes: buf.Bytes(),
+	})
+	doc.Caption = i18n.T(locale, "manager.stats.caption", period.label(locale), formatDate(locale, from))
+	if _, err := sendReply(bot, doc); err != nil {
+		log.Printf("failed to send stats export: %v", err)
+	}
+}
+
+// handleStatsCallback — кнопки stats_<период> и stats_csv_<период>
+func handleStatsCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
+	if key, ok := strings.CutPrefix(data, "stats_csv_"); ok {
+		if period, ok := findStatsPeriod(key); ok {
+			sendStatsExport(bot, chatID, period)
+		}
+		return
+	}
+	if period, ok := findStatsPeriod(strings.TrimPrefix(data, "stats_")); ok {
+		showStats(bot, chatID, period)
+	}
+}
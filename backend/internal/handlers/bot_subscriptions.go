@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// defaultSearchAlertsPerHour — сколько уведомлений о новых объявлениях получает пользователь в час
+	defaultSearchAlertsPerHour = 5
+	// searchCallbackPrefix — кнопка «Отписаться» в уведомлении: sub_off_<ID поиска>; доступна всем пользователям
+	searchCallbackPrefix = "sub_"
+)
+
+// searchAlertLockClass — первый ключ advisory-блокировки лимита уведомлений, второй — хеш Telegram ID подписчика
+const searchAlertLockClass int32 = 0x73726368 // "srch"
+
+// errSearchAlertsLimited — пользователь уже получил SAVED_SEARCH_ALERTS_PER_HOUR уведомлений за час
+var errSearchAlertsLimited = errors.New("saved search alerts limit reached")
+
+// searchAlertsPerHour читает SAVED_SEARCH_ALERTS_PER_HOUR
+func searchAlertsPerHour() int {
+	if raw := strings.TrimSpace(os.Getenv("SAVED_SEARCH_ALERTS_PER_HOUR")); raw != "" {
+		if limit, err := strconv.Atoi(raw); err == nil && limit > 0 {
+			return limit
+		}
+		log.Printf("saved searches: invalid SAVED_SEARCH_ALERTS_PER_HOUR=%q, using default %d", raw, defaultSearchAlertsPerHour)
+	}
+	return defaultSearchAlertsPerHour
+}
+
+// dispatchSavedSearchAlerts рассылает подписчикам уведомление об опубликованном объявлении.
+// Каждый пользователь получает не больше одного уведомления об объявлении; после
+// SAVED_SEARCH_ALERTS_PER_HOUR уведомлений за час остальные пропускаются и учитываются
+// в market_saved_search_alerts_total{result="limited"}.
+func dispatchSavedSearchAlerts(bot *tgbotapi.BotAPI, ad models.Ad) {
+	if ad.ID == 0 || ad.Status != models.AdStatusActive {
+		return
+	}
+
+	var searches []models.SavedSearch
+	if err := db.DB.
+		Where("category = ? AND (mode = '' OR mode = ?) AND (tag = '' OR tag = ?)", ad.Category, ad.Mode, ad.Tag).
+		Order("id").Find(&searches).Error; err != nil {
+		log.Printf("saved searches: failed to load searches for ad %d: %v", ad.ID, err)
+		return
+	}
+
+	ownerID := ownerTelegramID(ad)
+	limit := searchAlertsPerHour()
+	notified := make(map[int64]bool)
+	for _, search := range searches {
+		if search.UserID == ownerID || notified[search.UserID] || !matchesSavedSearch(search, ad) {
+			continue
+		}
+		notified[search.UserID] = true
+
+		created, err := reserveSavedSearchAlert(search, ad.ID, limit)
+		switch {
+		case errors.Is(err, errSearchAlertsLimited):
+			searchAlertsTotal.WithLabelValues("limited").Inc()
+			log.Printf("saved searches: user %d reached %d alerts per hour, ad %d skipped", search.UserID, limit, ad.ID)
+			continue
+		case err != nil:
+			searchAlertsTotal.WithLabelValues("error").Inc()
+			log.Printf("saved searches: failed to record alert for user %d: %v", search.UserID, err)
+			continue
+		case !created:
+			continue
+		}
+
+		searchAlertsTotal.WithLabelValues("sent").Inc()
+		sendSavedSearchAlert(bot, search, ad)
+	}
+}
+
+// lockUserLimit берёт транзакционную advisory-блокировку пользователя userID до конца tx.
+// Под ней проверяются лимиты «не больше N записей на пользователя»: без неё два параллельных
+// запроса одновременно видят N-1 запись и оба добавляют ещё одну.
+func lockUserLimit(tx *gorm.DB, class int32, userID int64) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?, hashtext(?))", class, strconv.FormatInt(userID, 10)).Error
+}
+
+// reserveSavedSearchAlert записывает уведомление подписчику, если он не превысил лимит за час.
+// Подсчёт и запись идут в одной транзакции под advisory-блокировкой пользователя: иначе
+// одновременная публикация нескольких объявлений увидит один и тот же счётчик и превысит лимит.
+// Возвращает false, если уведомление об этом объявлении уже записано.
+func reserveSavedSearchAlert(search models.SavedSearch, adID uint, limit int) (bool, error) {
+	created := false
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockUserLimit(tx, searchAlertLockClass, search.UserID); err != nil {
+			return err
+		}
+
+		var sent int64
+		if err := tx.Model(&models.SavedSearchAlert{}).
+			Where("user_id = ? AND created_at > ?", search.UserID, time.Now().Add(-time.Hour)).
+			Count(&sent).Error; err != nil {
+			return err
+		}
+		if sent >= int64(limit) {
+			return errSearchAlertsLimited
+		}
+
+		// Повторное сохранение того же объявления не должно присылать уведомление ещё раз
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.SavedSearchAlert{
+			UserID:   search.UserID,
+			AdID:     adID,
+			SearchID: search.ID,
+		})
+		created = result.RowsAffected > 0
+		return result.Error
+	})
+	return created, err
+}
+
+// adDetails — категория, режим, рубрика и цена объявления одной строкой на языке locale
+func adDetails(locale string, ad models.Ad) string {
+	details := []string{categoryName(ad.Category)}
+	if _, single := singleMode(ad.Category); !single {
+		details = append(details, modeName(ad.Category, ad.Mode))
+	}
+	details = append(details, tagName(ad.Category, ad.Tag))
+	if ad.Price > 0 {
+		details = append(details, formatPrice(locale, ad.Price))
+	}
+	return strings.Join(nonEmpty(details), " · ")
+}
+
+func sendSavedSearchAlert(bot *tgbotapi.BotAPI, search models.SavedSearch, ad models.Ad) {
+	locale := telegramUserLocale(search.UserID)
+	msg := tgbotapi.NewMessage(search.UserID, i18n.T(locale, "search.alert", ad.Title, adDetails(locale, ad)))
+	msg.ReplyMarkup = savedSearchAlertKeyboard(locale, ad.ID, search.ID, true)
+	if err := enqueueMessage(bot, msg); err != nil {
+		log.Printf("saved searches: failed to notify user %d about ad %d: %v", search.UserID, ad.ID, err)
+	}
+}
+
+// savedSearchAlertKeyboard — ссылка на объявление в Mini App и кнопка отписки от поиска
+func savedSearchAlertKeyboard(locale string, adID, searchID uint, withUnsubscribe bool) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if url := miniAppAdURL(adID); url != "" {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL(i18n.T(locale, "search.button.open"), url),
+		))
+	}
+	if withUnsubscribe {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "search.button.unsubscribe"), fmt.Sprintf("%soff_%d_%d", searchCallbackPrefix, searchID, adID)),
+		))
+	}
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// handleSavedSearchCallback удаляет сохранённый поиск по кнопке из уведомления: sub_off_<ID поиска>_<ID объявления>
+func handleSavedSearchCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) {
+	if _, err := bot.Request(tgbotapi.NewCallback(callback.ID, "")); err != nil {
+		log.Printf("callback answer error: %v", err)
+	}
+	if callback.Message == nil || callback.From == nil {
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(callback.Data, searchCallbackPrefix), "_")
+	if len(parts) != 3 || parts[0] != "off" {
+		return
+	}
+	searchID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return
+	}
+	adID, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return
+	}
+
+	chatID := callback.Message.Chat.ID
+	locale := userLocale(callback.From)
+	deleted, err := deleteSavedSearch(uint(searchID), callback.From.ID)
+	if err != nil {
+		log.Printf("saved searches: failed to delete search %d of user %d: %v", searchID, callback.From.ID, err)
+		notifyUser(bot, chatID, i18n.T(locale, "search.unsubscribe_failed"))
+		return
+	}
+
+	// Убираем кнопку отписки, ссылку на объявление оставляем
+	edit := tgbotapi.NewEditMessageReplyMarkup(chatID, callback.Message.MessageID, savedSearchAlertKeyboard(locale, uint(adID), 0, false))
+	if _, err := sendReply(bot, edit); err != nil {
+		log.Printf("saved searches: failed to update alert keyboard: %v", err)
+	}
+
+	if !deleted {
+		notifyUser(bot, chatID, i18n.T(locale, "search.not_found"))
+		return
+	}
+	notifyUser(bot, chatID, i18n.T(locale, "search.unsubscribed"))
+}
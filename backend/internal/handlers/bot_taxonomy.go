@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const commandTaxonomy = "/taxonomy"
+
+// handleTaxonomyCommand — просмотр и правка категорий, режимов и тегов менеджером
+func handleTaxonomyCommand(bot *tgbotapi.BotAPI, chatID int64, text string) {
+	locale := telegramUserLocale(chatID)
+	args := strings.Fields(text)[1:]
+	if len(args) == 0 {
+		sendText(bot, chatID, renderTaxonomy(locale, currentTaxonomy()))
+		return
+	}
+
+	action := strings.ToLower(args[0])
+	target, rest, ok := parseTaxonomyTarget(args[1:])
+	if !ok {
+		sendText(bot, chatID, i18n.T(locale, "manager.taxonomy.usage"))
+		return
+	}
+
+	var err error
+	switch {
+	case action == "add" && len(rest) > 0:
+		label := strings.Join(rest, " ")
+		if target.Kind == "" {
+			err = addTaxonomyCategory(target.Key, label)
+		} else {
+			err = addTaxonomyOption(target.Category, target.Kind, target.Key, label)
+		}
+	case action == "rename" && len(rest) > 0:
+		err = updateTaxonomy(target, map[string]interface{}{"label": strings.Join(rest, " ")})
+	case action == "hide" && len(rest) == 0:
+		err = updateTaxonomy(target, map[string]interface{}{"active": false})
+	case action == "show" && len(rest) == 0:
+		err = updateTaxonomy(target, map[string]interface{}{"active": true})
+	case action == "move" && len(rest) == 1:
+		position, convErr := strconv.Atoi(rest[0])
+		if convErr != nil {
+			sendText(bot, chatID, i18n.T(locale, "manager.taxonomy.usage"))
+			return
+		}
+		err = moveTaxonomy(target, position)
+	default:
+		sendText(bot, chatID, i18n.T(locale, "manager.taxonomy.usage"))
+		return
+	}
+
+	if err != nil {
+		if errors.Is(err, errTaxonomyKey) || errors.Is(err, errTaxonomyLabel) ||
+			errors.Is(err, errTaxonomyExists) || errors.Is(err, errTaxonomyNotFound) ||
+			errors.Is(err, errTaxonomyLastMode) {
+			sendText(bot, chatID, "❌ "+i18n.Message(locale, err, "manager.taxonomy.save_failed"))
+		} else {
+			log.Printf("taxonomy: failed to %s %+v: %v", action, target, err)
+			sendText(bot, chatID, i18n.T(locale, "manager.taxonomy.save_failed"))
+		}
+		return
+	}
+
+	log.Printf("Рубрики изменены: %s %+v %v", action, target, rest)
+	sendText(bot, chatID, i18n.T(locale, "manager.taxonomy.updated")+renderTaxonomy(locale, currentTaxonomy()))
+}
+
+// parseTaxonomyTarget разбирает «category <ключ>» или «mode|tag <категория> <ключ>»
+// и возвращает оставшиеся аргументы
+func parseTaxonomyTarget(args []string) (taxonomyTarget, []string, bool) {
+	if len(args) < 2 {
+		return taxonomyTarget{}, nil, false
+	}
+	switch kind := strings.ToLower(args[0]); kind {
+	case "category":
+		return taxonomyTarget{Key: strings.ToLower(args[1])}, args[2:], true
+	case models.TaxonomyKindMode, models.TaxonomyKindTag:
+		if len(args) < 3 {
+			return taxonomyTarget{}, nil, false
+		}
+		return taxonomyTarget{Kind: kind, Category: strings.ToLower(args[1]), Key: strings.ToLower(args[2])}, args[3:], true
+	default:
+		return taxonomyTarget{}, nil, false
+	}
+}
+
+// renderTaxonomy — дерево рубрик с ключами на языке locale; скрытые отмечены 🚫
+func renderTaxonomy(locale string, current taxonomy) string {
+	var text strings.Builder
+	text.WriteString(i18n.T(locale, "manager.taxonomy.title"))
+	for _, category := range current.Categories {
+		fmt.Fprintf(&text, "\n%d. %s [%s]%s\n", category.Position, category.Label, category.Key, taxonomyHiddenMark(category.Active))
+		for _, kind := range []string{models.TaxonomyKindMode, models.TaxonomyKindTag} {
+			var items []string
+			for _, option := range current.Options {
+				if option.Category == category.Key && option.Kind == kind {
+					items = append(items, fmt.Sprintf("%s [%s]%s", option.Label, option.Key, taxonomyHiddenMark(option.Active)))
+				}
+			}
+			if len(items) == 0 {
+				continue
+			}
+			label := i18n.T(locale, "manager.taxonomy.tags")
+			if kind == models.TaxonomyKindMode {
+				label = i18n.T(locale, "manager.taxonomy.modes")
+			}
+			fmt.Fprintf(&text, "   %s: %s\n", label, strings.Join(items, ", "))
+		}
+	}
+	text.WriteString(i18n.T(locale, "manager.taxonomy.help"))
+	return text.String()
+}
+
+func taxonomyHiddenMark(active bool) string {
+	if active {
+		return ""
+	}
+	return " 🚫"
+}
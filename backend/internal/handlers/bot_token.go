@@ -1,10 +1,15 @@
 package handlers
 
-import "sync"
+import (
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
 
 var (
 	botTokenMu sync.RWMutex
 	botToken   string
+	botAPI     *tgbotapi.BotAPI
 )
 
 func setBotToken(token string) {
@@ -19,3 +24,16 @@ func getBotToken() string {
 	return botToken
 }
 
+// setBotAPI сохраняет клиент бота для HTTP-обработчиков, которым нужно писать в Telegram
+func setBotAPI(bot *tgbotapi.BotAPI) {
+	botTokenMu.Lock()
+	defer botTokenMu.Unlock()
+	botAPI = bot
+}
+
+// getBotAPI возвращает клиент бота или nil, если бот не запущен
+func getBotAPI() *tgbotapi.BotAPI {
+	botTokenMu.RLock()
+	defer botTokenMu.RUnlock()
+	return botAPI
+}
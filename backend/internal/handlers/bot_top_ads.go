@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"youtube-market/internal/analytics"
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	commandTopAds = "/top"
+	// topAdsLimit — сколько объявлений показывать в отчёте
+	topAdsLimit       = 10
+	defaultTopAdsDays = 7
+	maxTopAdsDays     = 90
+)
+
+// handleTopAdsCommand — /top [дней]: самые востребованные объявления за период
+func handleTopAdsCommand(bot *tgbotapi.BotAPI, chatID int64, text string) {
+	locale := telegramUserLocale(chatID)
+	days := defaultTopAdsDays
+	if args := strings.Fields(text); len(args) > 1 {
+		parsed, err := strconv.Atoi(args[1])
+		if err != nil || parsed < 1 || parsed > maxTopAdsDays {
+			sendText(bot, chatID, i18n.T(locale, "manager.top.usage", maxTopAdsDays, defaultTopAdsDays))
+			return
+		}
+		days = parsed
+	}
+	showTopAds(bot, chatID, days)
+}
+
+// showTopAds показывает объявления с наибольшим числом нажатий «Связаться», открытий и показов
+func showTopAds(bot *tgbotapi.BotAPI, chatID int64, days int) {
+	locale := telegramUserLocale(chatID)
+	if !analytics.Enabled() {
+		sendText(bot, chatID, i18n.T(locale, "manager.top.disabled"))
+		return
+	}
+
+	top, err := analytics.Top(analytics.Since(days), topAdsLimit)
+	if err != nil {
+		log.Printf("analytics: failed to load top ads: %v", err)
+		sendText(bot, chatID, i18n.T(locale, "manager.stats.load_failed"))
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(i18n.T(locale, "manager.top.title", formatDays(locale, days)))
+	text.WriteString(i18n.T(locale, "manager.top.legend"))
+	if len(top) == 0 {
+		text.WriteString(i18n.T(locale, "manager.top.empty"))
+	}
+
+	adIDs := make([]uint, 0, len(top))
+	for _, row := range top {
+		adIDs = append(adIDs, row.AdID)
+	}
+	var ads []models.Ad
+	if err := db.DB.Unscoped().Select("id", "title").Where("id IN ?", adIDs).Find(&ads).Error; err != nil {
+		log.Printf("analytics: failed to load titles of top ads: %v", err)
+	}
+	titles := make(map[uint]string, len(ads))
+	for _, ad := range ads {
+		titles[ad.ID] = ad.Title
+	}
+
+	for i, row := range top {
+		title := titles[row.AdID]
+		if title == "" {
+			title = i18n.T(locale, "manager.top.deleted")
+		}
+		text.WriteString(fmt.Sprintf("%d. #%d %s\n    👁 %d · 📖 %d · 💬 %d%s\n",
+			i+1, row.AdID, escapeMarkdown(truncate(title, 40)), row.Impressions, row.Views, row.Contacts, contactRate(row.Totals)))
+	}
+	text.WriteString(i18n.T(locale, "manager.top.footer"))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 7), "top_ads_7"),
+			tgbotapi.NewInlineKeyboardButtonData(formatDays(locale, 30), "top_ads_30"),
+		),
+	)
+	msg := tgbotapi.NewMessage(chatID, text.String())
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = keyboard
+	if _, err := sendReply(bot, msg); err != nil {
+		log.Printf("failed to send top ads: %v", err)
+	}
+}
+
+// contactRate — доля открытий, после которых нажали «Связаться»
+func contactRate(totals analytics.Totals) string {
+	if totals.Views == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%.0f%%)", float64(totals.Contacts)*100/float64(totals.Views))
+}
+
+// handleTopAdsCallback — кнопки периода top_ads_<дней>
+func handleTopAdsCallback(bot *tgbotapi.BotAPI, chatID int64, data string) {
+	days, err := strconv.Atoi(strings.TrimPrefix(data, "top_ads_"))
+	if err != nil || days < 1 || days > maxTopAdsDays {
+		return
+	}
+	showTopAds(bot, chatID, days)
+}
@@ -0,0 +1,502 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"youtube-market/internal/analytics"
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// userCallbackPrefix — кнопки меню пользователя: usr_menu, usr_myads, usr_check, usr_request, usr_ad_<ID>
+	userCallbackPrefix = "usr_"
+	// adRequestCallbackPrefix — кнопки заявки у менеджера: adreq_accept_<ID>, adreq_reject_<ID>, adreq_check_<ID>
+	adRequestCallbackPrefix = "adreq_"
+	// maxUserAdsListed — сколько объявлений показывать списком, остальные — в Mini App
+	maxUserAdsListed = 10
+	// adRequestDailyLimit — заявок от пользователя за сутки
+	adRequestDailyLimit = 3
+	minAdRequestLength  = 20
+	maxAdRequestLength  = 2000
+)
+
+var (
+	webAppURLOnce sync.Once
+	webAppURL     string
+)
+
+// webAppBaseURL — адрес Mini App для кнопки web_app (WEB_APP_URL, только https)
+func webAppBaseURL() string {
+	webAppURLOnce.Do(func() {
+		raw := strings.TrimSpace(os.Getenv("WEB_APP_URL"))
+		if raw == "" {
+			return
+		}
+		if !strings.HasPrefix(raw, "https://") {
+			log.Printf("user bot: invalid WEB_APP_URL=%q, Telegram accepts only https", raw)
+			return
+		}
+		webAppURL = raw
+	})
+	return webAppURL
+}
+
+type webAppInfo struct {
+	URL string `json:"url"`
+}
+
+// userButton — кнопка inline-клавиатуры. В tgbotapi v5.5.1 нет кнопок web_app,
+// поэтому экраны пользователя отправляются через MakeRequest.
+type userButton struct {
+	Text         string      `json:"text"`
+	CallbackData string      `json:"callback_data,omitempty"`
+	URL          string      `json:"url,omitempty"`
+	WebApp       *webAppInfo `json:"web_app,omitempty"`
+}
+
+func userDataButton(text, data string) userButton {
+	return userButton{Text: text, CallbackData: userCallbackPrefix + data}
+}
+
+// userButtonsFrom переносит кнопки клавиатуры tgbotapi (данные и ссылки) на экран пользователя
+func userButtonsFrom(markup tgbotapi.InlineKeyboardMarkup) [][]userButton {
+	rows := make([][]userButton, 0, len(markup.InlineKeyboard))
+	for _, row := range markup.InlineKeyboard {
+		buttons := make([]userButton, 0, len(row))
+		for _, button := range row {
+			converted := userButton{Text: button.Text}
+			if button.CallbackData != nil {
+				converted.CallbackData = *button.CallbackData
+			}
+			if button.URL != nil {
+				converted.URL = *button.URL
+			}
+			buttons = append(buttons, converted)
+		}
+		rows = append(rows, buttons)
+	}
+	return rows
+}
+
+// openAppButton — «Открыть биржу»: кнопка web_app, а без WEB_APP_URL — ссылка MINI_APP_URL
+func openAppButton(locale string) (userButton, bool) {
+	text := i18n.T(locale, "user.button.open_app")
+	if url := webAppBaseURL(); url != "" {
+		return userButton{Text: text, WebApp: &webAppInfo{URL: url}}, true
+	}
+	if url := strings.TrimSpace(os.Getenv("MINI_APP_URL")); url != "" {
+		return userButton{Text: text, URL: url}, true
+	}
+	return userButton{}, false
+}
+
+func userMenuRow(locale string) []userButton {
+	return []userButton{userDataButton(i18n.T(locale, "user.button.menu"), "menu")}
+}
+
+// sendUserScreen редактирует сообщение messageID, а если его нет или изменить не удалось —
+// отправляет новое
+func sendUserScreen(bot *tgbotapi.BotAPI, chatID int64, messageID int, text, parseMode string, rows [][]userButton) {
+	params := tgbotapi.Params{"chat_id": strconv.FormatInt(chatID, 10), "text": text}
+	params.AddNonEmpty("parse_mode", parseMode)
+	if len(rows) > 0 {
+		if err := params.AddInterface("reply_markup", map[string]interface{}{"inline_keyboard": rows}); err != nil {
+			log.Printf("failed to encode user keyboard: %v", err)
+			return
+		}
+	}
+
+	if messageID != 0 {
+		params.AddNonZero("message_id", messageID)
+		_, err := bot.MakeRequest("editMessageText", params)
+		if err == nil || strings.Contains(err.Error(), "message is not modified") {
+			return
+		}
+		delete(params, "message_id")
+	}
+	if _, err := bot.MakeRequest("sendMessage", params); err != nil {
+		log.Printf("failed to send message to user %d: %v", chatID, err)
+	}
+}
+
+// setUserStage запоминает, какой ввод ждёт бот от пользователя
+func setUserStage(chatID int64, stage conversationStage) {
+	setSession(chatID, &adSession{Stage: stage, LastActivity: time.Now()})
+}
+
+// handleUserMessage — личные сообщения пользователей, которые не являются менеджерами
+func handleUserMessage(bot *tgbotapi.BotAPI, managerIDs []int64, msg *tgbotapi.Message) {
+	if msg.From == nil || !msg.Chat.IsPrivate() {
+		return
+	}
+	locale := userLocale(msg.From)
+	chatID := msg.Chat.ID
+
+	if msg.IsCommand() {
+		clearSession(chatID)
+		showUserMenu(bot, chatID, 0, locale)
+		return
+	}
+
+	if session := getSession(chatID); session != nil {
+		session.LastActivity = time.Now()
+		switch session.Stage {
+		case stageAwaitUserCheck:
+			handleUserCheckInput(bot, chatID, strings.TrimSpace(msg.Text), locale)
+			return
+		case stageAwaitAdRequest:
+			handleAdRequestInput(bot, managerIDs, msg, locale)
+			return
+		}
+	}
+
+	showUserMenu(bot, chatID, 0, locale)
+}
+
+func showUserMenu(bot *tgbotapi.BotAPI, chatID int64, messageID int, locale string) {
+	rows := [][]userButton{
+		{userDataButton(i18n.T(locale, "user.button.my_ads"), "myads")},
+		{userDataButton(i18n.T(locale, "user.button.check"), "check")},
+		{userDataButton(i18n.T(locale, "user.button.request"), "request")},
+	}
+	if button, ok := openAppButton(locale); ok {
+		rows = append(rows, []userButton{button})
+	}
+	sendUserScreen(bot, chatID, messageID, i18n.T(locale, "user.menu"), "", rows)
+}
+
+// handleUserCallback обрабатывает кнопки меню пользователя
+func handleUserCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) {
+	if _, err := bot.Request(tgbotapi.NewCallback(callback.ID, "")); err != nil {
+		log.Printf("callback answer error: %v", err)
+	}
+	if callback.Message == nil || callback.From == nil || !callback.Message.Chat.IsPrivate() {
+		return
+	}
+
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	locale := userLocale(callback.From)
+	data := strings.TrimPrefix(callback.Data, userCallbackPrefix)
+
+	switch {
+	case data == "menu":
+		clearSession(chatID)
+		showUserMenu(bot, chatID, messageID, locale)
+	case data == "myads":
+		clearSession(chatID)
+		showUserAds(bot, chatID, messageID, callback.From.ID, locale)
+	case data == "check":
+		setUserStage(chatID, stageAwaitUserCheck)
+		sendUserScreen(bot, chatID, messageID, i18n.T(locale, "user.check.prompt"), "", [][]userButton{userMenuRow(locale)})
+	case data == "request":
+		startAdRequest(bot, chatID, messageID, callback.From.ID, locale)
+	case strings.HasPrefix(data, "ad_"):
+		adID, err := strconv.ParseUint(strings.TrimPrefix(data, "ad_"), 10, 64)
+		if err != nil {
+			return
+		}
+		showUserAd(bot, chatID, messageID, callback.From.ID, locale, uint(adID))
+	}
+}
+
+// userAdStatus — строка статуса объявления для владельца
+func userAdStatus(locale string, ad models.Ad) string {
+	switch {
+	case ad.Status == models.AdStatusInactive:
+		return i18n.T(locale, "user.ad.inactive")
+	case ad.Status == models.AdStatusActive && ad.ExpiresAt.After(time.Now()):
+		return i18n.T(locale, "user.ad.active", formatDateTime(locale, ad.ExpiresAt))
+	default:
+		return i18n.T(locale, "user.ad.expired", formatDate(locale, ad.ExpiresAt))
+	}
+}
+
+// showUserAds показывает объявления пользователя — те же, что отдаёт /api/myads
+func showUserAds(bot *tgbotapi.BotAPI, chatID int64, messageID int, userID int64, locale string) {
+	var ads []models.Ad
+	if err := myAdsQuery(userID).Find(&ads).Error; err != nil {
+		log.Printf("user bot: failed to load ads of %d: %v", userID, err)
+		sendUserScreen(bot, chatID, messageID, i18n.T(locale, "user.failed"), "", [][]userButton{userMenuRow(locale)})
+		return
+	}
+
+	if len(ads) == 0 {
+		sendUserScreen(bot, chatID, messageID, i18n.T(locale, "user.my_ads.empty"), "", [][]userButton{
+			{userDataButton(i18n.T(locale, "user.button.request"), "request")},
+			userMenuRow(locale),
+		})
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(i18n.T(locale, "user.my_ads.title", len(ads)) + "\n")
+	var rows [][]userButton
+	for i, ad := range ads {
+		if i >= maxUserAdsListed {
+			text.WriteString("\n" + i18n.T(locale, "user.my_ads.more", len(ads)-maxUserAdsListed))
+			break
+		}
+		text.WriteString(fmt.Sprintf("\n#%d «%s»\n%s\n", ad.ID, truncate(ad.Title, 60), userAdStatus(locale, ad)))
+		rows = append(rows, []userButton{
+			userDataButton(fmt.Sprintf("#%d %s", ad.ID, truncate(ad.Title, 30)), fmt.Sprintf("ad_%d", ad.ID)),
+		})
+	}
+	if button, ok := openAppButton(locale); ok {
+		rows = append(rows, []userButton{button})
+	}
+	rows = append(rows, userMenuRow(locale))
+	sendUserScreen(bot, chatID, messageID, text.String(), "", rows)
+}
+
+// showUserAd — карточка объявления владельца со статистикой и кнопками продления и снятия
+func showUserAd(bot *tgbotapi.BotAPI, chatID int64, messageID int, userID int64, locale string, adID uint) {
+	ad, err := loadOwnedAd(adID, userID)
+	if err != nil {
+		text := i18n.T(locale, "user.ad.not_found")
+		if !errors.Is(err, errAdNotOwned) {
+			log.Printf("user bot: failed to load ad %d: %v", adID, err)
+			text = i18n.T(locale, "user.failed")
+		}
+		sendUserScreen(bot, chatID, messageID, text, "", [][]userButton{userMenuRow(locale)})
+		return
+	}
+
+	text := fmt.Sprintf("#%d «%s»\n%s\n\n%s", ad.ID, ad.Title, adDetails(locale, *ad), userAdStatus(locale, *ad))
+	if totals, err := analytics.ForAds([]uint{ad.ID}, time.Time{}); err == nil {
+		text += "\n" + i18n.T(locale, "user.ad.stats", totals[ad.ID].Views, totals[ad.ID].Contacts)
+	} else {
+		log.Printf("analytics: failed to load totals of ad %d: %v", ad.ID, err)
+	}
+
+	var rows [][]userButton
+	if ad.Status != models.AdStatusInactive {
+		rows = userButtonsFrom(ownerActionsKeyboard(locale, ad.ID, ad.Status == models.AdStatusActive))
+	}
+	if url := miniAppAdURL(ad.ID); url != "" && ad.Status == models.AdStatusActive {
+		rows = append(rows, []userButton{{Text: i18n.T(locale, "search.button.open"), URL: url}})
+	}
+	rows = append(rows, []userButton{userDataButton(i18n.T(locale, "user.button.my_ads"), "myads")})
+	sendUserScreen(bot, chatID, messageID, text, "", rows)
+}
+
+// handleUserCheckInput проверяет username по чёрному списку, как CheckScammer в Mini App
+func handleUserCheckInput(bot *tgbotapi.BotAPI, chatID int64, text, locale string) {
+	username := ""
+	if fields := strings.Fields(text); len(fields) > 0 {
+		username = normalizeUsername(strings.TrimPrefix(fields[0], "https://t.me/"))
+	}
+	if !usernamePattern.MatchString(username) {
+		sendUserScreen(bot, chatID, 0, i18n.T(locale, "user.check.invalid"), "", [][]userButton{userMenuRow(locale)})
+		return
+	}
+
+	lookup, err := lookupUser(username, 0)
+	if err != nil {
+		log.Printf("user lookup failed for %q: %v", username, err)
+		sendUserScreen(bot, chatID, 0, i18n.T(locale, "check.failed"), "", [][]userButton{userMenuRow(locale)})
+		return
+	}
+
+	clearSession(chatID)
+	sendUserScreen(bot, chatID, 0, renderUserLookup(locale, lookup), "Markdown", [][]userButton{
+		{userDataButton(i18n.T(locale, "user.button.check"), "check")},
+		userMenuRow(locale),
+	})
+}
+
+// adRequestsToday — сколько заявок пользователь отправил за последние сутки
+func adRequestsToday(userID int64) (int64, error) {
+	var count int64
+	err := db.DB.Model(&models.AdRequest{}).
+		Where("telegram_id = ? AND created_at > ?", userID, time.Now().Add(-24*time.Hour)).
+		Count(&count).Error
+	return count, err
+}
+
+func startAdRequest(bot *tgbotapi.BotAPI, chatID int64, messageID int, userID int64, locale string) {
+	count, err := adRequestsToday(userID)
+	if err != nil {
+		log.Printf("user bot: failed to count ad requests of %d: %v", userID, err)
+		sendUserScreen(bot, chatID, messageID, i18n.T(locale, "user.failed"), "", [][]userButton{userMenuRow(locale)})
+		return
+	}
+	if count >= adRequestDailyLimit {
+		clearSession(chatID)
+		sendUserScreen(bot, chatID, messageID, i18n.T(locale, "user.request.limit", adRequestDailyLimit, managerHelpLink), "", [][]userButton{userMenuRow(locale)})
+		return
+	}
+
+	setUserStage(chatID, stageAwaitAdRequest)
+	sendUserScreen(bot, chatID, messageID, i18n.T(locale, "user.request.prompt"), "", [][]userButton{userMenuRow(locale)})
+}
+
+// handleAdRequestInput сохраняет заявку и отправляет её менеджерам
+func handleAdRequestInput(bot *tgbotapi.BotAPI, managerIDs []int64, msg *tgbotapi.Message, locale string) {
+	chatID := msg.Chat.ID
+	text := strings.TrimSpace(msg.Text)
+	if utf8.RuneCountInString(text) < minAdRequestLength {
+		sendUserScreen(bot, chatID, 0, i18n.T(locale, "user.request.too_short"), "", [][]userButton{userMenuRow(locale)})
+		return
+	}
+
+	// Лимит проверяется ещё раз: заявку можно было начать до того, как он исчерпан
+	count, err := adRequestsToday(msg.From.ID)
+	if err != nil {
+		log.Printf("user bot: failed to count ad requests of %d: %v", msg.From.ID, err)
+		sendUserScreen(bot, chatID, 0, i18n.T(locale, "user.request.failed"), "", [][]userButton{userMenuRow(locale)})
+		return
+	}
+	if count >= adRequestDailyLimit {
+		clearSession(chatID)
+		sendUserScreen(bot, chatID, 0, i18n.T(locale, "user.request.limit", adRequestDailyLimit, managerHelpLink), "", [][]userButton{userMenuRow(locale)})
+		return
+	}
+
+	request := models.AdRequest{
+		TelegramID: msg.From.ID,
+		Username:   msg.From.UserName,
+		Text:       truncate(text, maxAdRequestLength),
+		Status:     models.AdRequestNew,
+	}
+	if err := db.DB.Create(&request).Error; err != nil {
+		log.Printf("user bot: failed to save ad request of %d: %v", msg.From.ID, err)
+		sendUserScreen(bot, chatID, 0, i18n.T(locale, "user.request.failed"), "", [][]userButton{userMenuRow(locale)})
+		return
+	}
+
+	clearSession(chatID)
+	sendUserScreen(bot, chatID, 0, i18n.T(locale, "user.request.sent", request.ID), "", [][]userButton{userMenuRow(locale)})
+	notifyManagersOfAdRequest(bot, managerIDs, request)
+}
+
+// adRequestKeyboard — кнопки заявки у менеджера; после решения остаются проверка и связь
+func adRequestKeyboard(locale string, request models.AdRequest, withDecision bool) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if withDecision {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.request.button.accept"), fmt.Sprintf("%saccept_%d", adRequestCallbackPrefix, request.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.request.button.reject"), fmt.Sprintf("%sreject_%d", adRequestCallbackPrefix, request.ID)),
+		))
+	}
+	row := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "manager.request.button.check"), fmt.Sprintf("%scheck_%d", adRequestCallbackPrefix, request.ID)),
+	)
+	if request.Username != "" {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonURL(i18n.T(locale, "manager.request.button.write"), "https://t.me/"+request.Username))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(append(rows, row)...)
+}
+
+// notifyManagersOfAdRequest рассылает заявку менеджерам, каждому на его языке
+func notifyManagersOfAdRequest(bot *tgbotapi.BotAPI, managerIDs []int64, request models.AdRequest) {
+	scam, err := checkScamStatusByTelegram(request.TelegramID, request.Username)
+	if err != nil {
+		log.Printf("user bot: failed to check author of ad request %d: %v", request.ID, err)
+	}
+
+	for _, managerID := range managerIDs {
+		locale := telegramUserLocale(managerID)
+		author := i18n.T(locale, "manager.request.no_username")
+		if request.Username != "" {
+			author = "@" + request.Username
+		}
+		text := i18n.T(locale, "manager.request.title", request.ID, author, request.TelegramID)
+		if err == nil && (!scam.Safe() || scam.Warning) {
+			text += "⚠️ " + scam.Message(locale) + "\n"
+		}
+		text += "\n" + request.Text
+
+		msg := tgbotapi.NewMessage(managerID, text)
+		msg.ReplyMarkup = adRequestKeyboard(locale, request, true)
+		if err := enqueueMessage(bot, msg); err != nil {
+			log.Printf("failed to notify manager %d of ad request %d: %v", managerID, request.ID, err)
+		}
+	}
+}
+
+// handleAdRequestCallback — решение менеджера по заявке. Заявку обрабатывает тот, кто
+// нажал первым; пользователь получает ответ на своём языке.
+func handleAdRequestCallback(bot *tgbotapi.BotAPI, managerIDs []int64, callback *tgbotapi.CallbackQuery) {
+	if callback.From == nil || !isManager(callback.From.ID, managerIDs) {
+		return
+	}
+	if _, err := bot.Request(tgbotapi.NewCallback(callback.ID, "")); err != nil {
+		log.Printf("callback answer error: %v", err)
+	}
+	if callback.Message == nil {
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(callback.Data, adRequestCallbackPrefix), "_")
+	if len(parts) != 2 {
+		return
+	}
+	requestID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return
+	}
+	chatID := callback.Message.Chat.ID
+	managerLocale := userLocale(callback.From)
+
+	var request models.AdRequest
+	if err := db.DB.First(&request, requestID).Error; err != nil {
+		log.Printf("failed to load ad request %d: %v", requestID, err)
+		notifyUser(bot, chatID, i18n.T(managerLocale, "manager.request.not_found"))
+		return
+	}
+
+	var status, labelKey, userKey string
+	switch parts[0] {
+	case "check":
+		showForwardedUserLookup(bot, chatID, request.TelegramID, request.Username)
+		return
+	case "accept":
+		status, labelKey, userKey = models.AdRequestAccepted, "manager.request.accepted", "user.request.accepted"
+	case "reject":
+		status, labelKey, userKey = models.AdRequestRejected, "manager.request.rejected", "user.request.rejected"
+	default:
+		return
+	}
+
+	now := time.Now()
+	result := db.DB.Model(&models.AdRequest{}).
+		Where("id = ? AND status = ?", request.ID, models.AdRequestNew).
+		Updates(map[string]interface{}{"status": status, "handled_by": callback.From.ID, "handled_at": now})
+	if result.Error != nil {
+		log.Printf("failed to update ad request %d: %v", request.ID, result.Error)
+		notifyUser(bot, chatID, i18n.T(managerLocale, "manager.request.save_failed"))
+		return
+	}
+	if result.RowsAffected == 0 {
+		notifyUser(bot, chatID, i18n.T(managerLocale, "manager.request.handled", request.ID))
+		return
+	}
+	recordManagerAction(callback.From.ID, models.ManagerActionAdRequest, 0, request.Username)
+
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, callback.Message.MessageID,
+		fmt.Sprintf("%s\n\n%s (%s)", callback.Message.Text, i18n.T(managerLocale, labelKey), formatDateTime(managerLocale, now)),
+		adRequestKeyboard(managerLocale, request, false))
+	if _, err := sendReply(bot, edit); err != nil {
+		log.Printf("failed to update ad request message: %v", err)
+	}
+
+	locale := telegramUserLocale(request.TelegramID)
+	if status == models.AdRequestAccepted {
+		notifyUser(bot, request.TelegramID, i18n.T(locale, userKey, request.ID))
+	} else {
+		notifyUser(bot, request.TelegramID, i18n.T(locale, userKey, request.ID, managerHelpLink))
+	}
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultBumpCooldown — как часто владелец может поднимать одно объявление
+const defaultBumpCooldown = 24 * time.Hour
+
+// feedOrder — порядок ленты: сначала премиум, затем по времени последнего поднятия
+const feedOrder = "is_premium DESC, COALESCE(bumped_at, created_at) DESC"
+
+var errAdNotBumpable = i18n.NewError("error.ad_not_bumpable")
+
+// bumpCooldownError — объявление поднимали недавно, следующее поднятие доступно в NextAt
+type bumpCooldownError struct {
+	NextAt time.Time
+}
+
+func (e bumpCooldownError) Error() string {
+	return e.Localize(i18n.Default())
+}
+
+func (e bumpCooldownError) Localize(locale string) string {
+	return i18n.T(locale, "error.bump_cooldown", e.NextAt.Format(i18n.T(locale, "format.datetime")))
+}
+
+// bumpCooldown читает BUMP_COOLDOWN (например 12h), по умолчанию 24 часа
+func bumpCooldown() time.Duration {
+	if raw := strings.TrimSpace(os.Getenv("BUMP_COOLDOWN")); raw != "" {
+		if cooldown, err := time.ParseDuration(raw); err == nil && cooldown >= 0 {
+			return cooldown
+		}
+		log.Printf("bump: invalid BUMP_COOLDOWN=%q, using default %s", raw, defaultBumpCooldown)
+	}
+	return defaultBumpCooldown
+}
+
+// nextBumpAt возвращает момент, с которого владелец снова может поднять объявление
+// (новое объявление и так наверху ленты, поэтому отсчёт идёт от создания)
+func nextBumpAt(ad models.Ad) time.Time {
+	last := ad.CreatedAt
+	if ad.BumpedAt != nil {
+		last = *ad.BumpedAt
+	}
+	return last.Add(bumpCooldown())
+}
+
+// bumpAd поднимает объявление в ленте и записывает это в историю. Для владельца
+// действует BUMP_COOLDOWN, менеджер может поднимать без ограничений.
+func bumpAd(adID uint, bumpedBy int64, source string) (models.Ad, error) {
+	var ad models.Ad
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ad, adID).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if ad.Status != models.AdStatusActive || !ad.ExpiresAt.After(now) {
+			return errAdNotBumpable
+		}
+		if source == models.BumpSourceOwner {
+			if next := nextBumpAt(ad); next.After(now) {
+				return bumpCooldownError{NextAt: next}
+			}
+		}
+
+		// UpdateColumn не трогает updated_at: поднятие не считается изменением объявления
+		if err := tx.Model(&ad).UpdateColumn("bumped_at", now).Error; err != nil {
+			return err
+		}
+		ad.BumpedAt = &now
+
+		return tx.Create(&models.AdBump{AdID: ad.ID, BumpedBy: bumpedBy, Source: source}).Error
+	})
+	return ad, err
+}
+
+// BumpAd поднимает объявление текущего пользователя в ленте (POST /api/ads/:id/bump)
+func BumpAd(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		respondError(c, http.StatusUnauthorized, "error.auth_required")
+		return
+	}
+
+	adID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "error.invalid_ad_id")
+		return
+	}
+
+	if _, err := loadOwnedAd(uint(adID), userID); err != nil {
+		if errors.Is(err, errAdNotOwned) {
+			respondError(c, http.StatusNotFound, "error.ad_not_found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "error.ads_unavailable")
+		return
+	}
+
+	ad, err := bumpAd(uint(adID), userID, models.BumpSourceOwner)
+	var cooldown bumpCooldownError
+	switch {
+	case errors.As(err, &cooldown):
+		respondLocalizedError(c, http.StatusTooManyRequests, cooldown, "error.bump_cooldown", gin.H{"next_bump_at": cooldown.NextAt})
+		return
+	case errors.Is(err, errAdNotBumpable):
+		respondLocalizedError(c, http.StatusConflict, err, "error.bump_failed")
+		return
+	case err != nil:
+		log.Printf("bump: failed to bump ad %d: %v", adID, err)
+		respondError(c, http.StatusInternalServerError, "error.bump_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"bumped_at":    ad.BumpedAt,
+		"next_bump_at": nextBumpAt(ad),
+	})
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Лимиты Telegram на длину подписи к фото и текста сообщения
+const (
+	channelCaptionLimit = 1024
+	channelTextLimit    = 4096
+)
+
+// channelForCategory возвращает ID канала для категории объявления:
+// CHANNEL_ID_<КАТЕГОРИЯ> (например CHANNEL_ID_SERVICES), иначе общий CHANNEL_ID.
+// 0 — публикация в канал отключена.
+func channelForCategory(category string) int64 {
+	keys := []string{"CHANNEL_ID"}
+	if category != "" {
+		keys = append([]string{"CHANNEL_ID_" + strings.ToUpper(category)}, keys...)
+	}
+
+	for _, key := range keys {
+		raw := strings.TrimSpace(os.Getenv(key))
+		if raw == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			log.Printf("channel publisher: invalid %s=%q", key, raw)
+			continue
+		}
+		return id
+	}
+	return 0
+}
+
+// miniAppAdURL — ссылка на объявление в Mini App (MINI_APP_URL, например https://t.me/bot/app)
+func miniAppAdURL(adID uint) string {
+	base := strings.TrimSpace(os.Getenv("MINI_APP_URL"))
+	if base == "" {
+		return ""
+	}
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%sstartapp=ad_%d", base, separator, adID)
+}
+
+// publishAdToChannel публикует объявление в канал. repost=true удаляет старый пост и публикует
+// новый (при создании и продлении), иначе существующий пост редактируется.
+func publishAdToChannel(bot *tgbotapi.BotAPI, ad *models.Ad, repost bool) {
+	if bot == nil || ad.ID == 0 {
+		return
+	}
+
+	if ad.Status != models.AdStatusActive || !ad.ExpiresAt.After(time.Now()) {
+		removeAdFromChannel(bot, ad)
+		return
+	}
+
+	target := channelForCategory(ad.Category)
+	if target == 0 {
+		return
+	}
+
+	// Смена канала или фото не редактируется — публикуем заново
+	if ad.ChannelMessageID != 0 && (ad.ChannelChatID != target || ad.ChannelPhotoID != ad.PhotoID) {
+		repost = true
+	}
+
+	if ad.ChannelMessageID != 0 && !repost {
+		if err := editChannelPost(bot, ad); err != nil {
+			log.Printf("channel publisher: failed to edit post for ad %d: %v", ad.ID, err)
+		}
+		return
+	}
+
+	removeAdFromChannel(bot, ad)
+
+	var chattable tgbotapi.Chattable
+	if ad.PhotoID != "" {
+		photo := tgbotapi.NewPhoto(target, tgbotapi.FileID(ad.PhotoID))
+		photo.Caption = renderChannelPost(*ad, channelCaptionLimit)
+		photo.ParseMode = "Markdown"
+		if keyboard, ok := channelPostKeyboard(ad.ID); ok {
+			photo.ReplyMarkup = keyboard
+		}
+		chattable = photo
+	} else {
+		msg := tgbotapi.NewMessage(target, renderChannelPost(*ad, channelTextLimit))
+		msg.ParseMode = "Markdown"
+		msg.DisableWebPagePreview = true
+		if keyboard, ok := channelPostKeyboard(ad.ID); ok {
+			msg.ReplyMarkup = keyboard
+		}
+		chattable = msg
+	}
+
+	// Новый пост отправляется сразу, а не через очередь: его ID нужен, чтобы потом
+	// править и удалять пост. Правки и удаление идут через очередь.
+	sent, err := bot.Send(chattable)
+	if err != nil {
+		log.Printf("channel publisher: failed to post ad %d to %d: %v", ad.ID, target, err)
+		return
+	}
+
+	ad.ChannelChatID = target
+	ad.ChannelMessageID = sent.MessageID
+	ad.ChannelPhotoID = ad.PhotoID
+	saveChannelPost(ad)
+}
+
+func editChannelPost(bot *tgbotapi.BotAPI, ad *models.Ad) error {
+	keyboard, hasKeyboard := channelPostKeyboard(ad.ID)
+
+	var chattable tgbotapi.Chattable
+	if ad.ChannelPhotoID != "" {
+		edit := tgbotapi.NewEditMessageCaption(ad.ChannelChatID, ad.ChannelMessageID, renderChannelPost(*ad, channelCaptionLimit))
+		edit.ParseMode = "Markdown"
+		if hasKeyboard {
+			edit.ReplyMarkup = &keyboard
+		}
+		chattable = edit
+	} else {
+		edit := tgbotapi.NewEditMessageText(ad.ChannelChatID, ad.ChannelMessageID, renderChannelPost(*ad, channelTextLimit))
+		edit.ParseMode = "Markdown"
+		edit.DisableWebPagePreview = true
+		if hasKeyboard {
+			edit.ReplyMarkup = &keyboard
+		}
+		chattable = edit
+	}
+
+	err := enqueueMessage(bot, chattable)
+	if err != nil && strings.Contains(err.Error(), "message is not modified") {
+		return nil
+	}
+	return err
+}
+
+// removeAdFromChannel удаляет пост объявления из канала, если он есть
+func removeAdFromChannel(bot *tgbotapi.BotAPI, ad *models.Ad) {
+	if bot == nil || ad.ChannelMessageID == 0 {
+		return
+	}
+
+	if err := enqueueMessage(bot, tgbotapi.NewDeleteMessage(ad.ChannelChatID, ad.ChannelMessageID)); err != nil {
+		log.Printf("channel publisher: failed to delete post %d for ad %d: %v", ad.ChannelMessageID, ad.ID, err)
+	}
+
+	ad.ChannelChatID = 0
+	ad.ChannelMessageID = 0
+	ad.ChannelPhotoID = ""
+	saveChannelPost(ad)
+}
+
+func saveChannelPost(ad *models.Ad) {
+	// UpdateColumns не трогает updated_at, чтобы пост не влиял на порядок в ленте
+	if err := db.DB.Model(&models.Ad{}).Where("id = ?", ad.ID).UpdateColumns(map[string]interface{}{
+		"channel_chat_id":    ad.ChannelChatID,
+		"channel_message_id": ad.ChannelMessageID,
+		"channel_photo_id":   ad.ChannelPhotoID,
+	}).Error; err != nil {
+		log.Printf("channel publisher: failed to save post state for ad %d: %v", ad.ID, err)
+	}
+}
+
+func channelPostKeyboard(adID uint) (tgbotapi.InlineKeyboardMarkup, bool) {
+	url := miniAppAdURL(adID)
+	if url == "" {
+		return tgbotapi.InlineKeyboardMarkup{}, false
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL(i18n.T(i18n.Default(), "channel.button.open"), url),
+		),
+	), true
+}
+
+// renderChannelPost формирует текст поста (Markdown), укладываясь в limit символов.
+// Канал общий для всех читателей, поэтому пост пишется на языке по умолчанию (DEFAULT_LOCALE).
+func renderChannelPost(ad models.Ad, limit int) string {
+	locale := i18n.Default()
+	var header strings.Builder
+	if ad.IsPremium {
+		header.WriteString(i18n.T(locale, "channel.premium") + "\n")
+	}
+	header.WriteString(fmt.Sprintf("*%s*\n\n", escapeMarkdown(ad.Title)))
+
+	labels := []string{categoryName(ad.Category)}
+	if _, single := singleMode(ad.Category); !single {
+		labels = append(labels, modeName(ad.Category, ad.Mode))
+	}
+	labels = append(labels, tagName(ad.Category, ad.Tag))
+
+	var footer strings.Builder
+	footer.WriteString("\n\n📂 " + strings.Join(nonEmpty(labels), " · "))
+	if ad.Price > 0 {
+		footer.WriteString("\n💰 " + formatPrice(locale, ad.Price))
+	}
+	if ad.Username != "" {
+		footer.WriteString("\n👤 @" + escapeMarkdown(ad.Username))
+	}
+	footer.WriteString("\n" + i18n.T(locale, "channel.expires", formatDate(locale, ad.ExpiresAt)))
+
+	desc := escapeMarkdown(ad.Desc)
+	room := limit - len([]rune(header.String())) - len([]rune(footer.String()))
+	if room < 0 {
+		room = 0
+	}
+	if len([]rune(desc)) > room {
+		desc = strings.TrimRight(truncate(desc, max(room-1, 0)), "\\") + "…"
+	}
+
+	return header.String() + desc + footer.String()
+}
+
+func nonEmpty(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" {
+			out = append(out, value)
+		}
+	}
+	return out
+}
@@ -0,0 +1,352 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	"github.com/gin-gonic/gin"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// maxFavorites — сколько объявлений пользователь может держать в избранном
+	maxFavorites = 200
+	// favoriteCallbackPrefix — кнопка «Не уведомлять» в уведомлении: fav_mute_<ID объявления>;
+	// доступна всем пользователям
+	favoriteCallbackPrefix = "fav_"
+)
+
+// favoriteLockClass — первый ключ advisory-блокировки избранного пользователя (см. lockUserLimit)
+const favoriteLockClass int32 = 0x66617673 // "favs"
+
+var errFavoriteLimit = i18n.NewError("error.favorite_limit")
+
+// buildAdViews собирает AdView со счётчиком избранного. userID — текущий пользователь
+// Mini App, для него отмечаются объявления в избранном (0 — не отмечать).
+func buildAdViews(ads []models.Ad, userID int64) []AdView {
+	views := make([]AdView, 0, len(ads))
+	if len(ads) == 0 {
+		return views
+	}
+
+	adIDs := make([]uint, 0, len(ads))
+	for _, ad := range ads {
+		adIDs = append(adIDs, ad.ID)
+	}
+
+	var counts []struct {
+		AdID  uint
+		Count int64
+	}
+	if err := db.DB.Model(&models.Favorite{}).
+		Select("ad_id, COUNT(*) AS count").
+		Where("ad_id IN ?", adIDs).
+		Group("ad_id").Scan(&counts).Error; err != nil {
+		log.Printf("favorites: failed to count favorites: %v", err)
+	}
+	countByAd := make(map[uint]int64, len(counts))
+	for _, row := range counts {
+		countByAd[row.AdID] = row.Count
+	}
+
+	mine := make(map[uint]bool)
+	if userID != 0 {
+		var favoriteIDs []uint
+		if err := db.DB.Model(&models.Favorite{}).
+			Where("user_id = ? AND ad_id IN ?", userID, adIDs).
+			Pluck("ad_id", &favoriteIDs).Error; err != nil {
+			log.Printf("favorites: failed to load favorites of user %d: %v", userID, err)
+		}
+		for _, adID := range favoriteIDs {
+			mine[adID] = true
+		}
+	}
+
+	for _, ad := range ads {
+		view := buildAdView(ad)
+		view.Favorites = countByAd[ad.ID]
+		view.IsFavorite = mine[ad.ID]
+		views = append(views, view)
+	}
+	return views
+}
+
+func favoriteCount(adID uint) (int64, error) {
+	var count int64
+	err := db.DB.Model(&models.Favorite{}).Where("ad_id = ?", adID).Count(&count).Error
+	return count, err
+}
+
+// GetFavorites возвращает избранные объявления текущего пользователя, новые сверху (GET /api/favorites)
+func GetFavorites(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		respondError(c, http.StatusUnauthorized, "error.auth_required")
+		return
+	}
+
+	var ads []models.Ad
+	if err := db.DB.
+		Joins("JOIN favorites ON favorites.ad_id = ads.id").
+		Where("favorites.user_id = ?", userID).
+		Order("favorites.created_at DESC").
+		Find(&ads).Error; err != nil {
+		respondError(c, http.StatusInternalServerError, "error.favorites_unavailable")
+		return
+	}
+
+	now := time.Now()
+	for i := range ads {
+		if ads[i].Status == models.AdStatusActive && ads[i].ExpiresAt.Before(now) {
+			ads[i].Status = models.AdStatusExpired
+		}
+	}
+
+	c.JSON(http.StatusOK, buildAdViews(ads, userID))
+}
+
+type favoriteRequest struct {
+	// Notify — присылать уведомления об объявлении (по умолчанию да)
+	Notify *bool `json:"notify"`
+}
+
+// AddFavorite добавляет объявление в избранное или меняет настройку уведомлений
+// (POST /api/favorites/:adId)
+func AddFavorite(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		respondError(c, http.StatusUnauthorized, "error.auth_required")
+		return
+	}
+
+	adID, err := strconv.ParseUint(c.Param("adId"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "error.invalid_ad_id")
+		return
+	}
+
+	// Тело необязательно: пустой запрос добавляет объявление с уведомлениями
+	var req favoriteRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, "error.favorite_invalid")
+			return
+		}
+	}
+	notify := req.Notify == nil || *req.Notify
+
+	var ad models.Ad
+	if err := db.DB.Select("id").First(&ad, adID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(c, http.StatusNotFound, "error.ad_not_found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "error.favorites_unavailable")
+		return
+	}
+
+	err = db.DB.Transaction(func(tx *gorm.DB) error {
+		// Лимит проверяется под блокировкой пользователя, иначе параллельные запросы превысят maxFavorites
+		if err := lockUserLimit(tx, favoriteLockClass, userID); err != nil {
+			return err
+		}
+		var exists, total int64
+		if err := tx.Model(&models.Favorite{}).Where("user_id = ? AND ad_id = ?", userID, ad.ID).Count(&exists).Error; err != nil {
+			return err
+		}
+		if exists == 0 {
+			if err := tx.Model(&models.Favorite{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+				return err
+			}
+			if total >= maxFavorites {
+				return errFavoriteLimit
+			}
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "ad_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"notify"}),
+		}).Create(&models.Favorite{UserID: userID, AdID: ad.ID, Notify: notify}).Error
+	})
+	switch {
+	case errors.Is(err, errFavoriteLimit):
+		respondLocalizedError(c, http.StatusConflict, err, "error.favorite_limit", gin.H{"limit": maxFavorites})
+		return
+	case err != nil:
+		log.Printf("favorites: failed to add ad %d for user %d: %v", ad.ID, userID, err)
+		respondError(c, http.StatusInternalServerError, "error.favorites_unavailable")
+		return
+	}
+
+	count, err := favoriteCount(ad.ID)
+	if err != nil {
+		log.Printf("favorites: failed to count favorites of ad %d: %v", ad.ID, err)
+	}
+	c.JSON(http.StatusOK, gin.H{"ad_id": ad.ID, "is_favorite": true, "notify": notify, "favorites": count})
+}
+
+// RemoveFavorite убирает объявление из избранного (DELETE /api/favorites/:adId)
+func RemoveFavorite(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		respondError(c, http.StatusUnauthorized, "error.auth_required")
+		return
+	}
+
+	adID, err := strconv.ParseUint(c.Param("adId"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "error.invalid_ad_id")
+		return
+	}
+
+	if err := db.DB.Where("user_id = ? AND ad_id = ?", userID, adID).Delete(&models.Favorite{}).Error; err != nil {
+		log.Printf("favorites: failed to remove ad %d for user %d: %v", adID, userID, err)
+		respondError(c, http.StatusInternalServerError, "error.favorites_unavailable")
+		return
+	}
+
+	count, err := favoriteCount(uint(adID))
+	if err != nil {
+		log.Printf("favorites: failed to count favorites of ad %d: %v", adID, err)
+	}
+	c.JSON(http.StatusOK, gin.H{"ad_id": adID, "is_favorite": false, "favorites": count})
+}
+
+// notifyFavorites рассылает уведомление всем, кто добавил объявление в избранное и не
+// отключил уведомления. Владелец объявления уведомление не получает.
+func notifyFavorites(bot *tgbotapi.BotAPI, ad models.Ad, render func(locale string) string) {
+	var favorites []models.Favorite
+	if err := db.DB.Where("ad_id = ? AND notify = ?", ad.ID, true).Find(&favorites).Error; err != nil {
+		log.Printf("favorites: failed to load subscribers of ad %d: %v", ad.ID, err)
+		return
+	}
+
+	ownerID := ownerTelegramID(ad)
+	for _, favorite := range favorites {
+		if favorite.UserID == ownerID {
+			continue
+		}
+		sendFavoriteNotification(bot, favorite.UserID, ad.ID, render)
+	}
+}
+
+func sendFavoriteNotification(bot *tgbotapi.BotAPI, userID int64, adID uint, render func(locale string) string) bool {
+	locale := telegramUserLocale(userID)
+	msg := tgbotapi.NewMessage(userID, render(locale))
+	msg.ReplyMarkup = favoriteNotificationKeyboard(locale, adID, true)
+	if err := enqueueMessage(bot, msg); err != nil {
+		log.Printf("favorites: failed to notify user %d about ad %d: %v", userID, adID, err)
+		return false
+	}
+	return true
+}
+
+// favoriteNotificationKeyboard — ссылка на объявление в Mini App и кнопка отключения уведомлений
+func favoriteNotificationKeyboard(locale string, adID uint, withMute bool) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if url := miniAppAdURL(adID); url != "" {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL(i18n.T(locale, "search.button.open"), url),
+		))
+	}
+	if withMute {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, "favorite.button.mute"), fmt.Sprintf("%smute_%d", favoriteCallbackPrefix, adID)),
+		))
+	}
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// notifyFavoritesRenewed сообщает о продлении объявления из избранного
+func notifyFavoritesRenewed(bot *tgbotapi.BotAPI, ad models.Ad) {
+	notifyFavorites(bot, ad, func(locale string) string {
+		return i18n.T(locale, "favorite.renewed", ad.Title, formatDate(locale, ad.ExpiresAt))
+	})
+}
+
+// notifyFavoritesPriceChanged сообщает о новой цене объявления из избранного
+func notifyFavoritesPriceChanged(bot *tgbotapi.BotAPI, ad models.Ad, previousPrice int64) {
+	if ad.Price == previousPrice || ad.Status != models.AdStatusActive {
+		return
+	}
+	notifyFavorites(bot, ad, func(locale string) string {
+		return i18n.T(locale, "favorite.price_changed", ad.Title, formatPrice(locale, previousPrice), formatPrice(locale, ad.Price))
+	})
+}
+
+// notifyFavoritesExpiring предупреждает о скором окончании срока объявлений из избранного.
+// О каждом сроке объявления пользователь получает одно предупреждение — на первом этапе
+// EXPIRY_REMINDERS; после продления предупреждение придёт снова.
+func notifyFavoritesExpiring(bot *tgbotapi.BotAPI, ads []models.Ad) {
+	for _, ad := range ads {
+		if autoRenewActive(ad, ad.ExpiresAt) {
+			continue
+		}
+
+		var favorites []models.Favorite
+		if err := db.DB.
+			Where("ad_id = ? AND notify = ? AND (expiry_notified_for IS NULL OR expiry_notified_for <> ?)", ad.ID, true, ad.ExpiresAt).
+			Find(&favorites).Error; err != nil {
+			log.Printf("favorites: failed to load subscribers of ad %d: %v", ad.ID, err)
+			continue
+		}
+
+		ownerID := ownerTelegramID(ad)
+		for _, favorite := range favorites {
+			if favorite.UserID != ownerID {
+				sendFavoriteNotification(bot, favorite.UserID, ad.ID, func(locale string) string {
+					return i18n.T(locale, "favorite.expiring", ad.Title, formatDateTime(locale, ad.ExpiresAt))
+				})
+			}
+			// Отмечаем и неудачную отправку, чтобы не повторять её при каждом запуске
+			if err := db.DB.Model(&favorite).Update("expiry_notified_for", ad.ExpiresAt).Error; err != nil {
+				log.Printf("favorites: failed to mark expiry notice for favorite %d: %v", favorite.ID, err)
+			}
+		}
+	}
+}
+
+// handleFavoriteCallback отключает уведомления по кнопке: fav_mute_<ID объявления>
+func handleFavoriteCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) {
+	if _, err := bot.Request(tgbotapi.NewCallback(callback.ID, "")); err != nil {
+		log.Printf("callback answer error: %v", err)
+	}
+	if callback.Message == nil || callback.From == nil {
+		return
+	}
+
+	action, rawID, found := strings.Cut(strings.TrimPrefix(callback.Data, favoriteCallbackPrefix), "_")
+	if !found || action != "mute" {
+		return
+	}
+	adID, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		return
+	}
+
+	chatID := callback.Message.Chat.ID
+	locale := userLocale(callback.From)
+	if err := db.DB.Model(&models.Favorite{}).
+		Where("user_id = ? AND ad_id = ?", callback.From.ID, adID).
+		Update("notify", false).Error; err != nil {
+		log.Printf("favorites: failed to mute ad %d for user %d: %v", adID, callback.From.ID, err)
+		notifyUser(bot, chatID, i18n.T(locale, "favorite.mute_failed"))
+		return
+	}
+
+	edit := tgbotapi.NewEditMessageReplyMarkup(chatID, callback.Message.MessageID, favoriteNotificationKeyboard(locale, uint(adID), false))
+	if _, err := sendReply(bot, edit); err != nil {
+		log.Printf("favorites: failed to update notification keyboard: %v", err)
+	}
+	notifyUser(bot, chatID, i18n.T(locale, "favorite.muted"))
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/federation"
+	"youtube-market/internal/middleware"
+	"youtube-market/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	federationPageSize = 500
+	// FederationBlacklistPath — путь фида; общий лимит по IP к нему не применяется,
+	// партнёр ограничивается federationRequestsPerMinute
+	FederationBlacklistPath = "/api/federation/blacklist"
+	// federationRequestsPerMinute — запросов фида в минуту от одного партнёра; с запасом
+	// покрывает полную синхронизацию (до 100 страниц)
+	federationRequestsPerMinute = 300
+)
+
+// GetFederationBlacklist отдаёт партнёрам подписанный фид изменений чёрного списка.
+// Фид доступен только партнёрам из FEDERATION_PEERS: запрос должен быть подписан их ключом.
+// Параметр since — токен next из предыдущей страницы; без него фид отдаётся с начала.
+func GetFederationBlacklist(c *gin.Context) {
+	cfg := federation.CurrentConfig()
+	if cfg.PrivateKey == nil {
+		respondError(c, http.StatusNotFound, "error.federation_disabled")
+		return
+	}
+
+	peer, err := federation.Authenticate(c.Request, time.Now())
+	if err != nil {
+		log.Printf("federation: rejected feed request from %q: %v", c.Request.Header.Get(federation.PeerHeader), err)
+		respondError(c, http.StatusUnauthorized, "error.federation_auth_required")
+		return
+	}
+	if !middleware.Allow("ratelimit:federation:"+peer.Name, federationRequestsPerMinute) {
+		respondError(c, http.StatusTooManyRequests, "error.rate_limited")
+		return
+	}
+
+	cursor, err := federation.DecodeCursor(c.Query("since"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "error.federation_cursor_invalid")
+		return
+	}
+
+	// Удалённые и исключённые из списка пользователи попадают в фид с removed=true,
+	// поэтому выбираем всех, кто когда-либо был в чёрном списке
+	query := db.DB.Unscoped().
+		Where("is_scammer = ? OR blacklisted_at IS NOT NULL", true)
+	if !cursor.UpdatedAt.IsZero() {
+		query = query.Where("updated_at > ? OR (updated_at = ? AND id > ?)", cursor.UpdatedAt, cursor.UpdatedAt, cursor.ID)
+	}
+
+	var users []models.User
+	if err := query.
+		Order("updated_at ASC, id ASC").
+		Limit(federationPageSize + 1).
+		Find(&users).Error; err != nil {
+		respondError(c, http.StatusInternalServerError, "error.blacklist_unavailable")
+		return
+	}
+
+	feed := federation.Feed{
+		Source:  cfg.Source,
+		Entries: make([]federation.Entry, 0, len(users)),
+		Next:    c.Query("since"),
+	}
+	if len(users) > federationPageSize {
+		users = users[:federationPageSize]
+		feed.HasMore = true
+	}
+
+	for _, user := range users {
+		feed.Entries = append(feed.Entries, federation.Entry{
+			Username:  user.Username,
+			Reason:    user.ScamReason,
+			AddedAt:   user.BlacklistedAt,
+			Removed:   !user.IsScammer || user.DeletedAt.Valid,
+			UpdatedAt: user.UpdatedAt,
+		})
+	}
+	if len(users) > 0 {
+		last := users[len(users)-1]
+		feed.Next = federation.EncodeCursor(federation.Cursor{UpdatedAt: last.UpdatedAt, ID: last.ID})
+	}
+
+	body, err := json.Marshal(feed)
+	if err != nil {
+		log.Printf("federation: failed to render feed: %v", err)
+		respondError(c, http.StatusInternalServerError, "error.blacklist_unavailable")
+		return
+	}
+
+	log.Printf("federation: %s fetched %d entries", peer.Name, len(feed.Entries))
+
+	timestamp, signature := federation.SignFeed(cfg.PrivateKey, body, time.Now())
+	c.Header(federation.TimestampHeader, timestamp)
+	c.Header(federation.SignatureHeader, signature)
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
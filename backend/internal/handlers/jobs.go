@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"youtube-market/internal/analytics"
+	"youtube-market/internal/db"
+	"youtube-market/internal/models"
+	"youtube-market/internal/outbox"
+	"youtube-market/internal/scheduler"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// schedulerLockKey — ключ advisory-блокировки лидера планировщика
+	schedulerLockKey int64 = 0x79745f6a6f6273 // "yt_jobs"
+	// maxJobWait — как долго задача, управляемая данными, может спать без перепроверки
+	// (новые и продлённые объявления подхватываются не позже этого)
+	maxJobWait = 5 * time.Minute
+	// minJobWait защищает от холостого цикла, если обработать запись не удаётся
+	minJobWait = 5 * time.Second
+	// jobRunsRetention — сколько хранить историю запусков
+	jobRunsRetention = 30 * 24 * time.Hour
+	// outboxSentRetention и outboxDeadRetention — сколько хранить отправленные
+	// сообщения очереди и dead letter
+	outboxSentRetention = 3 * 24 * time.Hour
+	outboxDeadRetention = 30 * 24 * time.Hour
+)
+
+// newAdScheduler собирает задачи бота. Истечение, напоминания и очередь премиума
+// запускаются к ближайшему сроку из БД, а не по фиксированному тику.
+func newAdScheduler(bot *tgbotapi.BotAPI) *scheduler.Scheduler {
+	var leader scheduler.Leader
+	if sqlDB, err := db.DB.DB(); err == nil {
+		leader = scheduler.NewPostgresLeader(sqlDB, schedulerLockKey)
+	} else {
+		log.Printf("scheduler: leader lock unavailable, running jobs locally: %v", err)
+	}
+
+	s := scheduler.New(leader)
+	s.Add(scheduler.Job{
+		Name:     "ad_expiry",
+		Schedule: scheduler.ScheduleFunc(nextExpiryRun),
+		Run: func(ctx context.Context) error {
+			if err := processExpired(bot); err != nil {
+				return err
+			}
+			// Истёкший премиум освобождает место для брони из очереди
+			processPremiumQueue(bot)
+			return nil
+		},
+	})
+	s.Add(scheduler.Job{
+		Name:     "ad_pre_expiry_reminders",
+		Schedule: scheduler.ScheduleFunc(nextReminderRun),
+		Run: func(ctx context.Context) error {
+			return processPreExpiry(bot)
+		},
+	})
+	s.Add(scheduler.Job{
+		Name:     "premium_queue",
+		Schedule: scheduler.ScheduleFunc(nextPremiumQueueRun),
+		Run: func(ctx context.Context) error {
+			processPremiumQueue(bot)
+			return nil
+		},
+	})
+	s.Add(scheduler.Job{
+		Name:     "ad_stats_flush",
+		Schedule: scheduler.Every(time.Minute),
+		Run: func(ctx context.Context) error {
+			return analytics.Flush(ctx)
+		},
+	})
+	s.Add(scheduler.Job{
+		Name:     "job_runs_cleanup",
+		Schedule: scheduler.MustParse("0 4 * * *"),
+		Run: func(ctx context.Context) error {
+			return scheduler.PruneRuns(ctx, jobRunsRetention)
+		},
+	})
+	s.Add(scheduler.Job{
+		Name:     "outbox_cleanup",
+		Schedule: scheduler.MustParse("30 4 * * *"),
+		Run: func(ctx context.Context) error {
+			return outbox.Prune(ctx, outboxSentRetention, outboxDeadRetention)
+		},
+	})
+	s.Add(scheduler.Job{
+		Name:          "memory_cleanup",
+		Schedule:      scheduler.Every(30 * time.Minute),
+		EveryInstance: true,
+		Run: func(ctx context.Context) error {
+			persistSessionsCleanup()
+			guardCacheCleanup()
+			return nil
+		},
+	})
+	return s
+}
+
+// nextExpiryRun — ближайший срок окончания активного объявления или оплаченного премиума
+func nextExpiryRun(now time.Time) time.Time {
+	var adExpiry, premiumExpiry sql.NullTime
+	if err := db.DB.Model(&models.Ad{}).
+		Where("status = ?", models.AdStatusActive).
+		Select("MIN(expires_at)").Scan(&adExpiry).Error; err != nil {
+		log.Printf("scheduler: failed to find next expiry: %v", err)
+	}
+	if err := db.DB.Model(&models.Ad{}).
+		Where("is_premium = ? AND premium_until IS NOT NULL", true).
+		Select("MIN(premium_until)").Scan(&premiumExpiry).Error; err != nil {
+		log.Printf("scheduler: failed to find next premium expiry: %v", err)
+	}
+	return clampJobRun(now, adExpiry, premiumExpiry)
+}
+
+// nextReminderRun — ближайший момент, когда активное объявление входит в очередной этап напоминаний
+func nextReminderRun(now time.Time) time.Time {
+	var candidates []sql.NullTime
+	for _, stage := range reminderStages() {
+		var expiresAt sql.NullTime
+		if err := db.DB.Model(&models.Ad{}).
+			Where("status = ? AND expires_at > ?", models.AdStatusActive, now.Add(stage)).
+			Select("MIN(expires_at)").Scan(&expiresAt).Error; err != nil {
+			log.Printf("scheduler: failed to find next reminder: %v", err)
+			continue
+		}
+		if expiresAt.Valid {
+			expiresAt.Time = expiresAt.Time.Add(-stage)
+		}
+		candidates = append(candidates, expiresAt)
+	}
+	return clampJobRun(now, candidates...)
+}
+
+// nextPremiumQueueRun — ближайшее начало брони. Брони, ждущие свободного места,
+// обрабатывает ad_expiry при освобождении места, поэтому здесь учитываются только будущие.
+func nextPremiumQueueRun(now time.Time) time.Time {
+	var startsAt sql.NullTime
+	if err := db.DB.Model(&models.PremiumBooking{}).
+		Where("status = ? AND starts_at > ?", models.PremiumBookingScheduled, now).
+		Select("MIN(starts_at)").Scan(&startsAt).Error; err != nil {
+		log.Printf("scheduler: failed to find next premium booking: %v", err)
+	}
+	return clampJobRun(now, startsAt)
+}
+
+// clampJobRun выбирает ближайший из сроков и ограничивает его окном [minJobWait, maxJobWait]
+func clampJobRun(now time.Time, candidates ...sql.NullTime) time.Time {
+	next := now.Add(maxJobWait)
+	for _, candidate := range candidates {
+		if candidate.Valid && candidate.Time.Before(next) {
+			next = candidate.Time
+		}
+	}
+	if earliest := now.Add(minJobWait); next.Before(earliest) {
+		next = earliest
+	}
+	return next
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestClampJobRun(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) sql.NullTime { return sql.NullTime{Time: now.Add(d), Valid: true} }
+
+	tests := []struct {
+		name       string
+		candidates []sql.NullTime
+		want       time.Time
+	}{
+		{"no candidates", nil, now.Add(maxJobWait)},
+		{"unknown candidate ignored", []sql.NullTime{{}}, now.Add(maxJobWait)},
+		{"within window", []sql.NullTime{at(time.Minute)}, now.Add(time.Minute)},
+		{"earliest wins", []sql.NullTime{at(3 * time.Minute), {}, at(90 * time.Second)}, now.Add(90 * time.Second)},
+		{"too far is clamped to max", []sql.NullTime{at(time.Hour)}, now.Add(maxJobWait)},
+		{"overdue is clamped to min", []sql.NullTime{at(-time.Hour)}, now.Add(minJobWait)},
+		{"too soon is clamped to min", []sql.NullTime{at(time.Second)}, now.Add(minJobWait)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clampJobRun(now, tt.candidates...); !got.Equal(tt.want) {
+				t.Fatalf("clampJobRun = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
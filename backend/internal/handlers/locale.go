@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/middleware"
+	"youtube-market/internal/models"
+
+	"github.com/gin-gonic/gin"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+)
+
+// userLanguageTTL — сколько хранится в памяти язык пользователя. Запросы Mini App идут
+// пачками, а выбор языка меняется редко, поэтому в базу ходим не чаще раза в минуту.
+const userLanguageTTL = time.Minute
+
+type userLanguageEntry struct {
+	Language     string
+	LanguageCode string
+	LoadedAt     time.Time
+}
+
+var userLanguageCache = struct {
+	sync.Mutex
+	entries map[int64]userLanguageEntry
+}{entries: make(map[int64]userLanguageEntry)}
+
+// UserLanguage возвращает язык, выбранный пользователем командой /language (пустая строка —
+// как в Telegram), и запоминает его текущий language_code для уведомлений от бота.
+// Используется middleware.LocaleMiddleware.
+func UserLanguage(telegramID int64, languageCode string) string {
+	return loadUserLanguage(telegramID, languageCode).Language
+}
+
+func loadUserLanguage(telegramID int64, languageCode string) userLanguageEntry {
+	now := time.Now()
+	userLanguageCache.Lock()
+	entry, ok := userLanguageCache.entries[telegramID]
+	userLanguageCache.Unlock()
+
+	if !ok || now.Sub(entry.LoadedAt) > userLanguageTTL {
+		var user models.User
+		err := db.DB.Unscoped().Select("language", "language_code").
+			Where("telegram_id = ?", telegramID).Take(&user).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			log.Printf("i18n: failed to load language of user %d: %v", telegramID, err)
+		}
+		entry = userLanguageEntry{Language: user.Language, LanguageCode: user.LanguageCode, LoadedAt: now}
+	}
+
+	if languageCode != "" && languageCode != entry.LanguageCode {
+		if err := db.DB.Unscoped().Model(&models.User{}).
+			Where("telegram_id = ?", telegramID).
+			Update("language_code", languageCode).Error; err != nil {
+			log.Printf("i18n: failed to save language_code of user %d: %v", telegramID, err)
+		}
+		entry.LanguageCode = languageCode
+	}
+
+	userLanguageCache.Lock()
+	userLanguageCache.entries[telegramID] = entry
+	userLanguageCache.Unlock()
+	return entry
+}
+
+// userLocale — язык сообщений бота для пользователя Telegram
+func userLocale(from *tgbotapi.User) string {
+	if from == nil {
+		return i18n.Default()
+	}
+	entry := loadUserLanguage(from.ID, from.LanguageCode)
+	return i18n.Resolve(entry.Language, entry.LanguageCode)
+}
+
+// telegramUserLocale — язык сообщений для пользователя, известного только по Telegram ID
+// (например, получателя уведомления о возврате)
+func telegramUserLocale(telegramID int64) string {
+	entry := loadUserLanguage(telegramID, "")
+	return i18n.Resolve(entry.Language, entry.LanguageCode)
+}
+
+// setUserLanguage сохраняет выбранный пользователем язык (пустая строка — как в Telegram)
+func setUserLanguage(from *tgbotapi.User, language string) error {
+	user, err := db.EnsureTelegramUser(db.DB, from.ID, from.UserName)
+	if err != nil {
+		return err
+	}
+	if err := db.DB.Unscoped().Model(&user).Updates(map[string]interface{}{
+		"language":      language,
+		"language_code": from.LanguageCode,
+	}).Error; err != nil {
+		return err
+	}
+
+	userLanguageCache.Lock()
+	userLanguageCache.entries[from.ID] = userLanguageEntry{
+		Language:     language,
+		LanguageCode: from.LanguageCode,
+		LoadedAt:     time.Now(),
+	}
+	userLanguageCache.Unlock()
+	return nil
+}
+
+// contextLocale — язык ответа API, выбранный middleware.LocaleMiddleware
+func contextLocale(c *gin.Context) string {
+	return middleware.Locale(c)
+}
+
+// respondError отвечает ошибкой API на языке пользователя. code — ключ сообщения,
+// по которому клиент может распознать ошибку независимо от языка.
+func respondError(c *gin.Context, status int, key string, extra ...gin.H) {
+	c.JSON(status, errorBody(i18n.T(contextLocale(c), key), key, extra))
+}
+
+// respondLocalizedError отвечает доменной ошибкой (i18n.Error и подобными) на языке
+// пользователя; для ошибок без перевода используется сообщение fallbackKey
+func respondLocalizedError(c *gin.Context, status int, err error, fallbackKey string, extra ...gin.H) {
+	code := i18n.Code(err)
+	if code == "" {
+		code = fallbackKey
+	}
+	c.JSON(status, errorBody(i18n.Message(contextLocale(c), err, fallbackKey), code, extra))
+}
+
+func errorBody(message, code string, extra []gin.H) gin.H {
+	body := gin.H{"error": message, "code": code}
+	for _, fields := range extra {
+		for name, value := range fields {
+			body[name] = value
+		}
+	}
+	return body
+}
+
+// formatDate и formatDateTime форматируют дату в принятом для языка виде
+func formatDate(locale string, t time.Time) string {
+	return t.Format(i18n.T(locale, "format.date"))
+}
+
+func formatDateTime(locale string, t time.Time) string {
+	return t.Format(i18n.T(locale, "format.datetime"))
+}
+
+// formatDays — «7 дней», «1 day» с учётом правил множественного числа
+func formatDays(locale string, days int) string {
+	return i18n.N(locale, "unit.days", days, days)
+}
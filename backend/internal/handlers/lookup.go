@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+)
+
+// maxSimilarNames — сколько похожих username из чёрного списка показывать
+const maxSimilarNames = 5
+
+// userLookup — сводка по пользователю для быстрой проверки перед сделкой
+type userLookup struct {
+	Username  string
+	UserID    int64
+	Scam      *scamCheckResult // nil, если username неизвестен
+	Similar   []string
+	ActiveAds []models.Ad
+}
+
+// lookupUser собирает статус в чёрном списке, похожие имена и активные объявления.
+// Можно передать username, userID или оба сразу.
+func lookupUser(username string, userID int64) (userLookup, error) {
+	username = normalizeUsername(username)
+	result := userLookup{Username: username, UserID: userID}
+
+	if username != "" {
+		scam, err := checkScamStatus(username)
+		observeScamCheck(scamCheckSourceLookup, scam, err)
+		if err != nil {
+			return result, err
+		}
+		result.Scam = &scam
+
+		if !scam.Listed {
+			similar, err := similarBlacklisted(username, maxSimilarNames)
+			if err != nil {
+				return result, err
+			}
+			result.Similar = similar
+		}
+	}
+
+	query := db.DB.Where("status = ? AND expires_at > ?", models.AdStatusActive, time.Now())
+	switch {
+	case username != "" && userID != 0:
+		owners := db.DB.Model(&models.User{}).Unscoped().Select("id").Where("telegram_id = ?", userID)
+		query = query.Where("LOWER(username) = LOWER(?) OR owner_id IN (?)", username, owners)
+	case username != "":
+		query = query.Where("LOWER(username) = LOWER(?)", username)
+	case userID != 0:
+		query = ownedBy(query, userID)
+	default:
+		return result, nil
+	}
+
+	if err := query.Order("is_premium DESC, updated_at DESC").Find(&result.ActiveAds).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// similarBlacklisted ищет в чёрном списке username, отличающиеся на пару символов
+// или подменой похожих символов (0/o, 1/l и т.п.) — частый приём мошенников
+func similarBlacklisted(username string, limit int) ([]string, error) {
+	var usernames []string
+	if err := db.DB.Model(&models.User{}).
+		Where("is_scammer = ?", true).
+		Order("username ASC").
+		Pluck("username", &usernames).Error; err != nil {
+		return nil, err
+	}
+
+	target := skeletonUsername(username)
+	var similar []string
+	for _, candidate := range usernames {
+		if strings.EqualFold(candidate, username) {
+			continue
+		}
+		if isSimilarUsername(target, skeletonUsername(candidate)) {
+			similar = append(similar, candidate)
+			if len(similar) >= limit {
+				break
+			}
+		}
+	}
+	return similar, nil
+}
+
+var usernameHomoglyphs = strings.NewReplacer(
+	"0", "o",
+	"1", "l",
+	"i", "l",
+	"3", "e",
+	"4", "a",
+	"5", "s",
+	"7", "t",
+	"8", "b",
+	"rn", "m",
+	"vv", "w",
+	"_", "",
+)
+
+// skeletonUsername приводит username к «скелету»: нижний регистр без похожих символов и подчёркиваний
+func skeletonUsername(username string) string {
+	return usernameHomoglyphs.Replace(strings.ToLower(username))
+}
+
+func isSimilarUsername(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	maxDistance := 2
+	if len(a) < 6 || len(b) < 6 {
+		maxDistance = 1
+	}
+	if diff := len(a) - len(b); diff > maxDistance || -diff > maxDistance {
+		return false
+	}
+	return levenshtein(a, b) <= maxDistance
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// renderUserLookup формирует текст проверки пользователя (Markdown) на языке locale
+func renderUserLookup(locale string, lookup userLookup) string {
+	var text strings.Builder
+	text.WriteString(i18n.T(locale, "check.title") + "\n\n")
+
+	if lookup.Username != "" {
+		text.WriteString(fmt.Sprintf("👤 @%s\n", escapeMarkdown(lookup.Username)))
+	}
+	if lookup.UserID != 0 {
+		text.WriteString(fmt.Sprintf("🆔 ID: %d\n", lookup.UserID))
+	}
+	text.WriteString("\n")
+
+	switch {
+	case lookup.Scam == nil:
+		text.WriteString(i18n.T(locale, "check.username_hidden") + "\n")
+	case lookup.Scam.Listed:
+		text.WriteString(i18n.T(locale, "check.listed") + "\n")
+		if lookup.Scam.Reason != "" {
+			text.WriteString(i18n.T(locale, "check.reason", escapeMarkdown(lookup.Scam.Reason)) + "\n")
+		}
+	case lookup.Scam.Blocked || lookup.Scam.Warning:
+		icon := "⚠️"
+		if lookup.Scam.Blocked {
+			icon = "🚫"
+		}
+		text.WriteString(fmt.Sprintf("%s %s\n", icon, escapeMarkdown(lookup.Scam.Message(locale))))
+		for _, flag := range lookup.Scam.Partners {
+			if flag.Reason != "" {
+				text.WriteString(fmt.Sprintf("• %s: %s\n", escapeMarkdown(flag.Peer), escapeMarkdown(flag.Reason)))
+			}
+		}
+	default:
+		text.WriteString(i18n.T(locale, "check.clean") + "\n")
+	}
+
+	if len(lookup.Similar) > 0 {
+		names := make([]string, 0, len(lookup.Similar))
+		for _, name := range lookup.Similar {
+			names = append(names, "@"+escapeMarkdown(name))
+		}
+		text.WriteString("\n" + i18n.T(locale, "check.similar", strings.Join(names, ", ")) + "\n")
+	}
+
+	if len(lookup.ActiveAds) == 0 {
+		text.WriteString("\n" + i18n.T(locale, "check.no_ads"))
+		return text.String()
+	}
+
+	text.WriteString("\n" + i18n.T(locale, "check.ads", len(lookup.ActiveAds)) + "\n")
+	for i, ad := range lookup.ActiveAds {
+		if i >= 10 {
+			text.WriteString(i18n.T(locale, "check.more", len(lookup.ActiveAds)-10) + "\n")
+			break
+		}
+		premium := ""
+		if ad.IsPremium {
+			premium = "⭐ "
+		}
+		text.WriteString(fmt.Sprintf("%s#%d %s %s\n", premium, ad.ID, escapeMarkdown(truncate(ad.Title, 40)),
+			i18n.T(locale, "check.ad_until", formatDate(locale, ad.ExpiresAt))))
+	}
+
+	return text.String()
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// marketScrapeTimeout ограничивает запросы к БД при сборе метрик
+const marketScrapeTimeout = 5 * time.Second
+
+// Источники проверки по чёрному списку для market_scam_checks_total
+const (
+	scamCheckSourceAPI    = "api"
+	scamCheckSourceLookup = "lookup"
+	scamCheckSourceGuard  = "guard"
+)
+
+var (
+	adsCreatedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "market_ads_created_total",
+		Help: "Количество созданных объявлений",
+	}, []string{"category"})
+	adsRenewedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "market_ads_renewed_total",
+		Help: "Количество продлений объявлений по источнику (manager, owner, payment, auto)",
+	}, []string{"source"})
+	adsExpiredTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "market_ads_expired_total",
+		Help: "Количество объявлений, снятых по истечении срока",
+	}, []string{"category"})
+	scamChecksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "market_scam_checks_total",
+		Help: "Проверки пользователей по чёрному списку по источнику и результату (listed, blocked, warned, clean, error)",
+	}, []string{"source", "result"})
+	searchAlertsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "market_saved_search_alerts_total",
+		Help: "Уведомления по сохранённым поискам по результату (sent, limited, error)",
+	}, []string{"result"})
+	botUpdateDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "bot_update_duration_seconds",
+		Help:    "Время обработки обновления Telegram по типу обновления",
+		Buckets: prometheus.ExponentialBuckets(0.005, 3, 9),
+	}, []string{"type"})
+)
+
+// observeScamCheck учитывает проверку по чёрному списку
+func observeScamCheck(source string, result scamCheckResult, err error) {
+	outcome := "clean"
+	switch {
+	case err != nil:
+		outcome = "error"
+	case result.Listed:
+		outcome = "listed"
+	case result.Blocked:
+		outcome = "blocked"
+	case result.Warning:
+		outcome = "warned"
+	}
+	scamChecksTotal.WithLabelValues(source, outcome).Inc()
+}
+
+// observeRenewal учитывает продление объявления; вызывается после коммита продления
+func observeRenewal(source string) {
+	adsRenewedTotal.WithLabelValues(source).Inc()
+}
+
+// observeBotUpdate учитывает время обработки обновления Telegram
+func observeBotUpdate(update tgbotapi.Update, startedAt time.Time) {
+	botUpdateDuration.WithLabelValues(botUpdateType(update)).Observe(time.Since(startedAt).Seconds())
+}
+
+func botUpdateType(update tgbotapi.Update) string {
+	switch {
+	case update.Message != nil && update.Message.SuccessfulPayment != nil:
+		return "payment"
+	case update.Message != nil:
+		return "message"
+	case update.CallbackQuery != nil:
+		return "callback_query"
+	case update.InlineQuery != nil:
+		return "inline_query"
+	case update.PreCheckoutQuery != nil:
+		return "pre_checkout_query"
+	case update.MyChatMember != nil:
+		return "my_chat_member"
+	default:
+		return "other"
+	}
+}
+
+// marketCollector считает состояние биржи из БД при каждом запросе /metrics
+type marketCollector struct{}
+
+var (
+	activeAdsDesc = prometheus.NewDesc("market_active_ads",
+		"Активные объявления по категории, режиму и тегу", []string{"category", "mode", "tag"}, nil)
+	premiumActiveDesc = prometheus.NewDesc("market_premium_active",
+		"Занятые премиум-места по категории", []string{"category"}, nil)
+	premiumSlotsDesc = prometheus.NewDesc("market_premium_slots",
+		"Число премиум-мест в категории (PREMIUM_SLOTS_<КАТЕГОРИЯ>, PREMIUM_SLOTS или 3)", []string{"category"}, nil)
+	blacklistSizeDesc = prometheus.NewDesc("market_blacklist_entries",
+		"Записи чёрного списка: собственные (local) и полученные от партнёров (federation)", []string{"source"}, nil)
+)
+
+func init() {
+	prometheus.MustRegister(marketCollector{})
+}
+
+func (marketCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- activeAdsDesc
+	ch <- premiumActiveDesc
+	ch <- premiumSlotsDesc
+	ch <- blacklistSizeDesc
+}
+
+func (marketCollector) Collect(ch chan<- prometheus.Metric) {
+	if db.DB == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), marketScrapeTimeout)
+	defer cancel()
+	tx := db.DB.WithContext(ctx)
+	now := time.Now()
+
+	var active []struct {
+		Category string
+		Mode     string
+		Tag      string
+		Count    int64
+	}
+	if err := tx.Model(&models.Ad{}).
+		Select("category, mode, tag, COUNT(*) AS count").
+		Where("status = ? AND expires_at > ?", models.AdStatusActive, now).
+		Group("category, mode, tag").Scan(&active).Error; err != nil {
+		log.Printf("metrics: failed to count active ads: %v", err)
+	}
+	for _, row := range active {
+		ch <- prometheus.MustNewConstMetric(activeAdsDesc, prometheus.GaugeValue, float64(row.Count), row.Category, row.Mode, row.Tag)
+	}
+
+	var premium []struct {
+		Category string
+		Count    int64
+	}
+	if err := tx.Model(&models.Ad{}).
+		Select("category, COUNT(*) AS count").
+		Where("status = ? AND expires_at > ? AND is_premium = ?", models.AdStatusActive, now, true).
+		Group("category").Scan(&premium).Error; err != nil {
+		log.Printf("metrics: failed to count premium ads: %v", err)
+	}
+	premiumByCategory := make(map[string]int64, len(premium))
+	for _, row := range premium {
+		premiumByCategory[row.Category] = row.Count
+	}
+	// Кроме активных рубрик — скрытые, в которых ещё остались премиум-объявления
+	categories := activeCategoryKeys()
+	listed := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		listed[category] = true
+	}
+	for category := range premiumByCategory {
+		if !listed[category] {
+			categories = append(categories, category)
+		}
+	}
+	for _, category := range categories {
+		ch <- prometheus.MustNewConstMetric(premiumActiveDesc, prometheus.GaugeValue, float64(premiumByCategory[category]), category)
+		ch <- prometheus.MustNewConstMetric(premiumSlotsDesc, prometheus.GaugeValue, float64(premiumSlots(category)), category)
+	}
+
+	var local, federated int64
+	if err := tx.Model(&models.User{}).Where("is_scammer = ?", true).Count(&local).Error; err != nil {
+		log.Printf("metrics: failed to count blacklist: %v", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(blacklistSizeDesc, prometheus.GaugeValue, float64(local), "local")
+	}
+	if err := tx.Model(&models.PeerBlacklistEntry{}).Count(&federated).Error; err != nil {
+		log.Printf("metrics: failed to count partner blacklist: %v", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(blacklistSizeDesc, prometheus.GaugeValue, float64(federated), "federation")
+	}
+}
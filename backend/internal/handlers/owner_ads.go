@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	"github.com/gin-gonic/gin"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// defaultFreeRenewals — сколько раз владелец может бесплатно продлить объявление за freeRenewalWindow
+	defaultFreeRenewals = 1
+	freeRenewalWindow   = 30 * 24 * time.Hour
+)
+
+var errFreeRenewalsUsed = i18n.NewError("error.free_renewals_used")
+
+// freeRenewalsLimit читает FREE_RENEWALS_PER_MONTH; 0 — продление только за оплату
+func freeRenewalsLimit() int {
+	if raw := strings.TrimSpace(os.Getenv("FREE_RENEWALS_PER_MONTH")); raw != "" {
+		if limit, err := strconv.Atoi(raw); err == nil && limit >= 0 {
+			return limit
+		}
+		log.Printf("owner ads: invalid FREE_RENEWALS_PER_MONTH=%q, using default %d", raw, defaultFreeRenewals)
+	}
+	return defaultFreeRenewals
+}
+
+// recordRenewal сохраняет продление в историю. Метрику продлений вызывающий учитывает
+// через observeRenewal только после коммита, чтобы откаченная транзакция не попала в счётчик.
+func recordRenewal(tx *gorm.DB, ad models.Ad, userID int64, days int, source string) error {
+	return tx.Create(&models.AdRenewal{
+		AdID:      ad.ID,
+		UserID:    userID,
+		Days:      days,
+		Source:    source,
+		ExpiresAt: ad.ExpiresAt,
+	}).Error
+}
+
+// renewAdByOwner продлевает объявление владельцем с учётом лимита бесплатных продлений.
+// Срок добавляется к текущему сроку активного объявления (к текущему моменту — для истёкшего),
+// как при оплате продления, чтобы раннее продление не сокращало оплаченное время.
+func renewAdByOwner(adID uint, userID int64, days int) (models.Ad, int, error) {
+	var ad models.Ad
+	remaining := 0
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ad, adID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errAdNotOwned
+			}
+			return err
+		}
+		if !isAdOwner(ad, userID) {
+			return errAdNotOwned
+		}
+		if ad.Status == models.AdStatusInactive {
+			return errAdNotRenewable
+		}
+
+		var used int64
+		if err := tx.Model(&models.AdRenewal{}).
+			Where("ad_id = ? AND source = ? AND created_at > ?", ad.ID, models.RenewalSourceOwner, time.Now().Add(-freeRenewalWindow)).
+			Count(&used).Error; err != nil {
+			return err
+		}
+		limit := freeRenewalsLimit()
+		if used >= int64(limit) {
+			return errFreeRenewalsUsed
+		}
+		remaining = limit - int(used) - 1
+
+		applyPaymentToAd(&ad, models.PaymentProductRenew, days, time.Now())
+		if err := tx.Save(&ad).Error; err != nil {
+			return err
+		}
+		return recordRenewal(tx, ad, userID, days, models.RenewalSourceOwner)
+	})
+	if err == nil {
+		observeRenewal(models.RenewalSourceOwner)
+	}
+	return ad, remaining, err
+}
+
+type renewAdRequest struct {
+	Days int `json:"days" binding:"required"`
+}
+
+// RenewMyAd продлевает объявление текущего пользователя (POST /api/ads/:id/renew)
+func RenewMyAd(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		respondError(c, http.StatusUnauthorized, "error.auth_required")
+		return
+	}
+
+	adID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "error.invalid_ad_id")
+		return
+	}
+
+	var req renewAdRequest
+	if err := c.ShouldBindJSON(&req); err != nil || !isValidDuration(req.Days) {
+		respondError(c, http.StatusBadRequest, "error.renew_days")
+		return
+	}
+
+	ad, remaining, err := renewAdByOwner(uint(adID), userID, req.Days)
+	switch {
+	case errors.Is(err, errAdNotOwned):
+		respondError(c, http.StatusNotFound, "error.ad_not_found")
+		return
+	case errors.Is(err, errAdNotRenewable):
+		respondLocalizedError(c, http.StatusConflict, err, "error.renew_failed")
+		return
+	case errors.Is(err, errFreeRenewalsUsed):
+		respondLocalizedError(c, http.StatusPaymentRequired, err, "error.renew_failed", gin.H{"product": models.PaymentProductRenew})
+		return
+	case err != nil:
+		log.Printf("owner ads: failed to renew ad %d: %v", adID, err)
+		respondError(c, http.StatusInternalServerError, "error.renew_failed")
+		return
+	}
+
+	if bot := getBotAPI(); bot != nil {
+		announceOwnerRenewal(bot, ad, req.Days)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ad":                    buildAdViews([]models.Ad{ad}, userID)[0],
+		"free_renewals_left":    remaining,
+		"renewal_price_per_day": paymentPricePerDay(models.PaymentProductRenew),
+	})
+}
+
+// DeactivateMyAd снимает объявление текущего пользователя с биржи (POST /api/ads/:id/deactivate)
+func DeactivateMyAd(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		respondError(c, http.StatusUnauthorized, "error.auth_required")
+		return
+	}
+
+	adID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "error.invalid_ad_id")
+		return
+	}
+
+	ad, err := loadOwnedAd(uint(adID), userID)
+	if err != nil {
+		if errors.Is(err, errAdNotOwned) {
+			respondError(c, http.StatusNotFound, "error.ad_not_found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "error.ads_unavailable")
+		return
+	}
+
+	if err := deactivateAdByOwner(getBotAPI(), ad); err != nil {
+		log.Printf("owner ads: failed to deactivate ad %d: %v", ad.ID, err)
+		respondError(c, http.StatusInternalServerError, "error.deactivate_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ad": buildAdViews([]models.Ad{*ad}, userID)[0]})
+}
+
+// announceOwnerRenewal обновляет пост в канале и сообщает менеджерам о продлении владельцем
+func announceOwnerRenewal(bot *tgbotapi.BotAPI, ad models.Ad, days int) {
+	publishAdToChannel(bot, &ad, true)
+	go notifyFavoritesRenewed(bot, ad)
+	notifyManagers(bot, managerIDsFromEnv(), func(locale string) string {
+		return i18n.T(locale, "manager.owner.renewed", ad.ID, ad.Title, formatDays(locale, days), formatDateTime(locale, ad.ExpiresAt))
+	})
+}
+
+// deactivateAdByOwner снимает объявление владельца с биржи; bot может быть nil,
+// тогда канал и менеджеры не обновляются. Уже снятое объявление не трогается.
+func deactivateAdByOwner(bot *tgbotapi.BotAPI, ad *models.Ad) error {
+	if ad.Status == models.AdStatusInactive {
+		return nil
+	}
+	if err := setAdStatus(ad.ID, models.AdStatusInactive); err != nil {
+		return err
+	}
+	ad.Status = models.AdStatusInactive
+
+	if bot != nil {
+		removeAdFromChannel(bot, ad)
+		if ad.IsPremium {
+			processPremiumQueue(bot)
+		}
+		notifyManagers(bot, managerIDsFromEnv(), func(locale string) string {
+			return i18n.T(locale, "manager.owner.deactivated", ad.ID, ad.Title)
+		})
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/models"
+)
+
+func TestRenewAdByOwnerExtendsCurrentTerm(t *testing.T) {
+	openPaymentsDB(t)
+	t.Setenv("FREE_RENEWALS_PER_MONTH", "1")
+
+	ad := createOwnedAd(t, 1001, "services", false)
+	renewed, remaining, err := renewAdByOwner(ad.ID, 1001, 7)
+	if err != nil {
+		t.Fatalf("renewAdByOwner: %v", err)
+	}
+	if want := ad.ExpiresAt.Add(7 * 24 * time.Hour); !renewed.ExpiresAt.Equal(want) {
+		t.Fatalf("expires at %v, want %v", renewed.ExpiresAt, want)
+	}
+	if remaining != 0 {
+		t.Fatalf("%d free renewals left, want 0", remaining)
+	}
+
+	if _, _, err := renewAdByOwner(ad.ID, 1001, 7); !errors.Is(err, errFreeRenewalsUsed) {
+		t.Fatalf("second renewal: got %v, want errFreeRenewalsUsed", err)
+	}
+}
+
+func TestRenewAdByOwnerStartsExpiredAdFromNow(t *testing.T) {
+	openPaymentsDB(t)
+
+	ad := createOwnedAd(t, 1001, "services", false)
+	db.DB.Model(&models.Ad{}).Where("id = ?", ad.ID).Updates(map[string]interface{}{
+		"status":     models.AdStatusExpired,
+		"expires_at": time.Now().Add(-48 * time.Hour),
+	})
+
+	before := time.Now()
+	renewed, _, err := renewAdByOwner(ad.ID, 1001, 7)
+	if err != nil {
+		t.Fatalf("renewAdByOwner: %v", err)
+	}
+	if renewed.Status != models.AdStatusActive || renewed.ExpiresAt.Before(before.Add(7*24*time.Hour)) {
+		t.Fatalf("status %q, expires at %v", renewed.Status, renewed.ExpiresAt)
+	}
+
+	if _, _, err := renewAdByOwner(ad.ID, 2002, 7); !errors.Is(err, errAdNotOwned) {
+		t.Fatalf("renewal by another user: got %v, want errAdNotOwned", err)
+	}
+}
+
+func TestFreeRenewalsLimit(t *testing.T) {
+	tests := []struct {
+		env  string
+		want int
+	}{
+		{"", defaultFreeRenewals},
+		{"0", 0},
+		{" 3 ", 3},
+		{"-1", defaultFreeRenewals},
+		{"many", defaultFreeRenewals},
+	}
+	for _, tt := range tests {
+		t.Setenv("FREE_RENEWALS_PER_MONTH", tt.env)
+		if got := freeRenewalsLimit(); got != tt.want {
+			t.Fatalf("FREE_RENEWALS_PER_MONTH=%q: limit %d, want %d", tt.env, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"log"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// setAdOwner привязывает объявление к пользователю с указанным Telegram ID
+func setAdOwner(ad *models.Ad, telegramID int64, username string) error {
+	user, err := db.EnsureTelegramUser(db.DB, telegramID, username)
+	if err != nil {
+		return err
+	}
+	ad.OwnerID = &user.ID
+	ad.Owner = &user
+	return nil
+}
+
+// withOwner подгружает владельцев объявлений одним запросом (включая удалённых пользователей),
+// чтобы ownerTelegramID и ownerLocale в циклах по объявлениям не ходили в базу за каждым
+func withOwner(query *gorm.DB) *gorm.DB {
+	return query.Preload("Owner", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() })
+}
+
+// ownerLoaded — владелец объявления уже подгружен через withOwner
+func ownerLoaded(ad models.Ad) bool {
+	return ad.Owner != nil && ad.OwnerID != nil && ad.Owner.ID == *ad.OwnerID
+}
+
+// ownerTelegramID возвращает Telegram ID владельца объявления (0 — владелец не указан)
+func ownerTelegramID(ad models.Ad) int64 {
+	if ownerLoaded(ad) {
+		if ad.Owner.TelegramID != nil {
+			return *ad.Owner.TelegramID
+		}
+		return 0
+	}
+	if ad.OwnerID == nil {
+		return 0
+	}
+
+	var user models.User
+	if err := db.DB.Unscoped().Select("id", "telegram_id").First(&user, *ad.OwnerID).Error; err != nil {
+		log.Printf("failed to load owner %d of ad %d: %v", *ad.OwnerID, ad.ID, err)
+		return 0
+	}
+	if user.TelegramID == nil {
+		return 0
+	}
+	return *user.TelegramID
+}
+
+// ownerLocale — язык уведомлений владельцу: выбранный им командой /language или язык его Telegram
+func ownerLocale(ad models.Ad) string {
+	if ownerLoaded(ad) {
+		return i18n.Resolve(ad.Owner.Language, ad.Owner.LanguageCode)
+	}
+	if ad.OwnerID == nil {
+		return i18n.Default()
+	}
+
+	var user models.User
+	if err := db.DB.Unscoped().Select("id", "language", "language_code").First(&user, *ad.OwnerID).Error; err != nil {
+		log.Printf("failed to load language of owner %d of ad %d: %v", *ad.OwnerID, ad.ID, err)
+		return i18n.Default()
+	}
+	return i18n.Resolve(user.Language, user.LanguageCode)
+}
+
+// ownedBy ограничивает запрос объявлениями пользователя с указанным Telegram ID
+func ownedBy(query *gorm.DB, telegramID int64) *gorm.DB {
+	return query.Where("owner_id IN (?)", db.DB.Model(&models.User{}).Unscoped().Select("id").Where("telegram_id = ?", telegramID))
+}
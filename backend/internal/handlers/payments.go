@@ -0,0 +1,484 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	"github.com/gin-gonic/gin"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// starsCurrency — валюта Telegram Stars
+const starsCurrency = "XTR"
+
+// Цены по умолчанию за один день (в звёздах), переопределяются через окружение
+const (
+	defaultRenewPricePerDay   = 10
+	defaultPremiumPricePerDay = 50
+)
+
+var (
+	errPaymentNotFound   = i18n.NewError("error.payment_not_found")
+	errPaymentNotPaid    = i18n.NewError("error.payment_not_paid")
+	errAdNotOwned        = i18n.NewError("error.ad_not_owned")
+	errPremiumLimit      = i18n.NewError("error.premium_limit")
+	errInvalidProduct    = i18n.NewError("error.invalid_product")
+	errInvalidPayDays    = i18n.NewError("error.invalid_pay_days")
+	errAdNotRenewable    = i18n.NewError("error.ad_not_renewable")
+	errPaymentMismatched = i18n.NewError("error.payment_mismatched")
+)
+
+// paymentPricePerDay возвращает цену дня для товара: STARS_RENEW_PRICE_PER_DAY / STARS_PREMIUM_PRICE_PER_DAY
+func paymentPricePerDay(product string) int {
+	key, fallback := "STARS_RENEW_PRICE_PER_DAY", defaultRenewPricePerDay
+	if product == models.PaymentProductPremium {
+		key, fallback = "STARS_PREMIUM_PRICE_PER_DAY", defaultPremiumPricePerDay
+	}
+	if raw := strings.TrimSpace(os.Getenv(key)); raw != "" {
+		if price, err := strconv.Atoi(raw); err == nil && price > 0 {
+			return price
+		}
+		log.Printf("payments: invalid %s=%q, using default %d", key, raw, fallback)
+	}
+	return fallback
+}
+
+func paymentProductLabel(locale, product string) string {
+	if product == models.PaymentProductPremium {
+		return i18n.T(locale, "payment.product.premium")
+	}
+	return i18n.T(locale, "payment.product.renew")
+}
+
+// createPayment создаёт счёт в статусе pending после проверки владельца и лимитов
+func createPayment(userID int64, adID uint, product string, days int) (*models.Payment, *models.Ad, error) {
+	if product != models.PaymentProductRenew && product != models.PaymentProductPremium {
+		return nil, nil, errInvalidProduct
+	}
+	if !isValidDuration(days) {
+		return nil, nil, errInvalidPayDays
+	}
+
+	ad, err := loadOwnedAd(adID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validatePaymentTarget(db.DB, ad, product); err != nil {
+		return nil, nil, err
+	}
+
+	payload, err := newPaymentPayload()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payment := &models.Payment{
+		Payload:  payload,
+		UserID:   userID,
+		AdID:     ad.ID,
+		Product:  product,
+		Days:     days,
+		Amount:   paymentPricePerDay(product) * days,
+		Currency: starsCurrency,
+		Status:   models.PaymentStatusPending,
+	}
+	if err := db.DB.Create(payment).Error; err != nil {
+		return nil, nil, err
+	}
+	return payment, ad, nil
+}
+
+func loadOwnedAd(adID uint, userID int64) (*models.Ad, error) {
+	var ad models.Ad
+	if err := db.DB.First(&ad, adID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errAdNotOwned
+		}
+		return nil, err
+	}
+	if !isAdOwner(ad, userID) {
+		return nil, errAdNotOwned
+	}
+	return &ad, nil
+}
+
+// validatePaymentTarget проверяет, что товар можно применить к объявлению прямо сейчас
+func validatePaymentTarget(tx *gorm.DB, ad *models.Ad, product string) error {
+	if ad.Status == models.AdStatusInactive {
+		return errAdNotRenewable
+	}
+	if product != models.PaymentProductPremium || (ad.IsPremium && ad.Status == models.AdStatusActive && ad.ExpiresAt.After(time.Now())) {
+		return nil
+	}
+
+	free, err := premiumSlotFree(tx, ad.Category, &ad.ID)
+	if err != nil {
+		return err
+	}
+	if !free {
+		return errPremiumLimit
+	}
+	return nil
+}
+
+func newPaymentPayload() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "pay_" + hex.EncodeToString(buf), nil
+}
+
+// invoiceParams собирает параметры sendInvoice/createInvoiceLink для оплаты в Stars
+func invoiceParams(locale string, payment *models.Payment, ad *models.Ad) tgbotapi.Params {
+	title := paymentProductLabel(locale, payment.Product)
+	duration := formatDays(locale, payment.Days)
+	description := i18n.T(locale, "payment.invoice_description", truncate(ad.Title, 200), duration)
+
+	params := make(tgbotapi.Params)
+	params["title"] = title
+	params["description"] = description
+	params["payload"] = payment.Payload
+	params["currency"] = starsCurrency
+	_ = params.AddInterface("prices", []tgbotapi.LabeledPrice{
+		{Label: i18n.T(locale, "payment.price_label", title, duration), Amount: payment.Amount},
+	})
+	return params
+}
+
+// sendStarsInvoice отправляет счёт в личный чат пользователя
+func sendStarsInvoice(bot *tgbotapi.BotAPI, chatID int64, locale string, payment *models.Payment, ad *models.Ad) error {
+	params := invoiceParams(locale, payment, ad)
+	params.AddNonZero64("chat_id", chatID)
+	_, err := bot.MakeRequest("sendInvoice", params)
+	return err
+}
+
+// createStarsInvoiceLink создаёт ссылку на счёт для открытия из Mini App (WebApp.openInvoice)
+func createStarsInvoiceLink(bot *tgbotapi.BotAPI, locale string, payment *models.Payment, ad *models.Ad) (string, error) {
+	resp, err := bot.MakeRequest("createInvoiceLink", invoiceParams(locale, payment, ad))
+	if err != nil {
+		return "", err
+	}
+	var link string
+	if err := json.Unmarshal(resp.Result, &link); err != nil {
+		return "", err
+	}
+	return link, nil
+}
+
+// checkPreCheckout проверяет счёт перед списанием звёзд
+func checkPreCheckout(payload string, payerID int64, amount int, currency string) error {
+	var payment models.Payment
+	if err := db.DB.Where("payload = ?", payload).First(&payment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errPaymentNotFound
+		}
+		return err
+	}
+	if payment.Status != models.PaymentStatusPending || !matchesInvoice(payment, payerID, amount, currency) {
+		return errPaymentMismatched
+	}
+
+	ad, err := loadOwnedAd(payment.AdID, payerID)
+	if err != nil {
+		return err
+	}
+	return validatePaymentTarget(db.DB, ad, payment.Product)
+}
+
+// fulfilPayment фиксирует оплату и применяет товар к объявлению. Повторный вызов для того же
+// payload ничего не меняет (already=true). Если товар применить нельзя, платёж остаётся
+// в статусе paid и возвращается ошибка — такой платёж нужно вернуть.
+func fulfilPayment(payerID int64, payment *tgbotapi.SuccessfulPayment) (result models.Payment, ad models.Ad, already bool, err error) {
+	err = db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("payload = ?", payment.InvoicePayload).
+			First(&result).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errPaymentNotFound
+			}
+			return err
+		}
+
+		if already, err = fulfilmentState(result, payerID, payment); already || err != nil {
+			return err
+		}
+
+		now := time.Now()
+		markPaid(&result, payment, now)
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ad, result.AdID).Error; err != nil {
+			return err
+		}
+		if result.Product == models.PaymentProductPremium {
+			if err := lockPremiumCategory(tx, ad.Category); err != nil {
+				return err
+			}
+		}
+		if err := validatePaymentTarget(tx, &ad, result.Product); err != nil {
+			return err
+		}
+
+		applyPaymentToAd(&ad, result.Product, result.Days, now)
+		if err := tx.Save(&ad).Error; err != nil {
+			return err
+		}
+		if result.Product == models.PaymentProductRenew {
+			if err := recordRenewal(tx, ad, result.UserID, result.Days, models.RenewalSourcePayment); err != nil {
+				return err
+			}
+		}
+
+		result.Status = models.PaymentStatusFulfilled
+		result.FulfilledAt = &now
+		return tx.Save(&result).Error
+	})
+	if err == nil && !already && result.Product == models.PaymentProductRenew {
+		observeRenewal(models.RenewalSourcePayment)
+	}
+	if err != nil && result.ID != 0 && result.Status == models.PaymentStatusPaid {
+		// Сохраняем факт оплаты вне откатившейся транзакции, чтобы платёж можно было вернуть
+		if saveErr := db.DB.Model(&models.Payment{}).Where("id = ? AND status = ?", result.ID, models.PaymentStatusPending).Updates(map[string]interface{}{
+			"status":                     models.PaymentStatusPaid,
+			"paid_at":                    result.PaidAt,
+			"telegram_payment_charge_id": result.TelegramPaymentChargeID,
+			"provider_payment_charge_id": result.ProviderPaymentChargeID,
+		}).Error; saveErr != nil {
+			log.Printf("payments: failed to mark payment %d as paid: %v", result.ID, saveErr)
+		}
+	}
+	return result, ad, already, err
+}
+
+// matchesInvoice сверяет плательщика, сумму и валюту с выставленным счётом
+func matchesInvoice(payment models.Payment, payerID int64, amount int, currency string) bool {
+	return payment.UserID == payerID && payment.Amount == amount && payment.Currency == currency
+}
+
+// fulfilmentState решает, что делать с подтверждённой оплатой: already=true, если платёж
+// уже исполнен или возвращён; ошибка — если оплата не совпадает со счётом
+func fulfilmentState(payment models.Payment, payerID int64, paid *tgbotapi.SuccessfulPayment) (already bool, err error) {
+	if payment.Status != models.PaymentStatusPending && payment.Status != models.PaymentStatusPaid {
+		return true, nil
+	}
+	if !matchesInvoice(payment, payerID, paid.TotalAmount, paid.Currency) {
+		return false, errPaymentMismatched
+	}
+	return false, nil
+}
+
+// markPaid переводит pending-платёж в paid; у уже оплаченного ничего не меняет
+func markPaid(payment *models.Payment, paid *tgbotapi.SuccessfulPayment, now time.Time) {
+	if payment.Status != models.PaymentStatusPending {
+		return
+	}
+	payment.Status = models.PaymentStatusPaid
+	payment.PaidAt = &now
+	payment.TelegramPaymentChargeID = paid.TelegramPaymentChargeID
+	payment.ProviderPaymentChargeID = paid.ProviderPaymentChargeID
+}
+
+// applyPaymentToAd продлевает объявление или премиум-размещение на days дней
+func applyPaymentToAd(ad *models.Ad, product string, days int, now time.Time) {
+	period := time.Duration(days) * 24 * time.Hour
+
+	switch product {
+	case models.PaymentProductRenew:
+		base := ad.ExpiresAt
+		if ad.Status != models.AdStatusActive || base.Before(now) {
+			base = now
+		}
+		ad.ExpiresAt = base.Add(period)
+	case models.PaymentProductPremium:
+		if !ad.IsPremium {
+			ad.PremiumUntil = nil
+		}
+		grantPremium(ad, days, now)
+	}
+
+	ad.Status = models.AdStatusActive
+}
+
+// refundPayment возвращает звёзды и откатывает применённый товар. Повторный возврат — no-op.
+// Сначала платёж переводится в refunding и товар откатывается в отдельной транзакции,
+// затем вызывается refundStarPayment, и только после ответа Telegram платёж становится refunded.
+// Если Telegram не ответил, платёж остаётся в refunding и повторный вызов только повторит возврат.
+func refundPayment(bot *tgbotapi.BotAPI, paymentID uint, reason string) (models.Payment, error) {
+	var payment models.Payment
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&payment, paymentID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errPaymentNotFound
+			}
+			return err
+		}
+
+		if done, err := refundState(payment.Status); done || err != nil {
+			return err
+		}
+
+		if payment.Status == models.PaymentStatusFulfilled {
+			var ad models.Ad
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ad, payment.AdID).Error; err == nil {
+				revertPaymentOnAd(&ad, payment.Product, payment.Days, time.Now())
+				if err := tx.Save(&ad).Error; err != nil {
+					return err
+				}
+			} else if err != gorm.ErrRecordNotFound {
+				return err
+			}
+		}
+
+		payment.Status = models.PaymentStatusRefunding
+		payment.RefundReason = truncate(reason, 256)
+		return tx.Save(&payment).Error
+	})
+	if err != nil || payment.Status == models.PaymentStatusRefunded {
+		return payment, err
+	}
+
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("user_id", payment.UserID)
+	params["telegram_payment_charge_id"] = payment.TelegramPaymentChargeID
+	if _, err := bot.MakeRequest("refundStarPayment", params); err != nil && !strings.Contains(err.Error(), "CHARGE_ALREADY_REFUNDED") {
+		return payment, fmt.Errorf("refundStarPayment: %w", err)
+	}
+
+	now := time.Now()
+	if err := db.DB.Model(&models.Payment{}).
+		Where("id = ? AND status = ?", payment.ID, models.PaymentStatusRefunding).
+		Updates(map[string]interface{}{"status": models.PaymentStatusRefunded, "refunded_at": now}).Error; err != nil {
+		return payment, err
+	}
+	payment.Status = models.PaymentStatusRefunded
+	payment.RefundedAt = &now
+	return payment, nil
+}
+
+// refundState решает, можно ли начинать возврат: done=true, если возврат уже начат или
+// завершён; неоплаченный счёт вернуть нельзя
+func refundState(status string) (done bool, err error) {
+	switch status {
+	case models.PaymentStatusRefunded, models.PaymentStatusRefunding:
+		return true, nil
+	case models.PaymentStatusPending:
+		return false, errPaymentNotPaid
+	}
+	return false, nil
+}
+
+// revertPaymentOnAd откатывает продление или премиум, применённые applyPaymentToAd
+func revertPaymentOnAd(ad *models.Ad, product string, days int, now time.Time) {
+	period := time.Duration(days) * 24 * time.Hour
+
+	switch product {
+	case models.PaymentProductRenew:
+		ad.ExpiresAt = ad.ExpiresAt.Add(-period)
+	case models.PaymentProductPremium:
+		if ad.PremiumUntil != nil {
+			premiumUntil := ad.PremiumUntil.Add(-period)
+			ad.PremiumUntil = &premiumUntil
+			if !premiumUntil.After(now) {
+				ad.IsPremium = false
+				ad.PremiumUntil = nil
+			}
+		}
+	}
+}
+
+// GetPaymentOptions отдаёт Mini App сроки и цены (в звёздах за день)
+func GetPaymentOptions(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"currency":  starsCurrency,
+		"durations": []int{1, 7, 14, 30},
+		"price_per_day": gin.H{
+			models.PaymentProductRenew:   paymentPricePerDay(models.PaymentProductRenew),
+			models.PaymentProductPremium: paymentPricePerDay(models.PaymentProductPremium),
+		},
+	})
+}
+
+type createInvoiceRequest struct {
+	AdID    uint   `json:"ad_id" binding:"required"`
+	Product string `json:"product" binding:"required"`
+	Days    int    `json:"days" binding:"required"`
+}
+
+// CreatePaymentInvoice создаёт счёт в Stars для объявления текущего пользователя
+// и возвращает ссылку для Telegram.WebApp.openInvoice
+func CreatePaymentInvoice(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		respondError(c, http.StatusUnauthorized, "error.auth_required")
+		return
+	}
+
+	var req createInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "error.payment_params_required")
+		return
+	}
+
+	bot := getBotAPI()
+	if bot == nil {
+		respondError(c, http.StatusServiceUnavailable, "error.payments_unavailable")
+		return
+	}
+
+	payment, ad, err := createPayment(userID, req.AdID, req.Product, req.Days)
+	if err != nil {
+		respondPaymentError(c, err)
+		return
+	}
+
+	link, err := createStarsInvoiceLink(bot, contextLocale(c), payment, ad)
+	if err != nil {
+		log.Printf("payments: createInvoiceLink failed for payment %d: %v", payment.ID, err)
+		respondError(c, http.StatusBadGateway, "error.invoice_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"payment_id":   payment.ID,
+		"invoice_link": link,
+		"amount":       payment.Amount,
+		"currency":     payment.Currency,
+	})
+}
+
+func respondPaymentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errAdNotOwned):
+		respondLocalizedError(c, http.StatusNotFound, err, "error.payment_failed")
+	case errors.Is(err, errInvalidProduct), errors.Is(err, errInvalidPayDays):
+		respondLocalizedError(c, http.StatusBadRequest, err, "error.payment_failed")
+	case errors.Is(err, errPremiumLimit), errors.Is(err, errAdNotRenewable):
+		respondLocalizedError(c, http.StatusConflict, err, "error.payment_failed")
+	default:
+		log.Printf("payments: %v", err)
+		respondError(c, http.StatusInternalServerError, "error.payment_failed")
+	}
+}
+
+// contextUserID возвращает Telegram ID, проверенный TMAuthMiddleware
+func contextUserID(c *gin.Context) (int64, bool) {
+	value, exists := c.Get("user_id")
+	userID, _ := value.(int64)
+	return userID, exists && userID != 0
+}
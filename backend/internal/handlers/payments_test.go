@@ -0,0 +1,429 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/db/dbtest"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// fakeBotAPI — сервер Bot API для тестов: запоминает вызванные методы и отвечает
+// заданным телом (по умолчанию {"ok":true,"result":true})
+type fakeBotAPI struct {
+	mu        sync.Mutex
+	calls     []string
+	params    map[string][]string
+	responses map[string]string
+}
+
+func (f *fakeBotAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := path.Base(r.URL.Path)
+	r.ParseForm()
+
+	f.mu.Lock()
+	f.calls = append(f.calls, method)
+	if f.params == nil {
+		f.params = make(map[string][]string)
+	}
+	f.params[method] = append(f.params[method], r.Form.Get("telegram_payment_charge_id"))
+	response, ok := f.responses[method]
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case ok:
+		w.Write([]byte(response))
+	case method == "getMe":
+		w.Write([]byte(`{"ok":true,"result":{"id":1,"is_bot":true,"first_name":"Test","username":"test_bot"}}`))
+	default:
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}
+}
+
+func (f *fakeBotAPI) count(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, call := range f.calls {
+		if call == method {
+			n++
+		}
+	}
+	return n
+}
+
+func (f *fakeBotAPI) respond(method, body string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.responses == nil {
+		f.responses = make(map[string]string)
+	}
+	if body == "" {
+		delete(f.responses, method)
+		return
+	}
+	f.responses[method] = body
+}
+
+// startFakeBotAPI направляет бота на httptest-сервер через TELEGRAM_API_ENDPOINT
+func startFakeBotAPI(t *testing.T) (*tgbotapi.BotAPI, *fakeBotAPI) {
+	t.Helper()
+
+	fake := &fakeBotAPI{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	t.Setenv("TELEGRAM_API_ENDPOINT", server.URL+"/bot%s/%s")
+	bot, err := newBotAPI("test-token")
+	if err != nil {
+		t.Fatalf("newBotAPI: %v", err)
+	}
+	return bot, fake
+}
+
+// createOwnedAd создаёт пользователя с telegramID и активное объявление, которым он владеет
+func createOwnedAd(t *testing.T, telegramID int64, category string, premium bool) models.Ad {
+	t.Helper()
+
+	user := models.User{TelegramID: &telegramID}
+	if err := db.DB.Create(&user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	ad := models.Ad{
+		OwnerID:   &user.ID,
+		Title:     "Канал",
+		Category:  category,
+		Status:    models.AdStatusActive,
+		IsPremium: premium,
+		ExpiresAt: time.Now().Add(3 * 24 * time.Hour).Truncate(time.Second),
+	}
+	if err := db.DB.Create(&ad).Error; err != nil {
+		t.Fatalf("create ad: %v", err)
+	}
+	return ad
+}
+
+func successfulPayment(payment *models.Payment, chargeID string) *tgbotapi.SuccessfulPayment {
+	return &tgbotapi.SuccessfulPayment{
+		Currency:                payment.Currency,
+		TotalAmount:             payment.Amount,
+		InvoicePayload:          payment.Payload,
+		TelegramPaymentChargeID: chargeID,
+	}
+}
+
+func openPaymentsDB(t *testing.T) {
+	dbtest.Open(t, "payments", "ad_renewals", "ads", "users")
+}
+
+func TestCheckPreCheckout(t *testing.T) {
+	openPaymentsDB(t)
+	t.Setenv("PREMIUM_SLOTS", "1")
+
+	ad := createOwnedAd(t, 1001, "services", false)
+	payment, _, err := createPayment(1001, ad.ID, models.PaymentProductRenew, 7)
+	if err != nil {
+		t.Fatalf("createPayment: %v", err)
+	}
+
+	if err := checkPreCheckout(payment.Payload, 1001, payment.Amount, starsCurrency); err != nil {
+		t.Fatalf("valid pre-checkout rejected: %v", err)
+	}
+	if err := checkPreCheckout(payment.Payload, 1001, payment.Amount-1, starsCurrency); !errors.Is(err, errPaymentMismatched) {
+		t.Fatalf("wrong amount: got %v, want errPaymentMismatched", err)
+	}
+	if err := checkPreCheckout(payment.Payload, 2002, payment.Amount, starsCurrency); !errors.Is(err, errPaymentMismatched) {
+		t.Fatalf("another payer: got %v, want errPaymentMismatched", err)
+	}
+	if err := checkPreCheckout("pay_unknown", 1001, payment.Amount, starsCurrency); !errors.Is(err, errPaymentNotFound) {
+		t.Fatalf("unknown payload: got %v, want errPaymentNotFound", err)
+	}
+
+	premium, _, err := createPayment(1001, ad.ID, models.PaymentProductPremium, 1)
+	if err != nil {
+		t.Fatalf("createPayment premium: %v", err)
+	}
+	// пока пользователь оплачивал счёт, последнее премиум-место заняли
+	createOwnedAd(t, 3003, "services", true)
+	if err := checkPreCheckout(premium.Payload, 1001, premium.Amount, starsCurrency); !errors.Is(err, errPremiumLimit) {
+		t.Fatalf("premium over limit: got %v, want errPremiumLimit", err)
+	}
+
+	db.DB.Model(&models.Ad{}).Where("id = ?", ad.ID).Update("status", models.AdStatusInactive)
+	if err := checkPreCheckout(payment.Payload, 1001, payment.Amount, starsCurrency); !errors.Is(err, errAdNotRenewable) {
+		t.Fatalf("inactive ad: got %v, want errAdNotRenewable", err)
+	}
+}
+
+func TestFulfilPaymentIsIdempotent(t *testing.T) {
+	openPaymentsDB(t)
+
+	ad := createOwnedAd(t, 1001, "services", false)
+	payment, _, err := createPayment(1001, ad.ID, models.PaymentProductRenew, 7)
+	if err != nil {
+		t.Fatalf("createPayment: %v", err)
+	}
+
+	first, renewed, already, err := fulfilPayment(1001, successfulPayment(payment, "charge-1"))
+	if err != nil || already {
+		t.Fatalf("first fulfilment: already=%v err=%v", already, err)
+	}
+	if first.Status != models.PaymentStatusFulfilled {
+		t.Fatalf("status %q, want fulfilled", first.Status)
+	}
+	if want := ad.ExpiresAt.Add(7 * 24 * time.Hour); !renewed.ExpiresAt.Equal(want) {
+		t.Fatalf("expires at %v, want %v", renewed.ExpiresAt, want)
+	}
+
+	// Telegram может доставить successful_payment с тем же charge id повторно
+	_, _, already, err = fulfilPayment(1001, successfulPayment(payment, "charge-1"))
+	if err != nil || !already {
+		t.Fatalf("duplicate fulfilment: already=%v err=%v", already, err)
+	}
+
+	var stored models.Ad
+	db.DB.First(&stored, ad.ID)
+	if !stored.ExpiresAt.Equal(renewed.ExpiresAt) {
+		t.Fatalf("duplicate payment extended the ad again: %v != %v", stored.ExpiresAt, renewed.ExpiresAt)
+	}
+	var renewals int64
+	db.DB.Model(&models.AdRenewal{}).Where("ad_id = ?", ad.ID).Count(&renewals)
+	if renewals != 1 {
+		t.Fatalf("%d renewals recorded, want 1", renewals)
+	}
+}
+
+func TestRefundPayment(t *testing.T) {
+	openPaymentsDB(t)
+	bot, fake := startFakeBotAPI(t)
+
+	ad := createOwnedAd(t, 1001, "services", false)
+	payment, _, err := createPayment(1001, ad.ID, models.PaymentProductRenew, 7)
+	if err != nil {
+		t.Fatalf("createPayment: %v", err)
+	}
+	if _, _, _, err := fulfilPayment(1001, successfulPayment(payment, "charge-1")); err != nil {
+		t.Fatalf("fulfilPayment: %v", err)
+	}
+
+	refunded, err := refundPayment(bot, payment.ID, "тест")
+	if err != nil {
+		t.Fatalf("refundPayment: %v", err)
+	}
+	if refunded.Status != models.PaymentStatusRefunded || refunded.RefundedAt == nil {
+		t.Fatalf("status %q, want refunded", refunded.Status)
+	}
+	if got := fake.params["refundStarPayment"]; len(got) != 1 || got[0] != "charge-1" {
+		t.Fatalf("refundStarPayment calls: %v", got)
+	}
+
+	var stored models.Ad
+	db.DB.First(&stored, ad.ID)
+	if !stored.ExpiresAt.Equal(ad.ExpiresAt) {
+		t.Fatalf("renewal not reverted: expires at %v, want %v", stored.ExpiresAt, ad.ExpiresAt)
+	}
+
+	if _, err := refundPayment(bot, payment.ID, "повтор"); err != nil {
+		t.Fatalf("repeated refund: %v", err)
+	}
+	if n := fake.count("refundStarPayment"); n != 1 {
+		t.Fatalf("repeated refund called Telegram again: %d calls", n)
+	}
+}
+
+func TestRefundPaymentRetriesAfterTelegramError(t *testing.T) {
+	openPaymentsDB(t)
+	bot, fake := startFakeBotAPI(t)
+
+	ad := createOwnedAd(t, 1001, "services", false)
+	payment, _, err := createPayment(1001, ad.ID, models.PaymentProductRenew, 7)
+	if err != nil {
+		t.Fatalf("createPayment: %v", err)
+	}
+	if _, _, _, err := fulfilPayment(1001, successfulPayment(payment, "charge-1")); err != nil {
+		t.Fatalf("fulfilPayment: %v", err)
+	}
+
+	fake.respond("refundStarPayment", `{"ok":false,"error_code":400,"description":"Bad Request: temporary failure"}`)
+	failed, err := refundPayment(bot, payment.ID, "тест")
+	if err == nil || !strings.Contains(err.Error(), "refundStarPayment") {
+		t.Fatalf("got %v, want refundStarPayment error", err)
+	}
+	if failed.Status != models.PaymentStatusRefunding {
+		t.Fatalf("status %q, want refunding", failed.Status)
+	}
+
+	fake.respond("refundStarPayment", "")
+	refunded, err := refundPayment(bot, payment.ID, "повтор")
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if refunded.Status != models.PaymentStatusRefunded || refunded.RefundReason != "тест" {
+		t.Fatalf("status %q, reason %q", refunded.Status, refunded.RefundReason)
+	}
+
+	var stored models.Ad
+	db.DB.First(&stored, ad.ID)
+	if !stored.ExpiresAt.Equal(ad.ExpiresAt) {
+		t.Fatalf("renewal reverted more than once: expires at %v, want %v", stored.ExpiresAt, ad.ExpiresAt)
+	}
+}
+
+func TestParsePaymentCallback(t *testing.T) {
+	tests := []struct {
+		data    string
+		product string
+		adID    uint
+		days    int
+		ok      bool
+	}{
+		{"pay_renew_42_7", models.PaymentProductRenew, 42, 7, true},
+		{"pay_premium_1_30", models.PaymentProductPremium, 1, 30, true},
+		{"pay_renew_42", "", 0, 0, false},
+		{"pay_renew_42_7_1", "", 0, 0, false},
+		{"pay_renew_x_7", "", 0, 0, false},
+		{"pay_renew_-1_7", "", 0, 0, false},
+		{"pay_renew_42_week", "", 0, 0, false},
+	}
+	for _, tt := range tests {
+		product, adID, days, ok := parsePaymentCallback(tt.data)
+		if product != tt.product || adID != tt.adID || days != tt.days || ok != tt.ok {
+			t.Fatalf("parsePaymentCallback(%q) = %q, %d, %d, %v; want %q, %d, %d, %v",
+				tt.data, product, adID, days, ok, tt.product, tt.adID, tt.days, tt.ok)
+		}
+	}
+}
+
+func TestFulfilmentState(t *testing.T) {
+	invoice := models.Payment{UserID: 1001, Amount: 70, Currency: starsCurrency}
+	paid := &tgbotapi.SuccessfulPayment{TotalAmount: 70, Currency: starsCurrency}
+
+	tests := []struct {
+		name        string
+		status      string
+		payerID     int64
+		amount      int
+		wantAlready bool
+		wantErr     error
+	}{
+		{name: "pending", status: models.PaymentStatusPending, payerID: 1001, amount: 70},
+		// Товар не применился с первого раза — повторная доставка пробует снова
+		{name: "paid", status: models.PaymentStatusPaid, payerID: 1001, amount: 70},
+		{name: "fulfilled", status: models.PaymentStatusFulfilled, payerID: 1001, amount: 70, wantAlready: true},
+		{name: "refunding", status: models.PaymentStatusRefunding, payerID: 1001, amount: 70, wantAlready: true},
+		{name: "refunded", status: models.PaymentStatusRefunded, payerID: 1001, amount: 70, wantAlready: true},
+		{name: "another payer", status: models.PaymentStatusPending, payerID: 2002, amount: 70, wantErr: errPaymentMismatched},
+		{name: "wrong amount", status: models.PaymentStatusPending, payerID: 1001, amount: 69, wantErr: errPaymentMismatched},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment := invoice
+			payment.Status = tt.status
+			got := *paid
+			got.TotalAmount = tt.amount
+
+			already, err := fulfilmentState(payment, tt.payerID, &got)
+			if already != tt.wantAlready || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("fulfilmentState = %v, %v; want %v, %v", already, err, tt.wantAlready, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMarkPaid(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	paid := &tgbotapi.SuccessfulPayment{TelegramPaymentChargeID: "charge-2", ProviderPaymentChargeID: "provider-2"}
+
+	pending := models.Payment{Status: models.PaymentStatusPending}
+	markPaid(&pending, paid, now)
+	if pending.Status != models.PaymentStatusPaid || pending.PaidAt == nil || !pending.PaidAt.Equal(now) ||
+		pending.TelegramPaymentChargeID != "charge-2" || pending.ProviderPaymentChargeID != "provider-2" {
+		t.Fatalf("pending payment after markPaid: %+v", pending)
+	}
+
+	// Повторная доставка не перезаписывает время и charge id первой оплаты
+	paidAt := now.Add(-time.Hour)
+	already := models.Payment{Status: models.PaymentStatusPaid, PaidAt: &paidAt, TelegramPaymentChargeID: "charge-1"}
+	markPaid(&already, paid, now)
+	if !already.PaidAt.Equal(paidAt) || already.TelegramPaymentChargeID != "charge-1" {
+		t.Fatalf("paid payment changed by markPaid: %+v", already)
+	}
+}
+
+func TestRefundState(t *testing.T) {
+	tests := []struct {
+		status   string
+		wantDone bool
+		wantErr  error
+	}{
+		{models.PaymentStatusPending, false, errPaymentNotPaid},
+		{models.PaymentStatusPaid, false, nil},
+		{models.PaymentStatusFulfilled, false, nil},
+		{models.PaymentStatusRefunding, true, nil},
+		{models.PaymentStatusRefunded, true, nil},
+	}
+	for _, tt := range tests {
+		done, err := refundState(tt.status)
+		if done != tt.wantDone || !errors.Is(err, tt.wantErr) {
+			t.Fatalf("refundState(%q) = %v, %v; want %v, %v", tt.status, done, err, tt.wantDone, tt.wantErr)
+		}
+	}
+}
+
+func TestApplyAndRevertRenewal(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+
+	active := models.Ad{Status: models.AdStatusActive, ExpiresAt: now.Add(3 * 24 * time.Hour)}
+	applyPaymentToAd(&active, models.PaymentProductRenew, 7, now)
+	if want := now.Add(3*24*time.Hour + week); !active.ExpiresAt.Equal(want) {
+		t.Fatalf("active ad expires at %v, want %v", active.ExpiresAt, want)
+	}
+	revertPaymentOnAd(&active, models.PaymentProductRenew, 7, now)
+	if want := now.Add(3 * 24 * time.Hour); !active.ExpiresAt.Equal(want) {
+		t.Fatalf("reverted ad expires at %v, want %v", active.ExpiresAt, want)
+	}
+
+	expired := models.Ad{Status: models.AdStatusExpired, ExpiresAt: now.Add(-48 * time.Hour)}
+	applyPaymentToAd(&expired, models.PaymentProductRenew, 7, now)
+	if expired.Status != models.AdStatusActive || !expired.ExpiresAt.Equal(now.Add(week)) {
+		t.Fatalf("expired ad after renewal: status %q, expires at %v", expired.Status, expired.ExpiresAt)
+	}
+}
+
+func TestApplyAndRevertPremium(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	ad := models.Ad{Status: models.AdStatusActive, ExpiresAt: now.Add(3 * day)}
+	applyPaymentToAd(&ad, models.PaymentProductPremium, 7, now)
+	if !ad.IsPremium || ad.PremiumUntil == nil || !ad.PremiumUntil.Equal(now.Add(7*day)) {
+		t.Fatalf("premium after payment: %v until %v", ad.IsPremium, ad.PremiumUntil)
+	}
+	// Премиум дольше срока объявления продлевает и само объявление
+	if !ad.ExpiresAt.Equal(now.Add(7 * day)) {
+		t.Fatalf("ad expires at %v, want %v", ad.ExpiresAt, now.Add(7*day))
+	}
+
+	// Вторая оплата добавляется к текущему премиуму, возврат снимает только её
+	applyPaymentToAd(&ad, models.PaymentProductPremium, 7, now)
+	revertPaymentOnAd(&ad, models.PaymentProductPremium, 7, now)
+	if !ad.IsPremium || !ad.PremiumUntil.Equal(now.Add(7*day)) {
+		t.Fatalf("premium after partial refund: %v until %v", ad.IsPremium, ad.PremiumUntil)
+	}
+
+	revertPaymentOnAd(&ad, models.PaymentProductPremium, 7, now)
+	if ad.IsPremium || ad.PremiumUntil != nil {
+		t.Fatalf("premium after full refund: %v until %v", ad.IsPremium, ad.PremiumUntil)
+	}
+}
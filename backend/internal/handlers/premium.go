@@ -0,0 +1,416 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	"github.com/gin-gonic/gin"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	errBookingNotFound = i18n.NewError("manager.error.booking_not_found")
+	errAdNotBookable   = i18n.NewError("manager.error.ad_not_bookable")
+)
+
+// premiumQueueLockKey — ключ advisory-блокировки прохода очереди премиума. Очередь
+// обрабатывают и планировщик, и действия менеджеров и владельцев на любом экземпляре,
+// поэтому проходы упорядочиваются блокировкой в Postgres, как лидер планировщика.
+const premiumQueueLockKey int64 = 0x79745f7072656d71 // "yt_premq"
+
+// premiumSlots возвращает число премиум-мест в категории: PREMIUM_SLOTS_<КАТЕГОРИЯ>
+// (например PREMIUM_SLOTS_SERVICES), иначе PREMIUM_SLOTS, иначе maxPremiumActiveAds.
+func premiumSlots(category string) int {
+	keys := []string{"PREMIUM_SLOTS"}
+	if category != "" {
+		keys = append([]string{"PREMIUM_SLOTS_" + strings.ToUpper(category)}, keys...)
+	}
+
+	for _, key := range keys {
+		raw := strings.TrimSpace(os.Getenv(key))
+		if raw == "" {
+			continue
+		}
+		slots, err := strconv.Atoi(raw)
+		if err != nil || slots < 0 {
+			log.Printf("premium: invalid %s=%q", key, raw)
+			continue
+		}
+		return slots
+	}
+	return maxPremiumActiveAds
+}
+
+// premiumLockClass — первый ключ advisory-блокировки премиум-мест, второй — хеш категории
+const premiumLockClass int32 = 0x7072656d // "prem"
+
+// lockPremiumCategory берёт транзакционную advisory-блокировку категории до конца tx.
+// Проверка свободного места (premiumSlotFree) и выдача премиума должны идти под ней,
+// иначе две транзакции одновременно увидят последнее место свободным.
+func lockPremiumCategory(tx *gorm.DB, category string) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?, hashtext(?))", premiumLockClass, category).Error
+}
+
+// premiumSlotFree проверяет, есть ли в категории свободное премиум-место
+func premiumSlotFree(tx *gorm.DB, category string, excludeID *uint) (bool, error) {
+	count, err := activePremiumCountTx(tx, category, excludeID)
+	if err != nil {
+		return false, err
+	}
+	return count < int64(premiumSlots(category)), nil
+}
+
+// grantPremium делает объявление премиум на days дней (0 — до окончания срока объявления)
+func grantPremium(ad *models.Ad, days int, now time.Time) {
+	ad.IsPremium = true
+	if days <= 0 {
+		ad.PremiumUntil = nil
+		return
+	}
+
+	base := now
+	if ad.PremiumUntil != nil && ad.PremiumUntil.After(now) {
+		base = *ad.PremiumUntil
+	}
+	premiumUntil := base.Add(time.Duration(days) * 24 * time.Hour)
+	ad.PremiumUntil = &premiumUntil
+	if ad.ExpiresAt.Before(premiumUntil) {
+		ad.ExpiresAt = premiumUntil
+	}
+}
+
+// bookPremium ставит объявление в очередь на премиум-место начиная с startsAt
+func bookPremium(ad models.Ad, startsAt time.Time, days int, bookedBy int64) (models.PremiumBooking, error) {
+	if ad.Status != models.AdStatusActive || !ad.ExpiresAt.After(time.Now()) {
+		return models.PremiumBooking{}, errAdNotBookable
+	}
+
+	booking := models.PremiumBooking{
+		AdID:     ad.ID,
+		Category: ad.Category,
+		StartsAt: startsAt,
+		Days:     days,
+		Status:   models.PremiumBookingScheduled,
+		BookedBy: bookedBy,
+	}
+	if err := db.DB.Create(&booking).Error; err != nil {
+		return models.PremiumBooking{}, err
+	}
+	return booking, nil
+}
+
+// cancelPremiumBooking отменяет бронь, которая ещё не сработала
+func cancelPremiumBooking(bookingID uint) (models.PremiumBooking, error) {
+	var booking models.PremiumBooking
+	if err := db.DB.First(&booking, bookingID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return booking, errBookingNotFound
+		}
+		return booking, err
+	}
+
+	result := db.DB.Model(&models.PremiumBooking{}).
+		Where("id = ? AND status = ?", bookingID, models.PremiumBookingScheduled).
+		Update("status", models.PremiumBookingCancelled)
+	if result.Error != nil {
+		return booking, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return booking, errBookingNotFound
+	}
+	booking.Status = models.PremiumBookingCancelled
+	return booking, nil
+}
+
+// processPremiumQueue занимает освободившиеся премиум-места броней, срок которых наступил.
+// Брони обслуживаются по порядку: раньше начало — раньше место.
+// Проход вызывается и из обработчиков HTTP и бота, поэтому не ждёт чужой проход, а пропускается:
+// освободившееся место займёт следующий запуск задачи premium_queue (не позже maxJobWait).
+func processPremiumQueue(bot *tgbotapi.BotAPI) {
+	unlock, locked, err := lockPremiumQueue(context.Background())
+	if err != nil {
+		log.Printf("premium queue lock failed: %v", err)
+		return
+	}
+	if !locked {
+		log.Printf("premium queue: another pass is running, skipping")
+		return
+	}
+	defer unlock()
+
+	now := time.Now()
+	var bookings []models.PremiumBooking
+	if err := db.DB.Where("status = ? AND starts_at <= ?", models.PremiumBookingScheduled, now).
+		Order("starts_at ASC, id ASC").
+		Find(&bookings).Error; err != nil {
+		log.Printf("premium queue scan failed: %v", err)
+		return
+	}
+
+	full := make(map[string]bool)
+	for _, booking := range bookings {
+		if full[booking.Category] {
+			continue
+		}
+
+		ad, activated, err := activatePremiumBooking(booking.ID, now)
+		if err != nil {
+			log.Printf("premium: failed to activate booking %d: %v", booking.ID, err)
+			continue
+		}
+		if !activated {
+			if ad.ID != 0 && ad.Category == booking.Category {
+				full[booking.Category] = true
+			}
+			continue
+		}
+
+		if bot == nil {
+			continue
+		}
+		publishAdToChannel(bot, &ad, false)
+		if ownerID := ownerTelegramID(ad); ownerID != 0 {
+			until := ad.ExpiresAt
+			if ad.PremiumUntil != nil {
+				until = *ad.PremiumUntil
+			}
+			locale := ownerLocale(ad)
+			notifyUser(bot, ownerID, i18n.T(locale, "owner.premium_started", ad.Title, formatDateTime(locale, until)))
+		}
+	}
+}
+
+// lockPremiumQueue пробует взять сессионную advisory-блокировку очереди на отдельном соединении,
+// чтобы два прохода на разных экземплярах не раздавали места одновременно и не нарушали порядок броней.
+// Если блокировку держит другой проход, возвращает locked=false не дожидаясь его.
+func lockPremiumQueue(ctx context.Context) (unlock func(), locked bool, err error) {
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return nil, false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", premiumQueueLockKey).Scan(&locked); err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+	if !locked {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	return func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", premiumQueueLockKey); err != nil {
+			log.Printf("premium queue unlock failed: %v", err)
+		}
+		conn.Close()
+	}, true, nil
+}
+
+// activatePremiumBooking применяет бронь, если в категории есть место. Бронь для снятого
+// или истёкшего объявления отменяется. Возвращает объявление (пустое, если бронь отменена).
+func activatePremiumBooking(bookingID uint, now time.Time) (ad models.Ad, activated bool, err error) {
+	err = db.DB.Transaction(func(tx *gorm.DB) error {
+		var booking models.PremiumBooking
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&booking, bookingID).Error; err != nil {
+			return err
+		}
+		if booking.Status != models.PremiumBookingScheduled {
+			return nil
+		}
+
+		if err := withOwner(tx).First(&ad, booking.AdID).Error; err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+		if ad.ID == 0 || ad.Status != models.AdStatusActive || !ad.ExpiresAt.After(now) {
+			ad = models.Ad{}
+			return tx.Model(&booking).Update("status", models.PremiumBookingCancelled).Error
+		}
+
+		holdsSlot := ad.IsPremium && (ad.PremiumUntil == nil || ad.PremiumUntil.After(now))
+		if !holdsSlot {
+			if err := lockPremiumCategory(tx, ad.Category); err != nil {
+				return err
+			}
+			free, err := premiumSlotFree(tx, ad.Category, &ad.ID)
+			if err != nil {
+				return err
+			}
+			if !free {
+				return nil
+			}
+		}
+
+		grantPremium(&ad, booking.Days, now)
+		if err := tx.Save(&ad).Error; err != nil {
+			return err
+		}
+
+		activated = true
+		return tx.Model(&booking).Updates(map[string]interface{}{
+			"status":       models.PremiumBookingActivated,
+			"activated_at": now,
+		}).Error
+	})
+	return ad, activated, err
+}
+
+// premiumCalendarEntry — занятое или ожидаемое премиум-место
+type premiumCalendarEntry struct {
+	AdID      uint       `json:"ad_id"`
+	Title     string     `json:"title"`
+	BookingID uint       `json:"booking_id,omitempty"`
+	StartsAt  *time.Time `json:"starts_at,omitempty"` // прогноз начала для брони в очереди
+	EndsAt    time.Time  `json:"ends_at"`
+}
+
+// premiumCalendar — занятость премиум-мест категории: текущие и прогноз по очереди
+type premiumCalendar struct {
+	Category string                 `json:"category"`
+	Slots    int                    `json:"slots"`
+	Active   []premiumCalendarEntry `json:"active"`
+	Upcoming []premiumCalendarEntry `json:"upcoming"`
+}
+
+// FreeAt возвращает момент, когда освободится ближайшее место (now, если место уже есть)
+func (c premiumCalendar) FreeAt(now time.Time) time.Time {
+	if len(c.Active) < c.Slots {
+		return now
+	}
+	freeAt := time.Time{}
+	for _, entry := range c.Active {
+		if freeAt.IsZero() || entry.EndsAt.Before(freeAt) {
+			freeAt = entry.EndsAt
+		}
+	}
+	return freeAt
+}
+
+// loadPremiumCalendar строит занятость мест по категориям. Начало броней в очереди —
+// прогноз: бронь занимает первое место, которое освободится не раньше её StartsAt.
+func loadPremiumCalendar(now time.Time) ([]premiumCalendar, error) {
+	var premiumAds []models.Ad
+	if err := db.DB.Where("status = ? AND is_premium = ? AND expires_at > ?", models.AdStatusActive, true, now).
+		Order("expires_at ASC").
+		Find(&premiumAds).Error; err != nil {
+		return nil, err
+	}
+
+	var bookings []models.PremiumBooking
+	if err := db.DB.Where("status = ?", models.PremiumBookingScheduled).
+		Order("starts_at ASC, id ASC").
+		Find(&bookings).Error; err != nil {
+		return nil, err
+	}
+
+	adIDs := make([]uint, 0, len(bookings))
+	for _, booking := range bookings {
+		adIDs = append(adIDs, booking.AdID)
+	}
+	bookedAds := make(map[uint]models.Ad)
+	if len(adIDs) > 0 {
+		var ads []models.Ad
+		if err := db.DB.Where("id IN ?", adIDs).Find(&ads).Error; err != nil {
+			return nil, err
+		}
+		for _, ad := range ads {
+			bookedAds[ad.ID] = ad
+		}
+	}
+
+	categories := activeCategoryKeys()
+	calendars := make([]premiumCalendar, 0, len(categories))
+	for _, category := range categories {
+		calendar := premiumCalendar{
+			Category: category,
+			Slots:    premiumSlots(category),
+			Active:   []premiumCalendarEntry{},
+			Upcoming: []premiumCalendarEntry{},
+		}
+
+		var freeAt []time.Time
+		for _, ad := range premiumAds {
+			if ad.Category != category {
+				continue
+			}
+			endsAt := ad.ExpiresAt
+			if ad.PremiumUntil != nil && ad.PremiumUntil.Before(endsAt) {
+				endsAt = *ad.PremiumUntil
+			}
+			calendar.Active = append(calendar.Active, premiumCalendarEntry{
+				AdID:   ad.ID,
+				Title:  ad.Title,
+				EndsAt: endsAt,
+			})
+			freeAt = append(freeAt, endsAt)
+		}
+		for i := len(calendar.Active); i < calendar.Slots; i++ {
+			freeAt = append(freeAt, now)
+		}
+
+		for _, booking := range bookings {
+			if booking.Category != category || len(freeAt) == 0 {
+				continue
+			}
+			ad := bookedAds[booking.AdID]
+
+			sort.Slice(freeAt, func(i, j int) bool { return freeAt[i].Before(freeAt[j]) })
+			startsAt := freeAt[0]
+			if booking.StartsAt.After(startsAt) {
+				startsAt = booking.StartsAt
+			}
+			endsAt := ad.ExpiresAt
+			if booking.Days > 0 {
+				endsAt = startsAt.Add(time.Duration(booking.Days) * 24 * time.Hour)
+			}
+			if endsAt.Before(startsAt) {
+				endsAt = startsAt
+			}
+			freeAt[0] = endsAt
+
+			calendar.Upcoming = append(calendar.Upcoming, premiumCalendarEntry{
+				AdID:      booking.AdID,
+				Title:     ad.Title,
+				BookingID: booking.ID,
+				StartsAt:  &startsAt,
+				EndsAt:    endsAt,
+			})
+		}
+
+		calendars = append(calendars, calendar)
+	}
+	return calendars, nil
+}
+
+func findPremiumCalendar(calendars []premiumCalendar, category string) premiumCalendar {
+	for _, calendar := range calendars {
+		if calendar.Category == category {
+			return calendar
+		}
+	}
+	return premiumCalendar{Category: category, Slots: premiumSlots(category)}
+}
+
+// GetPremiumCalendar отдаёт менеджеру занятость премиум-мест и очередь броней
+func GetPremiumCalendar(c *gin.Context) {
+	calendars, err := loadPremiumCalendar(time.Now())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "error.premium_calendar_unavailable")
+		return
+	}
+	c.JSON(http.StatusOK, calendars)
+}
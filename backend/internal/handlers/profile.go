@@ -15,30 +15,35 @@ import (
 func GetProfileAds(c *gin.Context) {
 	username := strings.TrimSpace(c.Param("username"))
 	if username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "username parameter is required"})
+		respondError(c, http.StatusBadRequest, "error.username_required")
 		return
 	}
 
 	username = strings.TrimPrefix(username, "@")
 
 	var ads []models.Ad
-	if err := db.DB.
+	if err := withOwner(db.DB).
 		Where("LOWER(username) = LOWER(?)", username).
 		Order(gorm.Expr("CASE WHEN status = ? THEN 0 WHEN status = ? THEN 1 ELSE 2 END, updated_at DESC", models.AdStatusActive, models.AdStatusExpired)).
 		Find(&ads).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch profile ads"})
+		respondError(c, http.StatusInternalServerError, "error.profile_unavailable")
 		return
 	}
 
 	now := time.Now()
-	response := make([]AdView, 0, len(ads))
+	userID, _ := contextUserID(c)
+	visible := ads[:0]
 	for _, ad := range ads {
 		// Ensure status reflects current expiration
 		if ad.Status == models.AdStatusActive && ad.ExpiresAt.Before(now) {
 			ad.Status = models.AdStatusExpired
 		}
-		response = append(response, buildAdView(ad))
+		// Снятые и истёкшие объявления, как и в GetAd, видит только владелец
+		if ad.Status != models.AdStatusActive && !isAdOwner(ad, userID) {
+			continue
+		}
+		visible = append(visible, ad)
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, buildAdViews(visible, userID))
 }
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm/clause"
+)
+
+// defaultReminderStages — за сколько до окончания срока напоминать владельцу
+var defaultReminderStages = []time.Duration{72 * time.Hour, 24 * time.Hour, time.Hour}
+
+// reminderStages читает EXPIRY_REMINDERS (например "3d,24h,1h") и возвращает этапы
+// по убыванию; некорректное значение заменяется значением по умолчанию
+func reminderStages() []time.Duration {
+	raw := strings.TrimSpace(os.Getenv("EXPIRY_REMINDERS"))
+	if raw == "" {
+		return defaultReminderStages
+	}
+
+	var stages []time.Duration
+	seen := make(map[time.Duration]bool)
+	for _, part := range strings.Split(raw, ",") {
+		lead, err := parseReminderLead(part)
+		if err != nil {
+			log.Printf("reminders: invalid EXPIRY_REMINDERS=%q (%v), using defaults", raw, err)
+			return defaultReminderStages
+		}
+		if !seen[lead] {
+			seen[lead] = true
+			stages = append(stages, lead)
+		}
+	}
+	sort.Slice(stages, func(i, j int) bool { return stages[i] > stages[j] })
+	return stages
+}
+
+// parseReminderLead разбирает длительность в формате time.ParseDuration или в днях ("3d")
+func parseReminderLead(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	var lead time.Duration
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		count, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid stage %q", raw)
+		}
+		lead = time.Duration(count) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return 0, fmt.Errorf("invalid stage %q", raw)
+		}
+		lead = parsed
+	}
+	if lead < time.Minute {
+		return 0, fmt.Errorf("stage %q is shorter than a minute", raw)
+	}
+	return lead, nil
+}
+
+// dueReminderStage возвращает ближайший к окончанию этап, время которого уже наступило
+func dueReminderStage(expiresAt, now time.Time, stages []time.Duration) (time.Duration, bool) {
+	for i := len(stages) - 1; i >= 0; i-- {
+		if !expiresAt.Add(-stages[i]).After(now) {
+			return stages[i], true
+		}
+	}
+	return 0, false
+}
+
+// formatReminderLead — оставшееся время в самых крупных целых единицах: «3 дня», «5 часов», «30 минут»
+func formatReminderLead(locale string, lead time.Duration) string {
+	switch {
+	case lead >= 24*time.Hour:
+		days := int(lead.Round(24*time.Hour) / (24 * time.Hour))
+		return i18n.N(locale, "unit.days", days, days)
+	case lead >= time.Hour:
+		hours := int(lead.Round(time.Hour) / time.Hour)
+		return i18n.N(locale, "unit.hours", hours, hours)
+	default:
+		minutes := int(lead.Round(time.Minute) / time.Minute)
+		return i18n.N(locale, "unit.minutes", minutes, minutes)
+	}
+}
+
+// processPreExpiry напоминает владельцам о скором окончании срока. Если объявление
+// пропустило несколько этапов (например, размещено на 1 день), отправляется одно
+// напоминание — для ближайшего этапа.
+func processPreExpiry(bot *tgbotapi.BotAPI) error {
+	stages := reminderStages()
+	now := time.Now()
+
+	var ads []models.Ad
+	if err := withOwner(db.DB).
+		Where("status = ? AND expires_at > ? AND expires_at <= ?", models.AdStatusActive, now, now.Add(stages[0])).
+		Find(&ads).Error; err != nil {
+		return fmt.Errorf("pre-expiry scan failed: %w", err)
+	}
+	if len(ads) == 0 {
+		return nil
+	}
+
+	adIDs := make([]uint, 0, len(ads))
+	for _, ad := range ads {
+		adIDs = append(adIDs, ad.ID)
+	}
+	var sent []models.AdNotification
+	if err := db.DB.Where("ad_id IN ? AND kind = ?", adIDs, models.AdNotificationExpiryReminder).Find(&sent).Error; err != nil {
+		return fmt.Errorf("pre-expiry notifications scan failed: %w", err)
+	}
+
+	for _, ad := range ads {
+		stage, ok := dueReminderStage(ad.ExpiresAt, now, stages)
+		if !ok || reminderSent(sent, ad, stage) || autoRenewActive(ad, ad.ExpiresAt) {
+			continue
+		}
+
+		locale := ownerLocale(ad)
+		text := i18n.T(locale, "owner.expiry_reminder",
+			ad.Title, formatReminderLead(locale, ad.ExpiresAt.Sub(now)), formatDateTime(locale, ad.ExpiresAt), managerHelpLink)
+		notifyAdOwner(bot, ad, models.AdNotificationExpiryReminder, stage, text, ownerActionsKeyboard(locale, ad.ID, true))
+	}
+
+	notifyFavoritesExpiring(bot, ads)
+	return nil
+}
+
+// reminderSent — для текущего срока объявления уже было напоминание этого или более позднего этапа
+func reminderSent(sent []models.AdNotification, ad models.Ad, stage time.Duration) bool {
+	for _, notification := range sent {
+		if notification.AdID == ad.ID && notification.ExpiresAt.Equal(ad.ExpiresAt) &&
+			notification.LeadSeconds <= int64(stage/time.Second) {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyAdOwner ставит уведомление владельцу в очередь и записывает его в ad_notifications.
+// Если поставить не удалось, запись не создаётся — напоминание повторится при следующем запуске.
+func notifyAdOwner(bot *tgbotapi.BotAPI, ad models.Ad, kind string, lead time.Duration, text string, keyboard tgbotapi.InlineKeyboardMarkup) bool {
+	ownerID := ownerTelegramID(ad)
+	if ownerID == 0 {
+		return false
+	}
+
+	msg := tgbotapi.NewMessage(ownerID, text)
+	msg.ReplyMarkup = keyboard
+	if err := enqueueMessage(bot, msg); err != nil {
+		log.Printf("failed to notify owner %d of ad %d: %v", ownerID, ad.ID, err)
+		return false
+	}
+
+	notification := models.AdNotification{
+		AdID:        ad.ID,
+		Kind:        kind,
+		LeadSeconds: int64(lead / time.Second),
+		ExpiresAt:   ad.ExpiresAt,
+	}
+	if err := db.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&notification).Error; err != nil {
+		log.Printf("failed to record %s notification for ad %d: %v", kind, ad.ID, err)
+	}
+	return true
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"strings"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errRevisionNotFound = i18n.NewError("manager.error.revision_not_found")
+
+// snapshotAd снимает версию содержимого объявления
+func snapshotAd(ad models.Ad, version int, authorID int64) models.AdRevision {
+	return models.AdRevision{
+		AdID:      ad.ID,
+		Version:   version,
+		AuthorID:  authorID,
+		OwnerID:   ad.OwnerID,
+		Username:  ad.Username,
+		Title:     ad.Title,
+		Desc:      ad.Desc,
+		PhotoID:   ad.PhotoID,
+		PhotoPath: ad.PhotoPath,
+		Category:  ad.Category,
+		Mode:      ad.Mode,
+		Tag:       ad.Tag,
+		Price:     ad.Price,
+	}
+}
+
+// applyRevision возвращает содержимое объявления к версии
+func applyRevision(ad *models.Ad, revision models.AdRevision) {
+	ad.OwnerID = revision.OwnerID
+	ad.Owner = nil
+	ad.Username = revision.Username
+	ad.Title = revision.Title
+	ad.Desc = revision.Desc
+	ad.PhotoID = revision.PhotoID
+	ad.PhotoPath = revision.PhotoPath
+	ad.Category = revision.Category
+	ad.Mode = revision.Mode
+	ad.Tag = revision.Tag
+	ad.Price = revision.Price
+}
+
+// ensureBaseRevision сохраняет текущее состояние объявления как первую версию, если истории
+// ещё нет (объявления, созданные до появления истории). Вызывать до перезаписи объявления.
+func ensureBaseRevision(tx *gorm.DB, adID uint) error {
+	var count int64
+	if err := tx.Model(&models.AdRevision{}).Where("ad_id = ?", adID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var current models.Ad
+	if err := tx.First(&current, adID).Error; err != nil {
+		return err
+	}
+	base := snapshotAd(current, 1, 0)
+	base.CreatedAt = current.UpdatedAt
+	return tx.Create(&base).Error
+}
+
+// saveAdRevision записывает сохранённое объявление как следующую версию
+func saveAdRevision(tx *gorm.DB, ad models.Ad, authorID int64, restoredFrom *int) error {
+	var last int
+	if err := tx.Model(&models.AdRevision{}).Where("ad_id = ?", ad.ID).
+		Select("COALESCE(MAX(version), 0)").Scan(&last).Error; err != nil {
+		return err
+	}
+
+	revision := snapshotAd(ad, last+1, authorID)
+	revision.RestoredFrom = restoredFrom
+	return tx.Create(&revision).Error
+}
+
+// restoreAdRevision откатывает содержимое объявления к версии revisionID. Откат сам
+// сохраняется новой версией, поэтому его тоже можно отменить.
+func restoreAdRevision(adID, revisionID uint, authorID int64) (models.Ad, models.AdRevision, error) {
+	var ad models.Ad
+	var revision models.AdRevision
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ad, adID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ? AND ad_id = ?", revisionID, adID).First(&revision).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errRevisionNotFound
+			}
+			return err
+		}
+
+		applyRevision(&ad, revision)
+		if err := tx.Omit("Owner").Save(&ad).Error; err != nil {
+			return err
+		}
+		return saveAdRevision(tx, ad, authorID, &revision.Version)
+	})
+	return ad, revision, err
+}
+
+// loadAdRevisions возвращает последние версии объявления, новые первыми
+func loadAdRevisions(adID uint, limit int) ([]models.AdRevision, error) {
+	var revisions []models.AdRevision
+	err := db.DB.Where("ad_id = ?", adID).Order("version DESC").Limit(limit).Find(&revisions).Error
+	return revisions, err
+}
+
+// revisionDiff перечисляет на языке locale поля, изменённые в версии cur по сравнению с prev
+func revisionDiff(locale string, prev, cur models.AdRevision) []string {
+	var changes []string
+	if prev.Title != cur.Title {
+		changes = append(changes, i18n.T(locale, "manager.history.change.title", truncate(prev.Title, 40), truncate(cur.Title, 40)))
+	}
+	if prev.Desc != cur.Desc {
+		changes = append(changes, i18n.T(locale, "manager.history.change.desc", truncate(prev.Desc, 40), truncate(cur.Desc, 40)))
+	}
+	if prev.PhotoID != cur.PhotoID {
+		switch {
+		case cur.PhotoID == "":
+			changes = append(changes, i18n.T(locale, "manager.history.change.photo_removed"))
+		case prev.PhotoID == "":
+			changes = append(changes, i18n.T(locale, "manager.history.change.photo_added"))
+		default:
+			changes = append(changes, i18n.T(locale, "manager.history.change.photo_replaced"))
+		}
+	}
+	if prev.Username != cur.Username {
+		changes = append(changes, i18n.T(locale, "manager.history.change.contact", prev.Username, cur.Username))
+	}
+	if !sameOwner(prev.OwnerID, cur.OwnerID) {
+		changes = append(changes, i18n.T(locale, "manager.history.change.owner"))
+	}
+	if prev.Price != cur.Price {
+		changes = append(changes, i18n.T(locale, "manager.history.change.price", formatPrice(locale, prev.Price), formatPrice(locale, cur.Price)))
+	}
+	if prev.Category != cur.Category || prev.Mode != cur.Mode || prev.Tag != cur.Tag {
+		changes = append(changes, i18n.T(locale, "manager.history.change.section", revisionSection(prev), revisionSection(cur)))
+	}
+	return changes
+}
+
+func sameOwner(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func revisionSection(revision models.AdRevision) string {
+	parts := []string{categoryName(revision.Category)}
+	if _, single := singleMode(revision.Category); !single && revision.Mode != "" {
+		parts = append(parts, modeName(revision.Category, revision.Mode))
+	}
+	if revision.Tag != "" {
+		parts = append(parts, tagName(revision.Category, revision.Tag))
+	}
+	return strings.Join(parts, " / ")
+}
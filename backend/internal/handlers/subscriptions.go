@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxSavedSearches — сколько сохранённых поисков может быть у одного пользователя
+	maxSavedSearches = 10
+	maxSearchQuery   = 128
+)
+
+// savedSearchLockClass — первый ключ advisory-блокировки сохранённых поисков пользователя (см. lockUserLimit)
+const savedSearchLockClass int32 = 0x73737263 // "ssrc"
+
+var (
+	errSearchInvalid = i18n.NewError("error.search_invalid")
+	errSearchLimit   = i18n.NewError("error.search_limit")
+)
+
+type savedSearchRequest struct {
+	Category string `json:"category"`
+	Mode     string `json:"mode"`
+	Tag      string `json:"tag"`
+	Query    string `json:"query"`
+	PriceMin *int64 `json:"price_min"`
+	PriceMax *int64 `json:"price_max"`
+}
+
+// normalize проверяет рубрики по справочнику и приводит запрос к виду для сохранения
+func (req savedSearchRequest) normalize() (models.SavedSearch, error) {
+	search := models.SavedSearch{
+		Category: strings.TrimSpace(req.Category),
+		Mode:     strings.TrimSpace(req.Mode),
+		Tag:      strings.TrimSpace(req.Tag),
+		Query:    strings.Join(strings.Fields(req.Query), " "),
+		PriceMin: req.PriceMin,
+		PriceMax: req.PriceMax,
+	}
+	if strings.EqualFold(search.Tag, "all") {
+		search.Tag = ""
+	}
+
+	current := currentTaxonomy()
+	if category, ok := current.category(search.Category); !ok || !category.Active {
+		return search, errSearchInvalid
+	}
+	if search.Mode != "" {
+		if _, ok := current.option(search.Category, models.TaxonomyKindMode, search.Mode); !ok {
+			return search, errSearchInvalid
+		}
+	}
+	if search.Tag != "" {
+		if _, ok := current.option(search.Category, models.TaxonomyKindTag, search.Tag); !ok {
+			return search, errSearchInvalid
+		}
+	}
+	if utf8.RuneCountInString(search.Query) > maxSearchQuery {
+		return search, errSearchInvalid
+	}
+	for _, price := range []*int64{search.PriceMin, search.PriceMax} {
+		if price != nil && (*price < 0 || *price > maxAdPrice) {
+			return search, errSearchInvalid
+		}
+	}
+	if search.PriceMin != nil && search.PriceMax != nil && *search.PriceMin > *search.PriceMax {
+		return search, errSearchInvalid
+	}
+	return search, nil
+}
+
+// matchesSavedSearch проверяет объявление на соответствие поиску. Объявления без цены
+// не подходят под поиск с ценовым диапазоном; в тексте должны встретиться все слова запроса.
+func matchesSavedSearch(search models.SavedSearch, ad models.Ad) bool {
+	if search.Category != ad.Category ||
+		(search.Mode != "" && search.Mode != ad.Mode) ||
+		(search.Tag != "" && search.Tag != ad.Tag) {
+		return false
+	}
+	if search.PriceMin != nil || search.PriceMax != nil {
+		if ad.Price <= 0 ||
+			(search.PriceMin != nil && ad.Price < *search.PriceMin) ||
+			(search.PriceMax != nil && ad.Price > *search.PriceMax) {
+			return false
+		}
+	}
+	text := strings.ToLower(ad.Title + " " + ad.Desc)
+	for _, word := range strings.Fields(strings.ToLower(search.Query)) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetSubscriptions возвращает сохранённые поиски текущего пользователя (GET /api/subscriptions)
+func GetSubscriptions(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		respondError(c, http.StatusUnauthorized, "error.auth_required")
+		return
+	}
+
+	searches := []models.SavedSearch{}
+	if err := db.DB.Where("user_id = ?", userID).Order("id").Find(&searches).Error; err != nil {
+		respondError(c, http.StatusInternalServerError, "error.searches_unavailable")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"subscriptions": searches, "limit": maxSavedSearches})
+}
+
+// CreateSubscription сохраняет поиск (POST /api/subscriptions)
+func CreateSubscription(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		respondError(c, http.StatusUnauthorized, "error.auth_required")
+		return
+	}
+
+	var req savedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "error.search_invalid")
+		return
+	}
+	search, err := req.normalize()
+	if err != nil {
+		respondLocalizedError(c, http.StatusBadRequest, err, "error.search_invalid")
+		return
+	}
+
+	search.UserID = userID
+	err = db.DB.Transaction(func(tx *gorm.DB) error {
+		// Лимит проверяется под блокировкой пользователя, иначе параллельные запросы превысят maxSavedSearches
+		if err := lockUserLimit(tx, savedSearchLockClass, userID); err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&models.SavedSearch{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= maxSavedSearches {
+			return errSearchLimit
+		}
+		return tx.Create(&search).Error
+	})
+	switch {
+	case errors.Is(err, errSearchLimit):
+		respondLocalizedError(c, http.StatusConflict, err, "error.search_limit", gin.H{"limit": maxSavedSearches})
+		return
+	case err != nil:
+		log.Printf("saved searches: failed to save search for user %d: %v", userID, err)
+		respondError(c, http.StatusInternalServerError, "error.searches_unavailable")
+		return
+	}
+	c.JSON(http.StatusCreated, search)
+}
+
+// DeleteSubscription удаляет сохранённый поиск пользователя (DELETE /api/subscriptions/:id)
+func DeleteSubscription(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		respondError(c, http.StatusUnauthorized, "error.auth_required")
+		return
+	}
+
+	searchID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "error.search_not_found")
+		return
+	}
+
+	deleted, err := deleteSavedSearch(uint(searchID), userID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "error.searches_unavailable")
+		return
+	}
+	if !deleted {
+		respondError(c, http.StatusNotFound, "error.search_not_found")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// deleteSavedSearch удаляет поиск, только если он принадлежит пользователю
+func deleteSavedSearch(searchID uint, userID int64) (bool, error) {
+	result := db.DB.Where("id = ? AND user_id = ?", searchID, userID).Delete(&models.SavedSearch{})
+	return result.RowsAffected > 0, result.Error
+}
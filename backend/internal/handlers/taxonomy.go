@@ -0,0 +1,391 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/i18n"
+	"youtube-market/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// taxonomyTTL — как долго держать рубрики в памяти. Правки менеджера сбрасывают кэш
+// сразу, другие экземпляры приложения подхватывают их не позже чем через TTL.
+const taxonomyTTL = time.Minute
+
+var taxonomyKeyPattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+var (
+	errTaxonomyKey      = i18n.NewError("manager.error.taxonomy_key")
+	errTaxonomyLabel    = i18n.NewError("manager.error.taxonomy_label")
+	errTaxonomyExists   = i18n.NewError("manager.error.taxonomy_exists")
+	errTaxonomyNotFound = i18n.NewError("manager.error.taxonomy_not_found")
+	errTaxonomyLastMode = i18n.NewError("manager.error.taxonomy_last_mode")
+)
+
+// taxonomy — все рубрики (в том числе неактивные), отсортированные по Position
+type taxonomy struct {
+	Categories []models.TaxonomyCategory
+	Options    []models.TaxonomyOption
+}
+
+var taxonomyCache = struct {
+	sync.Mutex
+	value    taxonomy
+	loadedAt time.Time
+}{}
+
+// currentTaxonomy возвращает рубрики из кэша, перечитывая их из базы по истечении TTL.
+// Если база недоступна, используется последняя загруженная версия.
+func currentTaxonomy() taxonomy {
+	taxonomyCache.Lock()
+	defer taxonomyCache.Unlock()
+
+	if !taxonomyCache.loadedAt.IsZero() && time.Since(taxonomyCache.loadedAt) < taxonomyTTL {
+		return taxonomyCache.value
+	}
+
+	var value taxonomy
+	if err := db.DB.Order("position, key").Find(&value.Categories).Error; err != nil {
+		log.Printf("taxonomy: failed to load categories: %v", err)
+		return taxonomyCache.value
+	}
+	if err := db.DB.Order("category, kind, position, key").Find(&value.Options).Error; err != nil {
+		log.Printf("taxonomy: failed to load options: %v", err)
+		return taxonomyCache.value
+	}
+
+	taxonomyCache.value = value
+	taxonomyCache.loadedAt = time.Now()
+	return value
+}
+
+func invalidateTaxonomy() {
+	taxonomyCache.Lock()
+	taxonomyCache.loadedAt = time.Time{}
+	taxonomyCache.Unlock()
+}
+
+func (t taxonomy) category(key string) (models.TaxonomyCategory, bool) {
+	for _, category := range t.Categories {
+		if category.Key == key {
+			return category, true
+		}
+	}
+	return models.TaxonomyCategory{}, false
+}
+
+func (t taxonomy) option(category, kind, key string) (models.TaxonomyOption, bool) {
+	for _, option := range t.Options {
+		if option.Category == category && option.Kind == kind && option.Key == key {
+			return option, true
+		}
+	}
+	return models.TaxonomyOption{}, false
+}
+
+// activeCategories — категории, которые предлагаются при создании объявления
+func (t taxonomy) activeCategories() []models.TaxonomyCategory {
+	var categories []models.TaxonomyCategory
+	for _, category := range t.Categories {
+		if category.Active {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// activeOptions — активные режимы или теги категории
+func (t taxonomy) activeOptions(category, kind string) []models.TaxonomyOption {
+	var options []models.TaxonomyOption
+	for _, option := range t.Options {
+		if option.Category == category && option.Kind == kind && option.Active {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+// categoryName — название категории; для неизвестного ключа возвращается сам ключ
+func categoryName(key string) string {
+	if category, ok := currentTaxonomy().category(key); ok {
+		return category.Label
+	}
+	return key
+}
+
+// modeName — название режима; для неизвестного ключа возвращается сам ключ
+func modeName(category, mode string) string {
+	if option, ok := currentTaxonomy().option(category, models.TaxonomyKindMode, mode); ok {
+		return option.Label
+	}
+	return mode
+}
+
+// tagName — название тега; для неизвестного ключа возвращается сам ключ
+func tagName(category, tag string) string {
+	if option, ok := currentTaxonomy().option(category, models.TaxonomyKindTag, tag); ok {
+		return option.Label
+	}
+	return tag
+}
+
+// singleMode — у категории ровно один режим, поэтому выбирать его не нужно
+// (так устроена категория «Другое»). Возвращает этот режим.
+func singleMode(category string) (string, bool) {
+	modes := currentTaxonomy().activeOptions(category, models.TaxonomyKindMode)
+	if len(modes) == 1 {
+		return modes[0].Key, true
+	}
+	return "", false
+}
+
+// selectableCategories — активные категории, в которых есть хотя бы один активный режим.
+// В категории без режимов объявление сохранить нельзя, поэтому менеджеру она не предлагается.
+func selectableCategories() []models.TaxonomyCategory {
+	current := currentTaxonomy()
+	var categories []models.TaxonomyCategory
+	for _, category := range current.activeCategories() {
+		if len(current.activeOptions(category.Key, models.TaxonomyKindMode)) > 0 {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// activeCategoryKeys — ключи активных категорий в порядке отображения
+func activeCategoryKeys() []string {
+	var keys []string
+	for _, category := range currentTaxonomy().activeCategories() {
+		keys = append(keys, category.Key)
+	}
+	return keys
+}
+
+type taxonomyOptionView struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+}
+
+type taxonomyCategoryView struct {
+	Key   string               `json:"key"`
+	Label string               `json:"label"`
+	Modes []taxonomyOptionView `json:"modes"`
+	Tags  []taxonomyOptionView `json:"tags"`
+}
+
+func buildOptionViews(options []models.TaxonomyOption) []taxonomyOptionView {
+	views := make([]taxonomyOptionView, 0, len(options))
+	for _, option := range options {
+		views = append(views, taxonomyOptionView{Key: option.Key, Label: option.Label})
+	}
+	return views
+}
+
+// GetTaxonomy возвращает активные категории с их режимами и тегами для Mini App
+func GetTaxonomy(c *gin.Context) {
+	current := currentTaxonomy()
+	categories := make([]taxonomyCategoryView, 0, len(current.Categories))
+	for _, category := range current.activeCategories() {
+		categories = append(categories, taxonomyCategoryView{
+			Key:   category.Key,
+			Label: category.Label,
+			Modes: buildOptionViews(current.activeOptions(category.Key, models.TaxonomyKindMode)),
+			Tags:  buildOptionViews(current.activeOptions(category.Key, models.TaxonomyKindTag)),
+		})
+	}
+
+	c.Header("Cache-Control", "public, max-age=60")
+	c.JSON(http.StatusOK, gin.H{
+		"categories": categories,
+		"labels":     taxonomyLabels(current),
+	})
+}
+
+type taxonomyLabelsView struct {
+	Categories map[string]string            `json:"categories"`
+	Modes      map[string]map[string]string `json:"modes"`
+	Tags       map[string]map[string]string `json:"tags"`
+}
+
+// taxonomyLabels — названия всех рубрик, включая скрытые, чтобы показывать старые объявления
+func taxonomyLabels(current taxonomy) taxonomyLabelsView {
+	labels := taxonomyLabelsView{
+		Categories: make(map[string]string, len(current.Categories)),
+		Modes:      make(map[string]map[string]string),
+		Tags:       make(map[string]map[string]string),
+	}
+	for _, category := range current.Categories {
+		labels.Categories[category.Key] = category.Label
+	}
+	for _, option := range current.Options {
+		target := labels.Tags
+		if option.Kind == models.TaxonomyKindMode {
+			target = labels.Modes
+		}
+		if target[option.Category] == nil {
+			target[option.Category] = make(map[string]string)
+		}
+		target[option.Category][option.Key] = option.Label
+	}
+	return labels
+}
+
+func validateTaxonomyLabel(label string) error {
+	if label == "" || len([]rune(label)) > 64 {
+		return errTaxonomyLabel
+	}
+	return nil
+}
+
+// addTaxonomyCategory добавляет активную категорию в конец списка
+func addTaxonomyCategory(key, label string) error {
+	if !taxonomyKeyPattern.MatchString(key) {
+		return errTaxonomyKey
+	}
+	if err := validateTaxonomyLabel(label); err != nil {
+		return err
+	}
+	defer invalidateTaxonomy()
+
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.TaxonomyCategory{}).Where("key = ?", key).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errTaxonomyExists
+		}
+		var last int
+		if err := tx.Model(&models.TaxonomyCategory{}).Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.TaxonomyCategory{Key: key, Label: label, Position: last + 1, Active: true}).Error
+	})
+}
+
+// addTaxonomyOption добавляет активный режим или тег в конец списка категории
+func addTaxonomyOption(category, kind, key, label string) error {
+	if !taxonomyKeyPattern.MatchString(key) {
+		return errTaxonomyKey
+	}
+	if err := validateTaxonomyLabel(label); err != nil {
+		return err
+	}
+	defer invalidateTaxonomy()
+
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.TaxonomyCategory{}).Where("key = ?", category).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errTaxonomyNotFound
+		}
+		if err := tx.Model(&models.TaxonomyOption{}).
+			Where("category = ? AND kind = ? AND key = ?", category, kind, key).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errTaxonomyExists
+		}
+		var last int
+		if err := tx.Model(&models.TaxonomyOption{}).Where("category = ? AND kind = ?", category, kind).
+			Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.TaxonomyOption{
+			Category: category,
+			Kind:     kind,
+			Key:      key,
+			Label:    label,
+			Position: last + 1,
+			Active:   true,
+		}).Error
+	})
+}
+
+// taxonomyTarget — категория (Kind пустой) или режим/тег категории
+type taxonomyTarget struct {
+	Kind     string
+	Category string
+	Key      string
+}
+
+func (target taxonomyTarget) query(tx *gorm.DB) *gorm.DB {
+	if target.Kind == "" {
+		return tx.Model(&models.TaxonomyCategory{}).Where("key = ?", target.Key)
+	}
+	return tx.Model(&models.TaxonomyOption{}).
+		Where("category = ? AND kind = ? AND key = ?", target.Category, target.Kind, target.Key)
+}
+
+// updateTaxonomy меняет название, активность или позицию рубрики
+func updateTaxonomy(target taxonomyTarget, updates map[string]interface{}) error {
+	if label, ok := updates["label"].(string); ok {
+		if err := validateTaxonomyLabel(label); err != nil {
+			return err
+		}
+	}
+	if active, ok := updates["active"].(bool); ok && !active && target.Kind == models.TaxonomyKindMode {
+		var others int64
+		if err := db.DB.Model(&models.TaxonomyOption{}).
+			Where("category = ? AND kind = ? AND key <> ? AND active = ?", target.Category, models.TaxonomyKindMode, target.Key, true).
+			Count(&others).Error; err != nil {
+			return err
+		}
+		if others == 0 {
+			return errTaxonomyLastMode
+		}
+	}
+	defer invalidateTaxonomy()
+
+	result := target.query(db.DB).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errTaxonomyNotFound
+	}
+	return nil
+}
+
+// moveTaxonomy ставит рубрику на позицию position (с 1) среди соседей и перенумеровывает остальные
+func moveTaxonomy(target taxonomyTarget, position int) error {
+	defer invalidateTaxonomy()
+
+	return db.DB.Transaction(func(tx *gorm.DB) error {
+		var keys []string
+		query := tx.Model(&models.TaxonomyCategory{})
+		if target.Kind != "" {
+			query = tx.Model(&models.TaxonomyOption{}).Where("category = ? AND kind = ?", target.Category, target.Kind)
+		}
+		if err := query.Order("position, key").Pluck("key", &keys).Error; err != nil {
+			return err
+		}
+
+		index := slices.Index(keys, target.Key)
+		if index < 0 {
+			return errTaxonomyNotFound
+		}
+
+		keys = slices.Delete(keys, index, index+1)
+		position = min(max(position, 1), len(keys)+1)
+		keys = slices.Insert(keys, position-1, target.Key)
+
+		for i, key := range keys {
+			sibling := taxonomyTarget{Kind: target.Kind, Category: target.Category, Key: key}
+			if err := sibling.query(tx).Update("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -8,19 +8,25 @@ import (
 )
 
 type AdView struct {
-	ID         uint      `json:"id"`
-	Username   string    `json:"username"`
-	Title      string    `json:"title"`
-	Desc       string    `json:"desc"`
-	Category   string    `json:"category"`
-	Mode       string    `json:"mode"`
-	Tag        string    `json:"tag"`
-	IsPremium  bool      `json:"is_premium"`
-	Status     string    `json:"status"`
-	ExpiresAt  time.Time `json:"expires_at"`
-	PhotoURL   string    `json:"photo_url,omitempty"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         uint         `json:"id"`
+	Username   string       `json:"username"`
+	Title      string       `json:"title"`
+	Desc       string       `json:"desc"`
+	Category   string       `json:"category"`
+	Mode       string       `json:"mode"`
+	Tag        string       `json:"tag"`
+	Price      int64        `json:"price,omitempty"`
+	Favorites  int64        `json:"favorites"`
+	IsFavorite bool         `json:"is_favorite"`
+	IsPremium  bool         `json:"is_premium"`
+	Status     string       `json:"status"`
+	ExpiresAt  time.Time    `json:"expires_at"`
+	BumpedAt   *time.Time   `json:"bumped_at,omitempty"`
+	NextBumpAt *time.Time   `json:"next_bump_at,omitempty"`
+	PhotoURL   string       `json:"photo_url,omitempty"`
+	Stats      *AdStatsView `json:"stats,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
 }
 
 func buildAdView(ad models.Ad) AdView {
@@ -32,13 +38,20 @@ func buildAdView(ad models.Ad) AdView {
 		Category:  ad.Category,
 		Mode:      ad.Mode,
 		Tag:       ad.Tag,
+		Price:     ad.Price,
 		IsPremium: ad.IsPremium,
 		Status:    ad.Status,
 		ExpiresAt: ad.ExpiresAt,
+		BumpedAt:  ad.BumpedAt,
 		CreatedAt: ad.CreatedAt,
 		UpdatedAt: ad.UpdatedAt,
 	}
 
+	if ad.Status == models.AdStatusActive {
+		next := nextBumpAt(ad)
+		view.NextBumpAt = &next
+	}
+
 	if ad.PhotoPath != "" {
 		view.PhotoURL = fmt.Sprintf("/api/ads/%d/photo", ad.ID)
 	}
@@ -72,4 +85,3 @@ func mergeAds(primary, secondary []models.Ad) []models.Ad {
 
 	return out
 }
-
@@ -0,0 +1,185 @@
+package i18n
+
+var catalogEN = map[string]string{
+	"language.name":        "English",
+	"language.prompt":      "🌐 Choose the bot language. Current: %s.",
+	"language.auto":        "Same as Telegram",
+	"language.set":         "✅ Bot language: %s.",
+	"language.save_failed": "❌ Could not save the language, please try again later.",
+
+	"unit.days.one":      "%d day",
+	"unit.days.other":    "%d days",
+	"unit.hours.one":     "%d hour",
+	"unit.hours.other":   "%d hours",
+	"unit.minutes.one":   "%d minute",
+	"unit.minutes.other": "%d minutes",
+	"unit.days_short":    "%d d",
+	"format.date":        "2006-01-02",
+	"format.datetime":    "2006-01-02 15:04",
+	"format.price_none":  "not specified",
+	"format.price":       "%s RUB",
+
+	"error.auth_required":                "authentication required",
+	"error.init_data_required":           "open the app from Telegram",
+	"error.init_data_invalid":            "could not verify Telegram data, please restart the app",
+	"error.manager_required":             "managers only",
+	"error.rate_limited":                 "too many requests, try again in a minute",
+	"error.invalid_ad_id":                "invalid ad ID",
+	"error.ad_not_found":                 "ad not found",
+	"error.photo_unavailable":            "could not load the photo",
+	"error.ads_unavailable":              "could not load ads",
+	"error.username_required":            "username is required",
+	"error.check_failed":                 "could not check the user",
+	"error.blacklist_unavailable":        "could not load the blacklist",
+	"error.federation_disabled":          "federation is disabled",
+	"error.federation_auth_required":     "peer authentication required",
+	"error.federation_cursor_invalid":    "invalid since token",
+	"error.export_format":                "format must be csv or json",
+	"error.export_failed":                "could not build the export",
+	"error.bump_failed":                  "could not bump the ad",
+	"error.renew_days":                   "renewal period must be 1, 7, 14 or 30 days",
+	"error.renew_failed":                 "could not renew the ad",
+	"error.deactivate_failed":            "could not remove the ad",
+	"error.payment_params_required":      "specify the ad, product and period",
+	"error.payments_unavailable":         "payments are temporarily unavailable",
+	"error.invoice_failed":               "could not create the invoice, please try again later",
+	"error.premium_calendar_unavailable": "could not load the premium calendar",
+	"error.profile_unavailable":          "could not load the user's ads",
+	"error.ad_not_bumpable":              "only an active ad can be bumped",
+	"error.bump_cooldown":                "the ad can be bumped again after %s",
+	"error.free_renewals_used":           "free renewals are used up — renew the ad with Stars",
+	"error.payment_not_found":            "payment not found",
+	"error.payment_not_paid":             "the payment has not been completed yet",
+	"error.ad_not_owned":                 "the ad was not found or belongs to another user",
+	"error.premium_limit":                "all premium slots are taken, please try again later",
+	"error.invalid_product":              "unknown product",
+	"error.invalid_pay_days":             "invalid period",
+	"error.ad_not_renewable":             "the ad was removed from the market — contact the manager to publish it again",
+	"error.payment_mismatched":           "payment details do not match the invoice",
+	"error.payment_failed":               "could not process the payment",
+	"error.search_invalid":               "check the category, query and price range",
+	"error.search_limit":                 "saved search limit reached",
+	"error.search_not_found":             "search not found",
+	"error.searches_unavailable":         "could not load saved searches",
+	"error.favorite_invalid":             "invalid favorite parameters",
+	"error.favorite_limit":               "too many ads in favorites",
+	"error.favorites_unavailable":        "could not load favorites",
+
+	"scam.listed":  "Warning! Scammer",
+	"scam.blocked": "Warning! Scammer (according to partner %s)",
+	"scam.warned":  "The user is flagged as a scammer by partner %s. Be careful",
+	"scam.clean":   "The user has not been seen in scam schemes",
+
+	"owner.ad_published":              "✅ Your ad “%s” is published until %s.\n\nContact %s to manage it.",
+	"owner.ad_reposted":               "Your ad “%s” is back on the market. Contact %s to manage it.",
+	"owner.ad_removed":                "Your ad “%s” was removed from the market. Contact %s to publish it again.",
+	"owner.ad_extended":               "Your ad “%s” is extended until %s.",
+	"owner.premium_started":           "⭐ Your ad “%s” got premium placement until %s.",
+	"owner.expiry_reminder":           "⏰ Your ad “%s” expires in %s — %s.\n\nRenew it with the buttons below, in the app or via %s.",
+	"owner.expired":                   "Your ad “%s” is no longer shown on the market. Renew it with the buttons below, in the app or contact %s.",
+	"owner.auto_renewed":              "🔁 The ad “%s” was automatically renewed for %s — until %s.",
+	"owner.auto_renew_last":           "This was the last automatic renewal.",
+	"owner.auto_renew_left":           "Automatic renewals left: %d.",
+	"owner.auto_renew_disabled":       "⏹ Automatic renewal of the ad “%s” is off. It stays active until %s.",
+	"owner.auto_renew_disable_failed": "❌ Could not turn off automatic renewal, please try again later.",
+	"owner.renewed":                   "✅ The ad “%s” is renewed for %s — until %s.\nFree renewals left this month: %d.",
+	"owner.removed":                   "❌ The ad “%s” was removed from the market. Contact %s to publish it again.",
+	"owner.remove_failed":             "❌ Could not remove the ad, please try again later.",
+	"owner.button.renew":              "🔄 %s",
+	"owner.button.remove":             "❌ Remove from market",
+	"owner.button.confirm_remove":     "✅ Yes, remove",
+	"owner.button.keep":               "↩️ Back",
+	"owner.button.auto_renew_off":     "⏹ Turn off auto-renewal",
+
+	"payment.usage":               "Usage: /%s <ad ID>",
+	"payment.choose_period":       "%s “%s”\nChoose the period:",
+	"payment.option":              "%s — %d ⭐",
+	"payment.product.renew":       "Ad renewal",
+	"payment.product.premium":     "Premium placement",
+	"payment.invoice_description": "“%s”: +%s",
+	"payment.price_label":         "%s for %s",
+	"payment.invoice_failed":      "❌ Could not create the invoice, please try again later.",
+	"payment.refunded_auto":       "❌ %s. Your Stars have been refunded.",
+	"payment.refund_reason_auto":  "auto-refund: %v",
+	"payment.paid":                "✅ Payment received. %s “%s” for %s. The ad is active until %s.",
+	"payment.refunded":            "You were refunded %d ⭐ for “%s”.",
+	"payment.renew_offer_button":  "⭐ %s — %d ⭐",
+
+	"check.usage":           "Usage: /check @username, /check <ID> or reply to the user's message with /check",
+	"check.failed":          "❌ Could not check the user.",
+	"check.title":           "🔎 *User check*",
+	"check.username_hidden": "ℹ️ The username is hidden — the blacklist cannot be checked.",
+	"check.listed":          "🚫 *Blacklisted*",
+	"check.reason":          "Reason: %s",
+	"check.clean":           "✅ Not found in the blacklist",
+	"check.similar":         "⚠️ Similar names in the blacklist: %s",
+	"check.no_ads":          "📋 No active ads",
+	"check.ads":             "📋 *Active ads: %d*",
+	"check.more":            "... and %d more",
+	"check.ad_until":        "(until %s)",
+	"check.inline_summary":  "%s · active ads: %d",
+	"check.inline_clean":    "Not found in the blacklist",
+
+	"guard.welcome": "🛡 *Market guard bot is on*\n\n" +
+		"I will warn you if a blacklisted user shows up in the chat.\n\n" +
+		"Admins can configure it:\n" +
+		"/guard — current settings\n" +
+		"/guard on | off — turn on or off\n" +
+		"/guard action warn | restrict | ban — only warn, restrict or ban (requires admin rights)\n" +
+		"/guard lang ru | en | uk — chat language\n" +
+		"/check @username — check a user",
+	"guard.listed":          "⚠️ *Warning!* %s is on the market blacklist.",
+	"guard.flagged":         "⚠️ *Warning!* %s: %s.",
+	"guard.careful":         "Be careful with deals.",
+	"guard.no_rights":       "_Could not apply the restriction: give the bot admin rights._",
+	"guard.banned":          "🚫 The user is banned from the chat.",
+	"guard.restricted":      "🔇 The user can no longer send messages.",
+	"guard.admins_only":     "❌ Only chat admins can change guard settings.",
+	"guard.usage":           "Usage: /guard [on | off | action warn | restrict | ban | lang ru | en | uk]",
+	"guard.save_failed":     "❌ Could not save the settings.",
+	"guard.enabled":         "on",
+	"guard.disabled":        "off",
+	"guard.action.warn":     "warning only",
+	"guard.action.restrict": "warning and mute",
+	"guard.action.ban":      "warning and ban",
+	"guard.status":          "🛡 *Chat guard*\n\nStatus: %s\nAction: %s\nLanguage: %s",
+
+	"search.alert":              "🔔 New ad matching your saved search:\n\n%s\n%s",
+	"search.button.open":        "Open ad",
+	"search.button.unsubscribe": "🔕 Unsubscribe from this search",
+	"search.unsubscribed":       "🔕 You have unsubscribed from this search. Manage other searches in the app.",
+	"search.unsubscribe_failed": "❌ Could not unsubscribe, please try again later.",
+	"search.not_found":          "This search has already been removed.",
+
+	"favorite.renewed":       "🔄 The ad “%s” from your favorites has been renewed until %s.",
+	"favorite.price_changed": "💰 The price of “%s” from your favorites has changed: %s → %s.",
+	"favorite.expiring":      "⏰ The ad “%s” from your favorites expires soon — %s.",
+	"favorite.button.mute":   "🔕 Stop notifications about this ad",
+	"favorite.muted":         "🔕 Notifications about this ad are off. It stays in your favorites.",
+	"favorite.mute_failed":   "❌ Could not turn off notifications, please try again later.",
+
+	"user.menu":              "👋 This is the marketplace bot. Here you can see your ads, check a user before a deal or request an ad placement.\n\nChoose an action:",
+	"user.button.my_ads":     "📋 My ads",
+	"user.button.check":      "🔎 Check a user",
+	"user.button.request":    "📝 Submit an ad request",
+	"user.button.open_app":   "🛒 Open the marketplace",
+	"user.button.menu":       "◀️ Menu",
+	"user.failed":            "❌ Could not load data, please try again later.",
+	"user.my_ads.empty":      "You have no ads yet. Submit a request and a manager will help you place an ad.",
+	"user.my_ads.title":      "📋 Your ads: %d",
+	"user.my_ads.more":       "… and %d more — see all ads in the app.",
+	"user.ad.active":         "✅ Active until %s",
+	"user.ad.expired":        "⏰ Expired on %s",
+	"user.ad.inactive":       "❌ Removed from the marketplace",
+	"user.ad.stats":          "👁 Views: %d · 📞 Contacts: %d",
+	"user.ad.not_found":      "❌ Ad not found.",
+	"user.check.prompt":      "🔎 Send the username to check, e.g. @username.",
+	"user.check.invalid":     "❌ Send the username as @username.",
+	"user.request.prompt":    "📝 Describe your ad in one message: what you offer or look for, the price and how to contact you. Managers will receive the request.",
+	"user.request.too_short": "❌ Please describe the ad in more detail.",
+	"user.request.sent":      "✅ Request #%d has been sent to the managers. We will message you once it is reviewed.",
+	"user.request.limit":     "⏳ You can send at most %d requests a day. Try again later or contact %s.",
+	"user.request.failed":    "❌ Could not send the request, please try again later.",
+	"user.request.accepted":  "✅ A manager has taken request #%d and will contact you soon.",
+	"user.request.rejected":  "Request #%d has been declined. You can ask questions at %s.",
+}
@@ -0,0 +1,367 @@
+package i18n
+
+// catalogManagerEN — панель менеджера, уведомления менеджерам и посты в канале
+var catalogManagerEN = map[string]string{
+	"channel.premium":     "⭐ *Premium*",
+	"channel.expires":     "⏱ Until %s",
+	"channel.button.open": "Open in the app",
+
+	"manager.request.title":         "📨 Ad request #%d\n\n👤 %s\n🆔 ID: %d\n",
+	"manager.request.no_username":   "no username",
+	"manager.request.button.accept": "✅ Accept",
+	"manager.request.button.reject": "❌ Reject",
+	"manager.request.button.check":  "🔎 Check",
+	"manager.request.button.write":  "💬 Message",
+	"manager.request.accepted":      "✅ Accepted",
+	"manager.request.rejected":      "❌ Rejected",
+	"manager.request.not_found":     "❌ Request not found.",
+	"manager.request.save_failed":   "❌ Could not save the decision on the request.",
+	"manager.request.handled":       "ℹ️ Request #%d has already been handled.",
+
+	"manager.payment.failed":        "⚠️ Could not process payment %s from %d: %v",
+	"manager.payment.not_refunded":  "⚠️ Payment #%d was neither applied nor refunded: %v",
+	"manager.payment.paid":          "💫 Payment #%d: %s of ad #%d for %s, %d ⭐ from %d",
+	"manager.refund.usage":          "Usage: /refund <payment ID> [reason]",
+	"manager.refund.invalid_id":     "❌ Invalid payment ID.",
+	"manager.refund.done":           "✅ Payment #%d refunded (%d ⭐).",
+	"manager.refund.default_reason": "refunded by a manager",
+
+	"manager.owner.renewed":        "🔄 The owner renewed ad #%d “%s” for %s (until %s).",
+	"manager.owner.deactivated":    "❌ The owner took ad #%d “%s” off the market.",
+	"manager.owner.auto_renew_off": "⏹ The owner turned off auto-renewal of ad #%d “%s”.",
+
+	"manager.button.back":          "◀️ Back",
+	"manager.button.cancel":        "◀️ Cancel",
+	"manager.button.menu":          "◀️ To menu",
+	"manager.button.skip":          "⏭ Skip",
+	"manager.button.skip_username": "⏭ Skip (no username)",
+	"manager.button.skip_user_id":  "⏭ Skip (enter the ID manually)",
+	"manager.button.yes":           "✅ Yes",
+	"manager.button.no":            "❌ No",
+	"manager.button.edit":          "✏️ Edit",
+	"manager.button.confirm":       "✅ Confirm",
+	"manager.button.save":          "✅ Save",
+	"manager.choose_button":        "👇 Choose an option with the buttons below.",
+	"manager.choose_setting":       "👇 Choose what to change with the buttons below.",
+
+	"manager.menu.title":     "📋 *Manager menu*\n\nChoose an action:",
+	"manager.menu.new_ad":    "➕ Create an ad",
+	"manager.menu.find_ad":   "🔍 Find an ad",
+	"manager.menu.blacklist": "🚫 Blacklist",
+	"manager.menu.premium":   "⭐ Premium slots",
+	"manager.menu.top_ads":   "📈 Top ads",
+	"manager.menu.stats":     "📊 Statistics",
+	"manager.menu.broadcast": "📣 Broadcast",
+
+	"manager.blacklist.title":            "🚫 *Blacklist management*",
+	"manager.blacklist.button.view":      "📋 View",
+	"manager.blacklist.button.add":       "➕ Add",
+	"manager.blacklist.button.remove":    "➖ Remove",
+	"manager.blacklist.button.import":    "📥 Import",
+	"manager.blacklist.button.export":    "📤 Export",
+	"manager.blacklist.load_failed":      "Could not load the blacklist.",
+	"manager.blacklist.empty":            "📋 *The blacklist is empty*",
+	"manager.blacklist.list":             "📋 *Blacklist:*\n\n",
+	"manager.blacklist.more":             "\n... and %d more users",
+	"manager.blacklist.add_prompt":       "➕ *Add to the blacklist*\n\nSend a username (for example: @username). You can add a reason after a space.",
+	"manager.blacklist.remove_prompt":    "➖ *Remove from the blacklist*\n\nSend a username (for example: @username)",
+	"manager.blacklist.invalid_username": "❌ Enter a username like @username",
+	"manager.blacklist.update_failed":    "❌ Could not update the blacklist.",
+	"manager.blacklist.added":            "✅ Added to the blacklist: @%s",
+	"manager.blacklist.not_listed":       "❌ @%s is not in the blacklist",
+	"manager.blacklist.removed":          "✅ Removed from the blacklist: @%s",
+
+	"manager.forward.no_user":        "❌ Could not get the user's details. Make sure the user allows forwarding of their messages.",
+	"manager.forward.no_id":          "❌ Could not get the user ID. Make sure the user allows forwarding of their messages.",
+	"manager.forward.received":       "✅ User ID received: %d\n\nUse /newad to create an ad",
+	"manager.forward.invalid":        "❌ Forward a message from the user or enter the ID manually (digits only).",
+	"manager.owner.save_failed":      "❌ Could not save the user.",
+	"manager.owner.received":         "✅ User ID received: %d\n✅ Username: @%s",
+	"manager.owner.username_prompt":  "✅ User ID received: %d\n\n👤 *Enter the contact username* (for example: @username)\n\nOr press \"Skip\" if no username is needed.",
+	"manager.owner.invalid_username": "❌ Enter a username like @username or press \"Skip\".",
+	"manager.owner.id_prompt":        "🆔 *User ID*\n\nForward any message from the user to get their ID automatically.\n\nOr press \"Skip\" to enter the ID manually.",
+	"manager.owner.manual_prompt":    "🆔 *Client ID*\n\nEnter the client ID manually (digits only):",
+	"manager.owner.id_empty":         "❌ The client ID cannot be empty. Enter the ID or forward a message from the user.",
+	"manager.owner.id_invalid":       "❌ The client ID must be a number. Enter the ID or forward a message from the user.",
+	"manager.owner.id_required":      "❌ The client ID is required. Go back to the preview and enter the client ID.",
+
+	"manager.find.prompt":       "🔍 *Find ads*\n\nSend the client ID (digits only) or forward any message from the user:",
+	"manager.find.failed":       "❌ Could not search for ads.",
+	"manager.find.user_empty":   "❌ No ads found for user ID %d.",
+	"manager.find.client_empty": "❌ No ads found for client ID %d.",
+	"manager.find.title":        "📋 *Ads found: %d*\n\n",
+	"manager.find.more":         "\n... and %d more ads",
+	"manager.find.send_failed":  "❌ Could not send the search results.",
+	"manager.find.expired":      "🔴 Expired",
+	"manager.find.inactive":     "⚫ Removed",
+	"manager.find.active":       "🟢 Active",
+
+	"manager.step.photo":                "📸 *Step 1: Photo*\n\nSend a photo for the ad or skip this step.",
+	"manager.step.photo_edit":           "📸 *Step 1: Photo*\n\nSend a new photo or skip this step.",
+	"manager.step.photo_failed":         "❌ Could not save the photo, please try again.",
+	"manager.step.title":                "📝 *Step 2: Title*\n\nEnter the ad title (up to 128 characters).",
+	"manager.step.title_empty":          "❌ The title cannot be empty.",
+	"manager.step.desc":                 "📄 *Step 3: Description*\n\nEnter the ad description.",
+	"manager.step.desc_empty":           "❌ The description cannot be empty.",
+	"manager.step.user_id":              "🆔 *Step 4: User ID*\n\nForward any message from the user to get their ID automatically.\n\nOr press \"Skip\" to enter the ID manually.",
+	"manager.step.category":             "📂 *Step 5: Category*\n\nChoose the ad category.",
+	"manager.step.category_no_modes":    "❌ This category has no modes. Add a mode with /taxonomy or choose another category.",
+	"manager.step.mode":                 "🎯 *Step 6: Mode*\n\nChoose the ad mode.",
+	"manager.step.tag":                  "🏷 *Step 7: Tag*\n\nChoose the ad tag.",
+	"manager.step.duration":             "⏱ *Step 8: Duration*\n\nChoose how long the ad is shown.",
+	"manager.step.premium":              "⭐ *Step 9: Premium placement*\n\nA premium ad is shown at the top of the list.",
+	"manager.step.premium_queue_hint":   "\nYou can queue the ad: it becomes premium as soon as a slot is free.",
+	"manager.step.premium_full":         " Queue the ad or remove one of the current ones.",
+	"manager.step.premium_check_failed": "❌ Could not check the premium ad limit.",
+	"manager.step.button.queue":         "📅 Queue",
+	"manager.step.current_m":            "\n\nCurrent: %s",
+	"manager.step.current_n":            "\n\nCurrent: %s",
+	"manager.step.current_f":            "\n\nCurrent: %s",
+	"manager.duration.invalid":          "❌ Invalid duration.",
+
+	"manager.ad.invalid_id":          "❌ Invalid ad ID.",
+	"manager.ad.not_found":           "❌ Ad not found.",
+	"manager.ad.update_failed":       "❌ Could not update the ad.",
+	"manager.ad.save_failed":         "❌ Could not save the ad: %s",
+	"manager.ad.published":           "✅ Ad #%d published.",
+	"manager.ad.updated":             "✅ Ad #%d updated.",
+	"manager.ad.removed":             "✅ Ad #%d taken off the market.",
+	"manager.ad.renew_prompt":        "🔄 *Renew the ad*\n\nChoose the renewal period:",
+	"manager.ad.renewed":             "✅ Ad #%d renewed until %s.",
+	"manager.ad.bump_failed":         "❌ Could not bump the ad.",
+	"manager.ad.bumped":              "✅ Ad #%d bumped in the feed.",
+	"manager.ad.publish_failed":      "❌ Could not put the ad on the market.",
+	"manager.ad.relisted":            "✅ Ad #%d is back on the market.",
+	"manager.ad.button.publish":      "✅ Put on the market",
+	"manager.ad.button.history":      "📜 History",
+	"manager.ad.button.renew":        "🔄 Renew",
+	"manager.ad.button.remove":       "❌ Remove",
+	"manager.ad.button.bump":         "⬆️ Bump in the feed",
+	"manager.ad.button.book_premium": "📅 Book premium",
+
+	"manager.settings.title":             "⚙️ *Ad settings*\n\n",
+	"manager.settings.category":          "📂 Category: %s\n",
+	"manager.settings.mode":              "🎯 Mode: %s\n",
+	"manager.settings.tag":               "🏷 Tag: %s\n",
+	"manager.settings.price":             "💰 Price: %s\n",
+	"manager.settings.premium":           "⭐ Premium: %s\n",
+	"manager.settings.duration":          "⏱ Duration: %s\n",
+	"manager.settings.auto_renew":        "🔁 Auto-renewal: %s\n\n",
+	"manager.settings.choose":            "Choose what to change:",
+	"manager.settings.duration_none":     "not set",
+	"manager.settings.premium_queued":    "queued",
+	"manager.settings.button.category":   "📂 Category",
+	"manager.settings.button.mode":       "🎯 Mode",
+	"manager.settings.button.tag":        "🏷 Tag",
+	"manager.settings.button.duration":   "⏱ Duration",
+	"manager.settings.button.premium":    "⭐ Premium",
+	"manager.settings.button.auto_renew": "🔁 Auto-renewal",
+	"manager.settings.button.price":      "💰 Price",
+	"manager.yes":                        "yes",
+	"manager.no":                         "no",
+	"manager.until":                      "until %s",
+
+	"manager.ad.summary":         "📋 *Ad #%d*\n\n📝 Title: %s\n📄 Description: %s\n👤 Contact: @%s\n📂 Category: %s\n🎯 Mode: %s\n🏷 Tag: %s\n💰 Price: %s\n⭐ Premium: %s\n📊 Status: %s",
+	"manager.ad.status.expired":  "Expired",
+	"manager.ad.status.inactive": "Removed",
+	"manager.ad.status.active":   "Active",
+	"manager.ad.expires":         "\n⏱ *Valid until:* %s",
+	"manager.ad.bumped_at":       "\n⬆️ Bumped in the feed: %s",
+	"manager.ad.preview":         "📋 *Ad preview*\n\n📝 Title: %s\n📄 Description: %s\n👤 Contact: @%s\n📂 Category: %s\n🎯 Mode: %s\n🏷 Tag: %s\n💰 Price: %s\n⭐ Premium: %s\n🆔 Client ID: %s\n⏱ Valid until: %s\n\nConfirm publication:",
+
+	"manager.error.title_empty":    "the title cannot be empty",
+	"manager.error.desc_empty":     "the description cannot be empty",
+	"manager.error.category_empty": "the category cannot be empty",
+	"manager.error.mode_empty":     "the mode cannot be empty",
+	"manager.error.tag_empty":      "the tag cannot be empty",
+	"manager.error.owner_empty":    "the client ID cannot be empty",
+	"manager.error.duration_empty": "the duration cannot be empty",
+	"manager.error.internal":       "internal error, see the server log",
+
+	"manager.price.prompt":        "💰 Enter the price in rubles (a number only) or 0 to leave the price out.\n\nCurrent: %s",
+	"manager.price.invalid":       "❌ Invalid price: %s.",
+	"manager.error.invalid_price": "the price must be a whole number from 0 to 1 000 000 000",
+
+	"manager.history.load_failed":           "❌ Could not load the history.",
+	"manager.history.empty":                 "📜 Ad #%d has no saved versions yet.",
+	"manager.history.title":                 "📜 History of ad #%d\n",
+	"manager.history.current":               " (current)",
+	"manager.history.restored_from":         "• restored from v%d\n",
+	"manager.history.no_changes":            "• no content changes\n",
+	"manager.history.first":                 "• first version\n",
+	"manager.history.button.restore":        "↩️ Restore v%d",
+	"manager.history.author_legacy":         "before history was kept",
+	"manager.history.author":                "manager %d",
+	"manager.history.restore_failed":        "❌ Could not restore the version.",
+	"manager.history.restored":              "✅ Ad #%d restored from version v%d.",
+	"manager.history.change.title":          "title: “%s” → “%s”",
+	"manager.history.change.desc":           "description: “%s” → “%s”",
+	"manager.history.change.photo_removed":  "photo removed",
+	"manager.history.change.photo_added":    "photo added",
+	"manager.history.change.photo_replaced": "photo replaced",
+	"manager.history.change.contact":        "contact: @%s → @%s",
+	"manager.history.change.owner":          "owner changed",
+	"manager.history.change.price":          "price: %s → %s",
+	"manager.history.change.section":        "section: %s → %s",
+	"manager.error.revision_not_found":      "version not found",
+
+	"manager.stats.period.day":              "today",
+	"manager.stats.period.week":             "7 days",
+	"manager.stats.period.month":            "30 days",
+	"manager.stats.source.manager":          "manager",
+	"manager.stats.source.owner":            "owner",
+	"manager.stats.source.payment":          "payment",
+	"manager.stats.source.auto":             "auto",
+	"manager.stats.action.ad_created":       "created",
+	"manager.stats.action.ad_edited":        "edited",
+	"manager.stats.action.ad_restored":      "restored",
+	"manager.stats.action.ad_renewed":       "renewed",
+	"manager.stats.action.ad_removed":       "removed",
+	"manager.stats.action.ad_bumped":        "bumped",
+	"manager.stats.action.premium_booked":   "premium",
+	"manager.stats.action.blacklisted":      "blacklisted",
+	"manager.stats.action.unblacklisted":    "unblacklisted",
+	"manager.stats.action.blacklist_import": "blacklist imports",
+	"manager.stats.action.broadcast":        "broadcasts",
+	"manager.stats.action.ad_request":       "requests",
+	"manager.stats.load_failed":             "❌ Could not load the statistics.",
+	"manager.stats.title":                   "📊 *Statistics: %s* (since %s)\n\n",
+	"manager.stats.new_ads":                 "🆕 New ads: %d\n",
+	"manager.stats.renewals":                "🔄 Renewals: %d",
+	"manager.stats.expired":                 "⌛ Expired: %d\n",
+	"manager.stats.removed":                 "🗑 Taken off the market: %d\n",
+	"manager.stats.premium":                 "⭐ Premium: %d ⭐ (payments: %d)\n",
+	"manager.stats.blacklisted":             "🚫 Added to the blacklist: %d\n",
+	"manager.stats.top_categories":          "\n*Top categories*\n",
+	"manager.stats.top_tags":                "\n*Top tags*\n",
+	"manager.stats.managers":                "\n*Managers*\n",
+	"manager.stats.no_actions":              "No actions in this period.\n",
+	"manager.stats.button.csv":              "📄 Export CSV",
+	"manager.stats.csv_failed":              "❌ Could not build the CSV.",
+	"manager.stats.caption":                 "📊 Statistics: %s (since %s)",
+
+	"manager.auto_renew.off":              "off",
+	"manager.auto_renew.every":            "every %s",
+	"manager.auto_renew.left.one":         ", %d time left",
+	"manager.auto_renew.left.other":       ", %d times left",
+	"manager.auto_renew.until":            ", until %s",
+	"manager.auto_renew.times.one":        "%d time",
+	"manager.auto_renew.times.other":      "%d times",
+	"manager.auto_renew.prompt":           "🔁 *Auto-renewal*\n\nCurrent: %s\n\nWhen the ad expires, it is renewed for the chosen period and the owner is notified. Choose the period:",
+	"manager.auto_renew.button.off":       "⏹ Turn off",
+	"manager.auto_renew.button.unlimited": "No limit",
+	"manager.auto_renew.button.until":     "📅 Until a date",
+	"manager.auto_renew.limit_prompt":     "🔁 Renew every %s. How many times?",
+	"manager.auto_renew.until_prompt":     "📅 Enter the date until which to renew the ad, as DD.MM.YYYY:",
+	"manager.auto_renew.until_invalid":    "❌ Invalid format. Example: 31.12.2025",
+	"manager.auto_renew.until_past":       "❌ This date has already passed.",
+
+	"manager.top.usage":    "Usage: /top [days], from 1 to %d (default %d)",
+	"manager.top.disabled": "📈 Statistics are not collected: Redis is unavailable.",
+	"manager.top.title":    "📈 *Top ads for %s*\n",
+	"manager.top.legend":   "👁 impressions · 📖 opens · 💬 “Contact”\n\n",
+	"manager.top.empty":    "No events in this period.",
+	"manager.top.deleted":  "deleted",
+	"manager.top.footer":   "\n_Data is updated once a minute._",
+
+	"manager.taxonomy.usage":           "Usage:\n/taxonomy — list sections\n/taxonomy add category <key> <name>\n/taxonomy add mode|tag <category> <key> <name>\n/taxonomy rename category <key> <name>\n/taxonomy rename mode|tag <category> <key> <name>\n/taxonomy hide|show category <key>\n/taxonomy hide|show mode|tag <category> <key>\n/taxonomy move category <key> <position>\n/taxonomy move mode|tag <category> <key> <position>\n\nThe key is stored in ads and never changes: Latin letters, digits and _. Hidden sections are not offered when creating an ad and are not shown in the Mini App.",
+	"manager.taxonomy.save_failed":     "❌ Could not save the sections.",
+	"manager.taxonomy.updated":         "✅ Sections updated.\n\n",
+	"manager.taxonomy.title":           "🗂 Sections\n",
+	"manager.taxonomy.tags":            "Tags",
+	"manager.taxonomy.modes":           "Modes",
+	"manager.taxonomy.help":            "\nMore: /taxonomy help",
+	"manager.error.taxonomy_key":       "the key may contain only Latin letters, digits and _, up to 32 characters",
+	"manager.error.taxonomy_label":     "the name cannot be empty or longer than 64 characters",
+	"manager.error.taxonomy_exists":    "this key already exists",
+	"manager.error.taxonomy_not_found": "section not found",
+	"manager.error.taxonomy_last_mode": "this is the last mode of the category: add or show another mode first",
+
+	"manager.broadcast.segment.active":   "owners of active ads",
+	"manager.broadcast.segment.category": "owners of ads in the “%s” category",
+	"manager.broadcast.segment.all":      "all ad owners",
+
+	"manager.broadcast.status.completed":    "✅ Completed",
+	"manager.broadcast.status.cancelled":    "⏹ Stopped",
+	"manager.broadcast.status.sending":      "⏳ Sending",
+	"manager.broadcast.progress":            "📣 Broadcast #%d — %s\n\nAudience: %s\nSent: %d of %d\nErrors: %d",
+	"manager.broadcast.button.cancel":       "⏹ Stop",
+	"manager.broadcast.button.no_buttons":   "⏭ No buttons",
+	"manager.broadcast.button.all":          "👥 All ad owners",
+	"manager.broadcast.button.active":       "✅ With active ads",
+	"manager.broadcast.button.send":         "🚀 Send (%d)",
+	"manager.broadcast.button.audience":     "👥 Another audience",
+	"manager.broadcast.button.cancel_draft": "✖️ Cancel",
+
+	"manager.broadcast.start":            "📣 *Broadcast to ad owners*\n\nSend the message text. A photo with a caption is fine too; Telegram formatting is kept.",
+	"manager.broadcast.caption_too_long": "❌ The photo caption is longer than %d characters.",
+	"manager.broadcast.empty":            "❌ Send text or a photo with a caption.",
+	"manager.broadcast.text_too_long":    "❌ The message is longer than %d characters.",
+	"manager.broadcast.buttons_prompt":   "🔘 *Buttons*\n\nSend up to %d link buttons, one per line:\n`Button text | https://example.com`",
+	"manager.broadcast.audience_prompt":  "👥 *Who should receive it?*\n\nCategory means owners who have had ads in that category. Blacklisted users do not receive broadcasts.",
+	"manager.broadcast.count_failed":     "❌ Could not count the recipients.",
+	"manager.broadcast.preview_failed":   "❌ Telegram rejected the message; check the text and buttons.",
+	"manager.broadcast.preview":          "👆 This is how users will see the message.\n\nAudience: %s\nRecipients: %d\nRate: up to %d messages per second",
+	"manager.broadcast.prepare_failed":   "❌ Could not prepare the broadcast.",
+	"manager.broadcast.starting":         "📣 Starting the broadcast…",
+	"manager.broadcast.save_failed":      "❌ Could not save the broadcast.",
+	"manager.broadcast.cancel_failed":    "❌ Could not stop the broadcast.",
+	"manager.broadcast.already_finished": "The broadcast has already finished.",
+	"manager.broadcast.draft_missing":    "Broadcast draft not found, please start over.",
+
+	"manager.error.broadcast_button_format":    "line “%s”: use the format “Text | link”",
+	"manager.error.broadcast_button_text":      "button text “%s” is longer than %d characters",
+	"manager.error.broadcast_button_link":      "invalid link “%s”",
+	"manager.error.broadcast_no_buttons":       "no buttons found",
+	"manager.error.broadcast_too_many_buttons": "no more than %d buttons",
+
+	"manager.premium.full":                  "All premium slots (%d) in the “%s” category are taken.",
+	"manager.premium.free_at":               " The next one frees up on %s.",
+	"manager.premium.has_free":              "There is a free slot in the category right now.",
+	"manager.premium.load_failed":           "❌ Could not load premium slots.",
+	"manager.premium.title":                 "⭐ *Premium slots*\n",
+	"manager.premium.category":              "\n*%s* — %d of %d taken\n",
+	"manager.premium.active_entry":          "• #%d %s — until %s\n",
+	"manager.premium.queue":                 "Queue:\n",
+	"manager.premium.queue_entry":           "• booking %d: #%d %s — from %s to %s\n",
+	"manager.premium.queue_hint":            "\nQueue dates are estimates: a booking takes the first slot that frees up.",
+	"manager.premium.button.cancel_booking": "❌ Cancel booking %d (#%d)",
+	"manager.premium.button.asap":           "▶️ As soon as possible",
+	"manager.premium.button.until_end":      "Until the ad expires",
+	"manager.premium.button.slots":          "⭐ Premium slots",
+	"manager.premium.cancel_failed":         "❌ Could not cancel the booking.",
+	"manager.premium.start_prompt":          "📅 *Premium slot booking*\n\n%s\n\nEnter the start date as DD.MM.YYYY HH:MM or choose “As soon as possible”.",
+	"manager.premium.start_invalid":         "❌ Invalid format. Example: 25.12.2025 18:00",
+	"manager.premium.start_past":            "❌ The start date has already passed.",
+	"manager.premium.start_after_expiry":    "❌ The ad is only active until %s. Renew it first.",
+	"manager.premium.days_prompt":           "📅 Start: %s\n\nHow long should premium be booked for?",
+	"manager.premium.book_failed":           "❌ Could not book premium.",
+	"manager.premium.booked":                "✅ Booking %d created for ad #%d.",
+	"manager.premium.expected_start":        "\nExpected start: %s.",
+	"manager.premium.already_until":         "\nThe ad is already premium until %s.",
+
+	"manager.error.booking_not_found": "the booking was not found or is no longer active",
+	"manager.error.ad_not_bookable":   "premium can only be booked for an active ad",
+
+	"manager.blacklist.import_prompt":          "📥 *Blacklist import*\n\nSend a CSV or JSON file.\n\nCSV: columns `username,reason,added_at` (reason and date are optional).\nJSON: an array of strings or objects `{\"username\", \"reason\", \"added_at\"}`.\n\nA preview of the changes is shown before the import.",
+	"manager.blacklist.import_not_document":    "❌ Send the CSV or JSON file as a document.",
+	"manager.blacklist.import_too_large":       "❌ The file is too large (2 MB max).",
+	"manager.blacklist.import_download_failed": "❌ Could not download the file, please try again.",
+	"manager.blacklist.import_parse_failed":    "❌ Could not parse the file: %s",
+	"manager.blacklist.import_diff_failed":     "❌ Error while comparing with the blacklist.",
+	"manager.blacklist.button.import_confirm":  "✅ Import (%d)",
+	"manager.blacklist.preview":                "📥 *Import preview*\n\n",
+	"manager.blacklist.preview_new":            "🆕 New: %d\n",
+	"manager.blacklist.preview_existing":       "♻️ Already listed: %d\n",
+	"manager.blacklist.preview_invalid":        "⚠️ Invalid: %d\n",
+	"manager.blacklist.preview_more":           "... and %d more\n",
+	"manager.blacklist.group_new":              "*Will be added:*",
+	"manager.blacklist.group_existing":         "*Already listed:*",
+	"manager.blacklist.group_invalid":          "*Skipped:*",
+	"manager.blacklist.nothing_to_import":      "\nNothing to import.",
+	"manager.blacklist.import_failed":          "❌ The import failed; the blacklist was not changed.",
+	"manager.blacklist.imported":               "✅ Records imported: %d",
+	"manager.blacklist.export_csv_failed":      "❌ Could not build the CSV file.",
+	"manager.blacklist.export_json_failed":     "❌ Could not build the JSON file.",
+	"manager.blacklist.export_caption":         "🚫 Blacklist: %d records",
+}
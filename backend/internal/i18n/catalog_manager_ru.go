@@ -0,0 +1,371 @@
+package i18n
+
+// catalogManagerRU — панель менеджера, уведомления менеджерам и посты в канале
+var catalogManagerRU = map[string]string{
+	"channel.premium":     "⭐ *Премиум*",
+	"channel.expires":     "⏱ До %s",
+	"channel.button.open": "Открыть в приложении",
+
+	"manager.request.title":         "📨 Заявка на объявление #%d\n\n👤 %s\n🆔 ID: %d\n",
+	"manager.request.no_username":   "без username",
+	"manager.request.button.accept": "✅ Принять",
+	"manager.request.button.reject": "❌ Отклонить",
+	"manager.request.button.check":  "🔎 Проверить",
+	"manager.request.button.write":  "💬 Написать",
+	"manager.request.accepted":      "✅ Принята",
+	"manager.request.rejected":      "❌ Отклонена",
+	"manager.request.not_found":     "❌ Заявка не найдена.",
+	"manager.request.save_failed":   "❌ Не удалось сохранить решение по заявке.",
+	"manager.request.handled":       "ℹ️ Заявка #%d уже обработана.",
+
+	"manager.payment.failed":        "⚠️ Не удалось обработать платёж %s от %d: %v",
+	"manager.payment.not_refunded":  "⚠️ Платёж #%d не применён и не возвращён: %v",
+	"manager.payment.paid":          "💫 Платёж #%d: %s объявления #%d на %s, %d ⭐ от %d",
+	"manager.refund.usage":          "Использование: /refund <ID платежа> [причина]",
+	"manager.refund.invalid_id":     "❌ Неверный ID платежа.",
+	"manager.refund.done":           "✅ Платёж #%d возвращён (%d ⭐).",
+	"manager.refund.default_reason": "возврат менеджером",
+
+	"manager.owner.renewed":        "🔄 Владелец продлил объявление #%d «%s» на %s (до %s).",
+	"manager.owner.deactivated":    "❌ Владелец снял объявление #%d «%s» с биржи.",
+	"manager.owner.auto_renew_off": "⏹ Владелец отключил автопродление объявления #%d «%s».",
+
+	"manager.button.back":          "◀️ Назад",
+	"manager.button.cancel":        "◀️ Отмена",
+	"manager.button.menu":          "◀️ В меню",
+	"manager.button.skip":          "⏭ Пропустить",
+	"manager.button.skip_username": "⏭ Пропустить (без username)",
+	"manager.button.skip_user_id":  "⏭ Пропустить (указать ID вручную)",
+	"manager.button.yes":           "✅ Да",
+	"manager.button.no":            "❌ Нет",
+	"manager.button.edit":          "✏️ Изменить",
+	"manager.button.confirm":       "✅ Подтвердить",
+	"manager.button.save":          "✅ Сохранить",
+	"manager.choose_button":        "👇 Выберите вариант кнопками ниже.",
+	"manager.choose_setting":       "👇 Выберите, что изменить, кнопками ниже.",
+
+	"manager.menu.title":     "📋 *Меню менеджера*\n\nВыберите действие:",
+	"manager.menu.new_ad":    "➕ Создать объявление",
+	"manager.menu.find_ad":   "🔍 Найти объявление",
+	"manager.menu.blacklist": "🚫 Чёрный список",
+	"manager.menu.premium":   "⭐ Премиум-места",
+	"manager.menu.top_ads":   "📈 Топ объявлений",
+	"manager.menu.stats":     "📊 Статистика",
+	"manager.menu.broadcast": "📣 Рассылка",
+
+	"manager.blacklist.title":            "🚫 *Управление чёрным списком*",
+	"manager.blacklist.button.view":      "📋 Просмотр",
+	"manager.blacklist.button.add":       "➕ Добавить",
+	"manager.blacklist.button.remove":    "➖ Удалить",
+	"manager.blacklist.button.import":    "📥 Импорт",
+	"manager.blacklist.button.export":    "📤 Экспорт",
+	"manager.blacklist.load_failed":      "Ошибка загрузки чёрного списка.",
+	"manager.blacklist.empty":            "📋 *Чёрный список пуст*",
+	"manager.blacklist.list":             "📋 *Чёрный список:*\n\n",
+	"manager.blacklist.more":             "\n... и ещё %d пользователей",
+	"manager.blacklist.add_prompt":       "➕ *Добавить в чёрный список*\n\nОтправьте username (например: @username). Через пробел можно указать причину.",
+	"manager.blacklist.remove_prompt":    "➖ *Удалить из чёрного списка*\n\nОтправьте username (например: @username)",
+	"manager.blacklist.invalid_username": "❌ Введите username в формате @username",
+	"manager.blacklist.update_failed":    "❌ Ошибка во время обновления чёрного списка.",
+	"manager.blacklist.added":            "✅ Добавлен в чёрный список: @%s",
+	"manager.blacklist.not_listed":       "❌ Пользователь @%s не найден в чёрном списке",
+	"manager.blacklist.removed":          "✅ Удалён из чёрного списка: @%s",
+
+	"manager.forward.no_user":        "❌ Не удалось получить информацию о пользователе. Убедитесь, что пользователь разрешил пересылку сообщений.",
+	"manager.forward.no_id":          "❌ Не удалось получить ID пользователя. Убедитесь, что пользователь разрешил пересылку сообщений.",
+	"manager.forward.received":       "✅ Получен ID пользователя: %d\n\nДля создания объявления используйте /newad",
+	"manager.forward.invalid":        "❌ Перешлите сообщение от пользователя или введите ID вручную (только цифры).",
+	"manager.owner.save_failed":      "❌ Не удалось сохранить пользователя.",
+	"manager.owner.received":         "✅ ID пользователя получен: %d\n✅ Username: @%s",
+	"manager.owner.username_prompt":  "✅ ID пользователя получен: %d\n\n👤 *Введите username для контакта* (например: @username)\n\nИли нажмите \"Пропустить\", если username не нужен.",
+	"manager.owner.invalid_username": "❌ Введите username в формате @username или нажмите \"Пропустить\".",
+	"manager.owner.id_prompt":        "🆔 *ID пользователя*\n\nПерешлите любое сообщение от пользователя, чтобы автоматически получить его ID.\n\nИли нажмите \"Пропустить\", чтобы ввести ID вручную.",
+	"manager.owner.manual_prompt":    "🆔 *Ввод ID клиента*\n\nВведите ID клиента вручную (только цифры):",
+	"manager.owner.id_empty":         "❌ ID клиента не может быть пустым. Введите ID или перешлите сообщение от пользователя.",
+	"manager.owner.id_invalid":       "❌ ID клиента должен быть числом. Введите ID или перешлите сообщение от пользователя.",
+	"manager.owner.id_required":      "❌ Необходимо указать ID клиента. Вернитесь к предпросмотру и введите ID клиента.",
+
+	"manager.find.prompt":       "🔍 *Найти объявления*\n\nОтправьте ID клиента (только цифры) или перешлите любое сообщение от пользователя:",
+	"manager.find.failed":       "❌ Ошибка при поиске объявлений.",
+	"manager.find.user_empty":   "❌ Объявления для пользователя ID %d не найдены.",
+	"manager.find.client_empty": "❌ Объявления для клиента ID %d не найдены.",
+	"manager.find.title":        "📋 *Найдено объявлений: %d*\n\n",
+	"manager.find.more":         "\n... и ещё %d объявлений",
+	"manager.find.send_failed":  "❌ Не удалось отправить результаты поиска.",
+	"manager.find.expired":      "🔴 Истекло",
+	"manager.find.inactive":     "⚫ Снято",
+	"manager.find.active":       "🟢 Активно",
+
+	"manager.step.photo":                "📸 *Шаг 1: Фото*\n\nОтправьте фото объявления или пропустите этот шаг.",
+	"manager.step.photo_edit":           "📸 *Шаг 1: Фото*\n\nОтправьте новое фото или пропустите этот шаг.",
+	"manager.step.photo_failed":         "❌ Не удалось сохранить фото, попробуйте ещё раз.",
+	"manager.step.title":                "📝 *Шаг 2: Заголовок*\n\nВведите заголовок объявления (до 128 символов).",
+	"manager.step.title_empty":          "❌ Заголовок не может быть пустым.",
+	"manager.step.desc":                 "📄 *Шаг 3: Описание*\n\nВведите описание объявления.",
+	"manager.step.desc_empty":           "❌ Описание не может быть пустым.",
+	"manager.step.user_id":              "🆔 *Шаг 4: ID пользователя*\n\nПерешлите любое сообщение от пользователя, чтобы автоматически получить его ID.\n\nИли нажмите \"Пропустить\", чтобы ввести ID вручную.",
+	"manager.step.category":             "📂 *Шаг 5: Категория*\n\nВыберите категорию объявления.",
+	"manager.step.category_no_modes":    "❌ В этой категории нет режимов. Добавьте режим командой /taxonomy или выберите другую категорию.",
+	"manager.step.mode":                 "🎯 *Шаг 6: Режим*\n\nВыберите режим объявления.",
+	"manager.step.tag":                  "🏷 *Шаг 7: Тег*\n\nВыберите тег объявления.",
+	"manager.step.duration":             "⏱ *Шаг 8: Срок действия*\n\nВыберите срок отображения объявления.",
+	"manager.step.premium":              "⭐ *Шаг 9: Премиум размещение*\n\nПремиум объявление будет отображаться вверху списка.",
+	"manager.step.premium_queue_hint":   "\nМожно поставить объявление в очередь — оно станет премиум, как только место освободится.",
+	"manager.step.premium_full":         " Поставьте объявление в очередь или снимите одно из текущих.",
+	"manager.step.premium_check_failed": "❌ Не удалось проверить лимит премиум-объявлений.",
+	"manager.step.button.queue":         "📅 В очередь",
+	"manager.step.current_m":            "\n\nТекущий: %s",
+	"manager.step.current_n":            "\n\nТекущее: %s",
+	"manager.step.current_f":            "\n\nТекущая: %s",
+	"manager.duration.invalid":          "❌ Неверный срок.",
+
+	"manager.ad.invalid_id":          "❌ Неверный ID объявления.",
+	"manager.ad.not_found":           "❌ Объявление не найдено.",
+	"manager.ad.update_failed":       "❌ Не удалось обновить объявление.",
+	"manager.ad.save_failed":         "❌ Не удалось сохранить объявление: %s",
+	"manager.ad.published":           "✅ Объявление #%d опубликовано.",
+	"manager.ad.updated":             "✅ Объявление #%d обновлено.",
+	"manager.ad.removed":             "✅ Объявление #%d снято с биржи.",
+	"manager.ad.renew_prompt":        "🔄 *Продлить объявление*\n\nВыберите срок продления:",
+	"manager.ad.renewed":             "✅ Объявление #%d продлено до %s.",
+	"manager.ad.bump_failed":         "❌ Не удалось поднять объявление.",
+	"manager.ad.bumped":              "✅ Объявление #%d поднято в ленте.",
+	"manager.ad.publish_failed":      "❌ Не удалось выложить объявление.",
+	"manager.ad.relisted":            "✅ Объявление #%d выложено на биржу.",
+	"manager.ad.button.publish":      "✅ Выложить",
+	"manager.ad.button.history":      "📜 История",
+	"manager.ad.button.renew":        "🔄 Продлить",
+	"manager.ad.button.remove":       "❌ Снять",
+	"manager.ad.button.bump":         "⬆️ Поднять в ленте",
+	"manager.ad.button.book_premium": "📅 Забронировать премиум",
+
+	"manager.settings.title":             "⚙️ *Настройки объявления*\n\n",
+	"manager.settings.category":          "📂 Категория: %s\n",
+	"manager.settings.mode":              "🎯 Режим: %s\n",
+	"manager.settings.tag":               "🏷 Тег: %s\n",
+	"manager.settings.price":             "💰 Цена: %s\n",
+	"manager.settings.premium":           "⭐ Премиум: %s\n",
+	"manager.settings.duration":          "⏱ Срок действия: %s\n",
+	"manager.settings.auto_renew":        "🔁 Автопродление: %s\n\n",
+	"manager.settings.choose":            "Выберите, что хотите изменить:",
+	"manager.settings.duration_none":     "не задан",
+	"manager.settings.premium_queued":    "в очереди",
+	"manager.settings.button.category":   "📂 Категория",
+	"manager.settings.button.mode":       "🎯 Режим",
+	"manager.settings.button.tag":        "🏷 Тег",
+	"manager.settings.button.duration":   "⏱ Срок",
+	"manager.settings.button.premium":    "⭐ Премиум",
+	"manager.settings.button.auto_renew": "🔁 Автопродление",
+	"manager.settings.button.price":      "💰 Цена",
+	"manager.yes":                        "да",
+	"manager.no":                         "нет",
+	"manager.until":                      "до %s",
+
+	"manager.ad.summary":         "📋 *Объявление #%d*\n\n📝 Заголовок: %s\n📄 Описание: %s\n👤 Контакт: @%s\n📂 Категория: %s\n🎯 Режим: %s\n🏷 Тег: %s\n💰 Цена: %s\n⭐ Премиум: %s\n📊 Статус: %s",
+	"manager.ad.status.expired":  "Истекло",
+	"manager.ad.status.inactive": "Снято",
+	"manager.ad.status.active":   "Активно",
+	"manager.ad.expires":         "\n⏱ *Действительно до:* %s",
+	"manager.ad.bumped_at":       "\n⬆️ Поднято в ленте: %s",
+	"manager.ad.preview":         "📋 *Предпросмотр объявления*\n\n📝 Заголовок: %s\n📄 Описание: %s\n👤 Контакт: @%s\n📂 Категория: %s\n🎯 Режим: %s\n🏷 Тег: %s\n💰 Цена: %s\n⭐ Премиум: %s\n🆔 ID клиента: %s\n⏱ Действительно до: %s\n\nПодтвердите публикацию:",
+
+	"manager.error.title_empty":    "заголовок не может быть пустым",
+	"manager.error.desc_empty":     "описание не может быть пустым",
+	"manager.error.category_empty": "категория не может быть пустой",
+	"manager.error.mode_empty":     "режим не может быть пустым",
+	"manager.error.tag_empty":      "тег не может быть пустым",
+	"manager.error.owner_empty":    "ID клиента не может быть пустым",
+	"manager.error.duration_empty": "срок действия не может быть пустым",
+	"manager.error.internal":       "внутренняя ошибка, подробности в логе сервера",
+
+	"manager.price.prompt":        "💰 Введите цену в рублях (только число) или 0, чтобы не указывать цену.\n\nСейчас: %s",
+	"manager.price.invalid":       "❌ Неверная цена: %s.",
+	"manager.error.invalid_price": "цена должна быть целым числом от 0 до 1 000 000 000",
+
+	"manager.history.load_failed":           "❌ Не удалось загрузить историю.",
+	"manager.history.empty":                 "📜 У объявления #%d ещё нет сохранённых версий.",
+	"manager.history.title":                 "📜 История объявления #%d\n",
+	"manager.history.current":               " (текущая)",
+	"manager.history.restored_from":         "• восстановлена из v%d\n",
+	"manager.history.no_changes":            "• без изменений содержимого\n",
+	"manager.history.first":                 "• первая версия\n",
+	"manager.history.button.restore":        "↩️ Восстановить v%d",
+	"manager.history.author_legacy":         "до ведения истории",
+	"manager.history.author":                "менеджер %d",
+	"manager.history.restore_failed":        "❌ Не удалось восстановить версию.",
+	"manager.history.restored":              "✅ Объявление #%d восстановлено из версии v%d.",
+	"manager.history.change.title":          "заголовок: «%s» → «%s»",
+	"manager.history.change.desc":           "описание: «%s» → «%s»",
+	"manager.history.change.photo_removed":  "фото удалено",
+	"manager.history.change.photo_added":    "добавлено фото",
+	"manager.history.change.photo_replaced": "фото заменено",
+	"manager.history.change.contact":        "контакт: @%s → @%s",
+	"manager.history.change.owner":          "сменён владелец",
+	"manager.history.change.price":          "цена: %s → %s",
+	"manager.history.change.section":        "рубрика: %s → %s",
+	"manager.error.revision_not_found":      "версия не найдена",
+
+	"manager.stats.period.day":              "сегодня",
+	"manager.stats.period.week":             "7 дней",
+	"manager.stats.period.month":            "30 дней",
+	"manager.stats.source.manager":          "менеджер",
+	"manager.stats.source.owner":            "владелец",
+	"manager.stats.source.payment":          "оплата",
+	"manager.stats.source.auto":             "авто",
+	"manager.stats.action.ad_created":       "создано",
+	"manager.stats.action.ad_edited":        "изменено",
+	"manager.stats.action.ad_restored":      "откачено",
+	"manager.stats.action.ad_renewed":       "продлено",
+	"manager.stats.action.ad_removed":       "снято",
+	"manager.stats.action.ad_bumped":        "поднято",
+	"manager.stats.action.premium_booked":   "премиум",
+	"manager.stats.action.blacklisted":      "в ЧС",
+	"manager.stats.action.unblacklisted":    "из ЧС",
+	"manager.stats.action.blacklist_import": "импорт ЧС",
+	"manager.stats.action.broadcast":        "рассылки",
+	"manager.stats.action.ad_request":       "заявки",
+	"manager.stats.load_failed":             "❌ Не удалось загрузить статистику.",
+	"manager.stats.title":                   "📊 *Статистика: %s* (с %s)\n\n",
+	"manager.stats.new_ads":                 "🆕 Новых объявлений: %d\n",
+	"manager.stats.renewals":                "🔄 Продлений: %d",
+	"manager.stats.expired":                 "⌛ Истекло: %d\n",
+	"manager.stats.removed":                 "🗑 Снято с биржи: %d\n",
+	"manager.stats.premium":                 "⭐ Премиум: %d ⭐ (оплат: %d)\n",
+	"manager.stats.blacklisted":             "🚫 Добавлено в чёрный список: %d\n",
+	"manager.stats.top_categories":          "\n*Топ категорий*\n",
+	"manager.stats.top_tags":                "\n*Топ тегов*\n",
+	"manager.stats.managers":                "\n*Менеджеры*\n",
+	"manager.stats.no_actions":              "Действий за период нет.\n",
+	"manager.stats.button.csv":              "📄 Выгрузить CSV",
+	"manager.stats.csv_failed":              "❌ Не удалось сформировать CSV.",
+	"manager.stats.caption":                 "📊 Статистика: %s (с %s)",
+
+	"manager.auto_renew.off":              "выкл.",
+	"manager.auto_renew.every":            "каждые %s",
+	"manager.auto_renew.left.one":         ", остался %d раз",
+	"manager.auto_renew.left.few":         ", осталось %d раза",
+	"manager.auto_renew.left.many":        ", осталось %d раз",
+	"manager.auto_renew.left.other":       ", осталось %d раза",
+	"manager.auto_renew.until":            ", до %s",
+	"manager.auto_renew.times.one":        "%d раз",
+	"manager.auto_renew.times.few":        "%d раза",
+	"manager.auto_renew.times.many":       "%d раз",
+	"manager.auto_renew.times.other":      "%d раза",
+	"manager.auto_renew.prompt":           "🔁 *Автопродление*\n\nСейчас: %s\n\nКогда срок объявления истечёт, оно будет продлено на выбранный период, а владелец получит уведомление. Выберите период:",
+	"manager.auto_renew.button.off":       "⏹ Выключить",
+	"manager.auto_renew.button.unlimited": "Без ограничений",
+	"manager.auto_renew.button.until":     "📅 До даты",
+	"manager.auto_renew.limit_prompt":     "🔁 Продлевать каждые %s. Сколько раз продлить?",
+	"manager.auto_renew.until_prompt":     "📅 Введите дату, до которой продлевать объявление, в формате ДД.ММ.ГГГГ:",
+	"manager.auto_renew.until_invalid":    "❌ Неверный формат. Пример: 31.12.2025",
+	"manager.auto_renew.until_past":       "❌ Дата уже прошла.",
+
+	"manager.top.usage":    "Использование: /top [дней], от 1 до %d (по умолчанию %d)",
+	"manager.top.disabled": "📈 Статистика не собирается: Redis недоступен.",
+	"manager.top.title":    "📈 *Топ объявлений за %s*\n",
+	"manager.top.legend":   "👁 показы · 📖 открытия · 💬 «Связаться»\n\n",
+	"manager.top.empty":    "За этот период событий нет.",
+	"manager.top.deleted":  "удалено",
+	"manager.top.footer":   "\n_Данные обновляются раз в минуту._",
+
+	"manager.taxonomy.usage":           "Использование:\n/taxonomy — список рубрик\n/taxonomy add category <ключ> <название>\n/taxonomy add mode|tag <категория> <ключ> <название>\n/taxonomy rename category <ключ> <название>\n/taxonomy rename mode|tag <категория> <ключ> <название>\n/taxonomy hide|show category <ключ>\n/taxonomy hide|show mode|tag <категория> <ключ>\n/taxonomy move category <ключ> <позиция>\n/taxonomy move mode|tag <категория> <ключ> <позиция>\n\nКлюч сохраняется в объявлениях и не меняется: латинские буквы, цифры и _. Скрытые рубрики не предлагаются при создании объявления и не показываются в Mini App.",
+	"manager.taxonomy.save_failed":     "❌ Не удалось сохранить рубрики.",
+	"manager.taxonomy.updated":         "✅ Рубрики обновлены.\n\n",
+	"manager.taxonomy.title":           "🗂 Рубрики\n",
+	"manager.taxonomy.tags":            "Теги",
+	"manager.taxonomy.modes":           "Режимы",
+	"manager.taxonomy.help":            "\nПодробнее: /taxonomy help",
+	"manager.error.taxonomy_key":       "ключ может содержать только латинские буквы, цифры и _, до 32 символов",
+	"manager.error.taxonomy_label":     "название не может быть пустым или длиннее 64 символов",
+	"manager.error.taxonomy_exists":    "такой ключ уже есть",
+	"manager.error.taxonomy_not_found": "рубрика не найдена",
+	"manager.error.taxonomy_last_mode": "это последний режим категории — сначала добавьте или покажите другой режим",
+
+	"manager.broadcast.segment.active":   "владельцы активных объявлений",
+	"manager.broadcast.segment.category": "владельцы объявлений в категории «%s»",
+	"manager.broadcast.segment.all":      "все владельцы объявлений",
+
+	"manager.broadcast.status.completed":    "✅ Завершена",
+	"manager.broadcast.status.cancelled":    "⏹ Остановлена",
+	"manager.broadcast.status.sending":      "⏳ Отправляется",
+	"manager.broadcast.progress":            "📣 Рассылка #%d — %s\n\nАудитория: %s\nОтправлено: %d из %d\nОшибок: %d",
+	"manager.broadcast.button.cancel":       "⏹ Остановить",
+	"manager.broadcast.button.no_buttons":   "⏭ Без кнопок",
+	"manager.broadcast.button.all":          "👥 Все владельцы объявлений",
+	"manager.broadcast.button.active":       "✅ С активными объявлениями",
+	"manager.broadcast.button.send":         "🚀 Отправить (%d)",
+	"manager.broadcast.button.audience":     "👥 Другая аудитория",
+	"manager.broadcast.button.cancel_draft": "✖️ Отменить",
+
+	"manager.broadcast.start":            "📣 *Рассылка владельцам объявлений*\n\nОтправьте текст сообщения. Можно фото с подписью, форматирование Telegram сохранится.",
+	"manager.broadcast.caption_too_long": "❌ Подпись к фото длиннее %d символов.",
+	"manager.broadcast.empty":            "❌ Отправьте текст или фото с подписью.",
+	"manager.broadcast.text_too_long":    "❌ Сообщение длиннее %d символов.",
+	"manager.broadcast.buttons_prompt":   "🔘 *Кнопки*\n\nОтправьте до %d кнопок-ссылок, по одной в строке:\n`Текст кнопки | https://example.com`",
+	"manager.broadcast.audience_prompt":  "👥 *Кому отправить?*\n\nКатегория — владельцы, у которых были объявления в этой категории. Пользователи из чёрного списка рассылку не получают.",
+	"manager.broadcast.count_failed":     "❌ Не удалось подсчитать получателей.",
+	"manager.broadcast.preview_failed":   "❌ Telegram не принял сообщение, проверьте текст и кнопки.",
+	"manager.broadcast.preview":          "👆 Так сообщение увидят пользователи.\n\nАудитория: %s\nПолучателей: %d\nСкорость: до %d сообщений в секунду",
+	"manager.broadcast.prepare_failed":   "❌ Не удалось подготовить рассылку.",
+	"manager.broadcast.starting":         "📣 Рассылка запускается…",
+	"manager.broadcast.save_failed":      "❌ Не удалось сохранить рассылку.",
+	"manager.broadcast.cancel_failed":    "❌ Не удалось остановить рассылку.",
+	"manager.broadcast.already_finished": "Рассылка уже завершена.",
+	"manager.broadcast.draft_missing":    "Черновик рассылки не найден, начните заново.",
+
+	"manager.error.broadcast_button_format":    "строка «%s»: нужен формат «Текст | ссылка»",
+	"manager.error.broadcast_button_text":      "текст кнопки «%s» длиннее %d символов",
+	"manager.error.broadcast_button_link":      "неверная ссылка «%s»",
+	"manager.error.broadcast_no_buttons":       "нет ни одной кнопки",
+	"manager.error.broadcast_too_many_buttons": "не больше %d кнопок",
+
+	"manager.premium.full":                  "Все премиум-места (%d) в категории «%s» заняты.",
+	"manager.premium.free_at":               " Ближайшее освободится %s.",
+	"manager.premium.has_free":              "Сейчас в категории есть свободное место.",
+	"manager.premium.load_failed":           "❌ Не удалось загрузить премиум-места.",
+	"manager.premium.title":                 "⭐ *Премиум-места*\n",
+	"manager.premium.category":              "\n*%s* — занято %d из %d\n",
+	"manager.premium.active_entry":          "• #%d %s — до %s\n",
+	"manager.premium.queue":                 "Очередь:\n",
+	"manager.premium.queue_entry":           "• бронь %d: #%d %s — с %s до %s\n",
+	"manager.premium.queue_hint":            "\nДаты в очереди — прогноз: бронь занимает первое освободившееся место.",
+	"manager.premium.button.cancel_booking": "❌ Отменить бронь %d (#%d)",
+	"manager.premium.button.asap":           "▶️ Как можно скорее",
+	"manager.premium.button.until_end":      "До конца размещения",
+	"manager.premium.button.slots":          "⭐ Премиум-места",
+	"manager.premium.cancel_failed":         "❌ Не удалось отменить бронь.",
+	"manager.premium.start_prompt":          "📅 *Бронь премиум-места*\n\n%s\n\nВведите дату начала в формате ДД.ММ.ГГГГ ЧЧ:ММ или выберите «Как можно скорее».",
+	"manager.premium.start_invalid":         "❌ Неверный формат. Пример: 25.12.2025 18:00",
+	"manager.premium.start_past":            "❌ Дата начала уже прошла.",
+	"manager.premium.start_after_expiry":    "❌ Объявление активно только до %s. Сначала продлите его.",
+	"manager.premium.days_prompt":           "📅 Начало: %s\n\nНа какой срок забронировать премиум?",
+	"manager.premium.book_failed":           "❌ Не удалось забронировать премиум.",
+	"manager.premium.booked":                "✅ Бронь %d создана для объявления #%d.",
+	"manager.premium.expected_start":        "\nОжидаемое начало: %s.",
+	"manager.premium.already_until":         "\nОбъявление уже премиум до %s.",
+
+	"manager.error.booking_not_found": "бронь не найдена или уже не активна",
+	"manager.error.ad_not_bookable":   "премиум можно забронировать только для активного объявления",
+
+	"manager.blacklist.import_prompt":          "📥 *Импорт чёрного списка*\n\nОтправьте файл CSV или JSON.\n\nCSV: колонки `username,reason,added_at` (причина и дата необязательны).\nJSON: массив строк или объектов `{\"username\", \"reason\", \"added_at\"}`.\n\nПеред импортом будет показан предпросмотр изменений.",
+	"manager.blacklist.import_not_document":    "❌ Отправьте файл CSV или JSON документом.",
+	"manager.blacklist.import_too_large":       "❌ Файл слишком большой (максимум 2 МБ).",
+	"manager.blacklist.import_download_failed": "❌ Не удалось скачать файл, попробуйте ещё раз.",
+	"manager.blacklist.import_parse_failed":    "❌ Не удалось разобрать файл: %s",
+	"manager.blacklist.import_diff_failed":     "❌ Ошибка при сравнении с чёрным списком.",
+	"manager.blacklist.button.import_confirm":  "✅ Импортировать (%d)",
+	"manager.blacklist.preview":                "📥 *Предпросмотр импорта*\n\n",
+	"manager.blacklist.preview_new":            "🆕 Новые: %d\n",
+	"manager.blacklist.preview_existing":       "♻️ Уже в списке: %d\n",
+	"manager.blacklist.preview_invalid":        "⚠️ Некорректные: %d\n",
+	"manager.blacklist.preview_more":           "... и ещё %d\n",
+	"manager.blacklist.group_new":              "*Будут добавлены:*",
+	"manager.blacklist.group_existing":         "*Уже в списке:*",
+	"manager.blacklist.group_invalid":          "*Пропущены:*",
+	"manager.blacklist.nothing_to_import":      "\nНечего импортировать.",
+	"manager.blacklist.import_failed":          "❌ Импорт не выполнен, чёрный список не изменён.",
+	"manager.blacklist.imported":               "✅ Импортировано записей: %d",
+	"manager.blacklist.export_csv_failed":      "❌ Не удалось сформировать CSV.",
+	"manager.blacklist.export_json_failed":     "❌ Не удалось сформировать JSON.",
+	"manager.blacklist.export_caption":         "🚫 Чёрный список: %d записей",
+}
@@ -0,0 +1,371 @@
+package i18n
+
+// catalogManagerUK — панель менеджера, уведомления менеджерам и посты в канале
+var catalogManagerUK = map[string]string{
+	"channel.premium":     "⭐ *Преміум*",
+	"channel.expires":     "⏱ До %s",
+	"channel.button.open": "Відкрити в застосунку",
+
+	"manager.request.title":         "📨 Заявка на оголошення #%d\n\n👤 %s\n🆔 ID: %d\n",
+	"manager.request.no_username":   "без username",
+	"manager.request.button.accept": "✅ Прийняти",
+	"manager.request.button.reject": "❌ Відхилити",
+	"manager.request.button.check":  "🔎 Перевірити",
+	"manager.request.button.write":  "💬 Написати",
+	"manager.request.accepted":      "✅ Прийнято",
+	"manager.request.rejected":      "❌ Відхилено",
+	"manager.request.not_found":     "❌ Заявку не знайдено.",
+	"manager.request.save_failed":   "❌ Не вдалося зберегти рішення щодо заявки.",
+	"manager.request.handled":       "ℹ️ Заявку #%d уже оброблено.",
+
+	"manager.payment.failed":        "⚠️ Не вдалося обробити платіж %s від %d: %v",
+	"manager.payment.not_refunded":  "⚠️ Платіж #%d не застосовано і не повернуто: %v",
+	"manager.payment.paid":          "💫 Платіж #%d: %s оголошення #%d на %s, %d ⭐ від %d",
+	"manager.refund.usage":          "Використання: /refund <ID платежу> [причина]",
+	"manager.refund.invalid_id":     "❌ Невірний ID платежу.",
+	"manager.refund.done":           "✅ Платіж #%d повернуто (%d ⭐).",
+	"manager.refund.default_reason": "повернення менеджером",
+
+	"manager.owner.renewed":        "🔄 Власник продовжив оголошення #%d «%s» на %s (до %s).",
+	"manager.owner.deactivated":    "❌ Власник зняв оголошення #%d «%s» з біржі.",
+	"manager.owner.auto_renew_off": "⏹ Власник вимкнув автопродовження оголошення #%d «%s».",
+
+	"manager.button.back":          "◀️ Назад",
+	"manager.button.cancel":        "◀️ Скасувати",
+	"manager.button.menu":          "◀️ До меню",
+	"manager.button.skip":          "⏭ Пропустити",
+	"manager.button.skip_username": "⏭ Пропустити (без username)",
+	"manager.button.skip_user_id":  "⏭ Пропустити (вказати ID вручну)",
+	"manager.button.yes":           "✅ Так",
+	"manager.button.no":            "❌ Ні",
+	"manager.button.edit":          "✏️ Змінити",
+	"manager.button.confirm":       "✅ Підтвердити",
+	"manager.button.save":          "✅ Зберегти",
+	"manager.choose_button":        "👇 Оберіть варіант кнопками нижче.",
+	"manager.choose_setting":       "👇 Оберіть, що змінити, кнопками нижче.",
+
+	"manager.menu.title":     "📋 *Меню менеджера*\n\nОберіть дію:",
+	"manager.menu.new_ad":    "➕ Створити оголошення",
+	"manager.menu.find_ad":   "🔍 Знайти оголошення",
+	"manager.menu.blacklist": "🚫 Чорний список",
+	"manager.menu.premium":   "⭐ Преміум-місця",
+	"manager.menu.top_ads":   "📈 Топ оголошень",
+	"manager.menu.stats":     "📊 Статистика",
+	"manager.menu.broadcast": "📣 Розсилка",
+
+	"manager.blacklist.title":            "🚫 *Керування чорним списком*",
+	"manager.blacklist.button.view":      "📋 Перегляд",
+	"manager.blacklist.button.add":       "➕ Додати",
+	"manager.blacklist.button.remove":    "➖ Видалити",
+	"manager.blacklist.button.import":    "📥 Імпорт",
+	"manager.blacklist.button.export":    "📤 Експорт",
+	"manager.blacklist.load_failed":      "Помилка завантаження чорного списку.",
+	"manager.blacklist.empty":            "📋 *Чорний список порожній*",
+	"manager.blacklist.list":             "📋 *Чорний список:*\n\n",
+	"manager.blacklist.more":             "\n... і ще %d користувачів",
+	"manager.blacklist.add_prompt":       "➕ *Додати до чорного списку*\n\nНадішліть username (наприклад: @username). Через пробіл можна вказати причину.",
+	"manager.blacklist.remove_prompt":    "➖ *Видалити з чорного списку*\n\nНадішліть username (наприклад: @username)",
+	"manager.blacklist.invalid_username": "❌ Введіть username у форматі @username",
+	"manager.blacklist.update_failed":    "❌ Помилка під час оновлення чорного списку.",
+	"manager.blacklist.added":            "✅ Додано до чорного списку: @%s",
+	"manager.blacklist.not_listed":       "❌ Користувача @%s немає в чорному списку",
+	"manager.blacklist.removed":          "✅ Видалено з чорного списку: @%s",
+
+	"manager.forward.no_user":        "❌ Не вдалося отримати інформацію про користувача. Переконайтеся, що користувач дозволив пересилання повідомлень.",
+	"manager.forward.no_id":          "❌ Не вдалося отримати ID користувача. Переконайтеся, що користувач дозволив пересилання повідомлень.",
+	"manager.forward.received":       "✅ Отримано ID користувача: %d\n\nЩоб створити оголошення, використайте /newad",
+	"manager.forward.invalid":        "❌ Перешліть повідомлення від користувача або введіть ID вручну (лише цифри).",
+	"manager.owner.save_failed":      "❌ Не вдалося зберегти користувача.",
+	"manager.owner.received":         "✅ ID користувача отримано: %d\n✅ Username: @%s",
+	"manager.owner.username_prompt":  "✅ ID користувача отримано: %d\n\n👤 *Введіть username для контакту* (наприклад: @username)\n\nАбо натисніть \"Пропустити\", якщо username не потрібен.",
+	"manager.owner.invalid_username": "❌ Введіть username у форматі @username або натисніть \"Пропустити\".",
+	"manager.owner.id_prompt":        "🆔 *ID користувача*\n\nПерешліть будь-яке повідомлення від користувача, щоб автоматично отримати його ID.\n\nАбо натисніть \"Пропустити\", щоб ввести ID вручну.",
+	"manager.owner.manual_prompt":    "🆔 *Введення ID клієнта*\n\nВведіть ID клієнта вручну (лише цифри):",
+	"manager.owner.id_empty":         "❌ ID клієнта не може бути порожнім. Введіть ID або перешліть повідомлення від користувача.",
+	"manager.owner.id_invalid":       "❌ ID клієнта має бути числом. Введіть ID або перешліть повідомлення від користувача.",
+	"manager.owner.id_required":      "❌ Потрібно вказати ID клієнта. Поверніться до попереднього перегляду та введіть ID клієнта.",
+
+	"manager.find.prompt":       "🔍 *Знайти оголошення*\n\nНадішліть ID клієнта (лише цифри) або перешліть будь-яке повідомлення від користувача:",
+	"manager.find.failed":       "❌ Помилка під час пошуку оголошень.",
+	"manager.find.user_empty":   "❌ Оголошень для користувача ID %d не знайдено.",
+	"manager.find.client_empty": "❌ Оголошень для клієнта ID %d не знайдено.",
+	"manager.find.title":        "📋 *Знайдено оголошень: %d*\n\n",
+	"manager.find.more":         "\n... і ще %d оголошень",
+	"manager.find.send_failed":  "❌ Не вдалося надіслати результати пошуку.",
+	"manager.find.expired":      "🔴 Закінчилось",
+	"manager.find.inactive":     "⚫ Знято",
+	"manager.find.active":       "🟢 Активне",
+
+	"manager.step.photo":                "📸 *Крок 1: Фото*\n\nНадішліть фото оголошення або пропустіть цей крок.",
+	"manager.step.photo_edit":           "📸 *Крок 1: Фото*\n\nНадішліть нове фото або пропустіть цей крок.",
+	"manager.step.photo_failed":         "❌ Не вдалося зберегти фото, спробуйте ще раз.",
+	"manager.step.title":                "📝 *Крок 2: Заголовок*\n\nВведіть заголовок оголошення (до 128 символів).",
+	"manager.step.title_empty":          "❌ Заголовок не може бути порожнім.",
+	"manager.step.desc":                 "📄 *Крок 3: Опис*\n\nВведіть опис оголошення.",
+	"manager.step.desc_empty":           "❌ Опис не може бути порожнім.",
+	"manager.step.user_id":              "🆔 *Крок 4: ID користувача*\n\nПерешліть будь-яке повідомлення від користувача, щоб автоматично отримати його ID.\n\nАбо натисніть \"Пропустити\", щоб ввести ID вручну.",
+	"manager.step.category":             "📂 *Крок 5: Категорія*\n\nОберіть категорію оголошення.",
+	"manager.step.category_no_modes":    "❌ У цій категорії немає режимів. Додайте режим командою /taxonomy або оберіть іншу категорію.",
+	"manager.step.mode":                 "🎯 *Крок 6: Режим*\n\nОберіть режим оголошення.",
+	"manager.step.tag":                  "🏷 *Крок 7: Тег*\n\nОберіть тег оголошення.",
+	"manager.step.duration":             "⏱ *Крок 8: Термін дії*\n\nОберіть термін показу оголошення.",
+	"manager.step.premium":              "⭐ *Крок 9: Преміум-розміщення*\n\nПреміум-оголошення показуватиметься вгорі списку.",
+	"manager.step.premium_queue_hint":   "\nМожна поставити оголошення в чергу — воно стане преміум, щойно місце звільниться.",
+	"manager.step.premium_full":         " Поставте оголошення в чергу або зніміть одне з поточних.",
+	"manager.step.premium_check_failed": "❌ Не вдалося перевірити ліміт преміум-оголошень.",
+	"manager.step.button.queue":         "📅 У чергу",
+	"manager.step.current_m":            "\n\nПоточний: %s",
+	"manager.step.current_n":            "\n\nПоточний: %s",
+	"manager.step.current_f":            "\n\nПоточна: %s",
+	"manager.duration.invalid":          "❌ Невірний термін.",
+
+	"manager.ad.invalid_id":          "❌ Невірний ID оголошення.",
+	"manager.ad.not_found":           "❌ Оголошення не знайдено.",
+	"manager.ad.update_failed":       "❌ Не вдалося оновити оголошення.",
+	"manager.ad.save_failed":         "❌ Не вдалося зберегти оголошення: %s",
+	"manager.ad.published":           "✅ Оголошення #%d опубліковано.",
+	"manager.ad.updated":             "✅ Оголошення #%d оновлено.",
+	"manager.ad.removed":             "✅ Оголошення #%d знято з біржі.",
+	"manager.ad.renew_prompt":        "🔄 *Продовжити оголошення*\n\nОберіть термін продовження:",
+	"manager.ad.renewed":             "✅ Оголошення #%d продовжено до %s.",
+	"manager.ad.bump_failed":         "❌ Не вдалося підняти оголошення.",
+	"manager.ad.bumped":              "✅ Оголошення #%d піднято у стрічці.",
+	"manager.ad.publish_failed":      "❌ Не вдалося викласти оголошення.",
+	"manager.ad.relisted":            "✅ Оголошення #%d викладено на біржу.",
+	"manager.ad.button.publish":      "✅ Викласти",
+	"manager.ad.button.history":      "📜 Історія",
+	"manager.ad.button.renew":        "🔄 Продовжити",
+	"manager.ad.button.remove":       "❌ Зняти",
+	"manager.ad.button.bump":         "⬆️ Підняти у стрічці",
+	"manager.ad.button.book_premium": "📅 Забронювати преміум",
+
+	"manager.settings.title":             "⚙️ *Налаштування оголошення*\n\n",
+	"manager.settings.category":          "📂 Категорія: %s\n",
+	"manager.settings.mode":              "🎯 Режим: %s\n",
+	"manager.settings.tag":               "🏷 Тег: %s\n",
+	"manager.settings.price":             "💰 Ціна: %s\n",
+	"manager.settings.premium":           "⭐ Преміум: %s\n",
+	"manager.settings.duration":          "⏱ Термін дії: %s\n",
+	"manager.settings.auto_renew":        "🔁 Автопродовження: %s\n\n",
+	"manager.settings.choose":            "Оберіть, що хочете змінити:",
+	"manager.settings.duration_none":     "не задано",
+	"manager.settings.premium_queued":    "у черзі",
+	"manager.settings.button.category":   "📂 Категорія",
+	"manager.settings.button.mode":       "🎯 Режим",
+	"manager.settings.button.tag":        "🏷 Тег",
+	"manager.settings.button.duration":   "⏱ Термін",
+	"manager.settings.button.premium":    "⭐ Преміум",
+	"manager.settings.button.auto_renew": "🔁 Автопродовження",
+	"manager.settings.button.price":      "💰 Ціна",
+	"manager.yes":                        "так",
+	"manager.no":                         "ні",
+	"manager.until":                      "до %s",
+
+	"manager.ad.summary":         "📋 *Оголошення #%d*\n\n📝 Заголовок: %s\n📄 Опис: %s\n👤 Контакт: @%s\n📂 Категорія: %s\n🎯 Режим: %s\n🏷 Тег: %s\n💰 Ціна: %s\n⭐ Преміум: %s\n📊 Статус: %s",
+	"manager.ad.status.expired":  "Закінчилось",
+	"manager.ad.status.inactive": "Знято",
+	"manager.ad.status.active":   "Активне",
+	"manager.ad.expires":         "\n⏱ *Дійсне до:* %s",
+	"manager.ad.bumped_at":       "\n⬆️ Піднято у стрічці: %s",
+	"manager.ad.preview":         "📋 *Попередній перегляд оголошення*\n\n📝 Заголовок: %s\n📄 Опис: %s\n👤 Контакт: @%s\n📂 Категорія: %s\n🎯 Режим: %s\n🏷 Тег: %s\n💰 Ціна: %s\n⭐ Преміум: %s\n🆔 ID клієнта: %s\n⏱ Дійсне до: %s\n\nПідтвердіть публікацію:",
+
+	"manager.error.title_empty":    "заголовок не може бути порожнім",
+	"manager.error.desc_empty":     "опис не може бути порожнім",
+	"manager.error.category_empty": "категорія не може бути порожньою",
+	"manager.error.mode_empty":     "режим не може бути порожнім",
+	"manager.error.tag_empty":      "тег не може бути порожнім",
+	"manager.error.owner_empty":    "ID клієнта не може бути порожнім",
+	"manager.error.duration_empty": "термін дії не може бути порожнім",
+	"manager.error.internal":       "внутрішня помилка, подробиці в лозі сервера",
+
+	"manager.price.prompt":        "💰 Введіть ціну в рублях (лише число) або 0, щоб не вказувати ціну.\n\nЗараз: %s",
+	"manager.price.invalid":       "❌ Невірна ціна: %s.",
+	"manager.error.invalid_price": "ціна має бути цілим числом від 0 до 1 000 000 000",
+
+	"manager.history.load_failed":           "❌ Не вдалося завантажити історію.",
+	"manager.history.empty":                 "📜 Оголошення #%d ще не має збережених версій.",
+	"manager.history.title":                 "📜 Історія оголошення #%d\n",
+	"manager.history.current":               " (поточна)",
+	"manager.history.restored_from":         "• відновлена з v%d\n",
+	"manager.history.no_changes":            "• без змін вмісту\n",
+	"manager.history.first":                 "• перша версія\n",
+	"manager.history.button.restore":        "↩️ Відновити v%d",
+	"manager.history.author_legacy":         "до ведення історії",
+	"manager.history.author":                "менеджер %d",
+	"manager.history.restore_failed":        "❌ Не вдалося відновити версію.",
+	"manager.history.restored":              "✅ Оголошення #%d відновлено з версії v%d.",
+	"manager.history.change.title":          "заголовок: «%s» → «%s»",
+	"manager.history.change.desc":           "опис: «%s» → «%s»",
+	"manager.history.change.photo_removed":  "фото видалено",
+	"manager.history.change.photo_added":    "додано фото",
+	"manager.history.change.photo_replaced": "фото замінено",
+	"manager.history.change.contact":        "контакт: @%s → @%s",
+	"manager.history.change.owner":          "змінено власника",
+	"manager.history.change.price":          "ціна: %s → %s",
+	"manager.history.change.section":        "рубрика: %s → %s",
+	"manager.error.revision_not_found":      "версію не знайдено",
+
+	"manager.stats.period.day":              "сьогодні",
+	"manager.stats.period.week":             "7 днів",
+	"manager.stats.period.month":            "30 днів",
+	"manager.stats.source.manager":          "менеджер",
+	"manager.stats.source.owner":            "власник",
+	"manager.stats.source.payment":          "оплата",
+	"manager.stats.source.auto":             "авто",
+	"manager.stats.action.ad_created":       "створено",
+	"manager.stats.action.ad_edited":        "змінено",
+	"manager.stats.action.ad_restored":      "відкочено",
+	"manager.stats.action.ad_renewed":       "продовжено",
+	"manager.stats.action.ad_removed":       "знято",
+	"manager.stats.action.ad_bumped":        "піднято",
+	"manager.stats.action.premium_booked":   "преміум",
+	"manager.stats.action.blacklisted":      "до ЧС",
+	"manager.stats.action.unblacklisted":    "з ЧС",
+	"manager.stats.action.blacklist_import": "імпорт ЧС",
+	"manager.stats.action.broadcast":        "розсилки",
+	"manager.stats.action.ad_request":       "заявки",
+	"manager.stats.load_failed":             "❌ Не вдалося завантажити статистику.",
+	"manager.stats.title":                   "📊 *Статистика: %s* (з %s)\n\n",
+	"manager.stats.new_ads":                 "🆕 Нових оголошень: %d\n",
+	"manager.stats.renewals":                "🔄 Продовжень: %d",
+	"manager.stats.expired":                 "⌛ Закінчилось: %d\n",
+	"manager.stats.removed":                 "🗑 Знято з біржі: %d\n",
+	"manager.stats.premium":                 "⭐ Преміум: %d ⭐ (оплат: %d)\n",
+	"manager.stats.blacklisted":             "🚫 Додано до чорного списку: %d\n",
+	"manager.stats.top_categories":          "\n*Топ категорій*\n",
+	"manager.stats.top_tags":                "\n*Топ тегів*\n",
+	"manager.stats.managers":                "\n*Менеджери*\n",
+	"manager.stats.no_actions":              "Дій за період немає.\n",
+	"manager.stats.button.csv":              "📄 Вивантажити CSV",
+	"manager.stats.csv_failed":              "❌ Не вдалося сформувати CSV.",
+	"manager.stats.caption":                 "📊 Статистика: %s (з %s)",
+
+	"manager.auto_renew.off":              "вимк.",
+	"manager.auto_renew.every":            "кожні %s",
+	"manager.auto_renew.left.one":         ", залишився %d раз",
+	"manager.auto_renew.left.few":         ", залишилося %d рази",
+	"manager.auto_renew.left.many":        ", залишилося %d разів",
+	"manager.auto_renew.left.other":       ", залишилося %d рази",
+	"manager.auto_renew.until":            ", до %s",
+	"manager.auto_renew.times.one":        "%d раз",
+	"manager.auto_renew.times.few":        "%d рази",
+	"manager.auto_renew.times.many":       "%d разів",
+	"manager.auto_renew.times.other":      "%d рази",
+	"manager.auto_renew.prompt":           "🔁 *Автопродовження*\n\nЗараз: %s\n\nКоли термін оголошення закінчиться, його буде продовжено на обраний період, а власник отримає сповіщення. Оберіть період:",
+	"manager.auto_renew.button.off":       "⏹ Вимкнути",
+	"manager.auto_renew.button.unlimited": "Без обмежень",
+	"manager.auto_renew.button.until":     "📅 До дати",
+	"manager.auto_renew.limit_prompt":     "🔁 Продовжувати кожні %s. Скільки разів продовжити?",
+	"manager.auto_renew.until_prompt":     "📅 Введіть дату, до якої продовжувати оголошення, у форматі ДД.ММ.РРРР:",
+	"manager.auto_renew.until_invalid":    "❌ Невірний формат. Приклад: 31.12.2025",
+	"manager.auto_renew.until_past":       "❌ Дата вже минула.",
+
+	"manager.top.usage":    "Використання: /top [днів], від 1 до %d (типово %d)",
+	"manager.top.disabled": "📈 Статистика не збирається: Redis недоступний.",
+	"manager.top.title":    "📈 *Топ оголошень за %s*\n",
+	"manager.top.legend":   "👁 покази · 📖 відкриття · 💬 «Зв'язатися»\n\n",
+	"manager.top.empty":    "За цей період подій немає.",
+	"manager.top.deleted":  "видалено",
+	"manager.top.footer":   "\n_Дані оновлюються раз на хвилину._",
+
+	"manager.taxonomy.usage":           "Використання:\n/taxonomy — список рубрик\n/taxonomy add category <ключ> <назва>\n/taxonomy add mode|tag <категорія> <ключ> <назва>\n/taxonomy rename category <ключ> <назва>\n/taxonomy rename mode|tag <категорія> <ключ> <назва>\n/taxonomy hide|show category <ключ>\n/taxonomy hide|show mode|tag <категорія> <ключ>\n/taxonomy move category <ключ> <позиція>\n/taxonomy move mode|tag <категорія> <ключ> <позиція>\n\nКлюч зберігається в оголошеннях і не змінюється: латинські літери, цифри та _. Приховані рубрики не пропонуються під час створення оголошення і не показуються в Mini App.",
+	"manager.taxonomy.save_failed":     "❌ Не вдалося зберегти рубрики.",
+	"manager.taxonomy.updated":         "✅ Рубрики оновлено.\n\n",
+	"manager.taxonomy.title":           "🗂 Рубрики\n",
+	"manager.taxonomy.tags":            "Теги",
+	"manager.taxonomy.modes":           "Режими",
+	"manager.taxonomy.help":            "\nДокладніше: /taxonomy help",
+	"manager.error.taxonomy_key":       "ключ може містити лише латинські літери, цифри та _, до 32 символів",
+	"manager.error.taxonomy_label":     "назва не може бути порожньою або довшою за 64 символи",
+	"manager.error.taxonomy_exists":    "такий ключ уже є",
+	"manager.error.taxonomy_not_found": "рубрику не знайдено",
+	"manager.error.taxonomy_last_mode": "це останній режим категорії — спочатку додайте або покажіть інший режим",
+
+	"manager.broadcast.segment.active":   "власники активних оголошень",
+	"manager.broadcast.segment.category": "власники оголошень у категорії «%s»",
+	"manager.broadcast.segment.all":      "усі власники оголошень",
+
+	"manager.broadcast.status.completed":    "✅ Завершено",
+	"manager.broadcast.status.cancelled":    "⏹ Зупинено",
+	"manager.broadcast.status.sending":      "⏳ Надсилається",
+	"manager.broadcast.progress":            "📣 Розсилка #%d — %s\n\nАудиторія: %s\nНадіслано: %d з %d\nПомилок: %d",
+	"manager.broadcast.button.cancel":       "⏹ Зупинити",
+	"manager.broadcast.button.no_buttons":   "⏭ Без кнопок",
+	"manager.broadcast.button.all":          "👥 Усі власники оголошень",
+	"manager.broadcast.button.active":       "✅ З активними оголошеннями",
+	"manager.broadcast.button.send":         "🚀 Надіслати (%d)",
+	"manager.broadcast.button.audience":     "👥 Інша аудиторія",
+	"manager.broadcast.button.cancel_draft": "✖️ Скасувати",
+
+	"manager.broadcast.start":            "📣 *Розсилка власникам оголошень*\n\nНадішліть текст повідомлення. Можна фото з підписом, форматування Telegram збережеться.",
+	"manager.broadcast.caption_too_long": "❌ Підпис до фото довший за %d символів.",
+	"manager.broadcast.empty":            "❌ Надішліть текст або фото з підписом.",
+	"manager.broadcast.text_too_long":    "❌ Повідомлення довше за %d символів.",
+	"manager.broadcast.buttons_prompt":   "🔘 *Кнопки*\n\nНадішліть до %d кнопок-посилань, по одній у рядку:\n`Текст кнопки | https://example.com`",
+	"manager.broadcast.audience_prompt":  "👥 *Кому надіслати?*\n\nКатегорія — власники, у яких були оголошення в цій категорії. Користувачі з чорного списку розсилку не отримують.",
+	"manager.broadcast.count_failed":     "❌ Не вдалося підрахувати отримувачів.",
+	"manager.broadcast.preview_failed":   "❌ Telegram не прийняв повідомлення, перевірте текст і кнопки.",
+	"manager.broadcast.preview":          "👆 Так повідомлення побачать користувачі.\n\nАудиторія: %s\nОтримувачів: %d\nШвидкість: до %d повідомлень на секунду",
+	"manager.broadcast.prepare_failed":   "❌ Не вдалося підготувати розсилку.",
+	"manager.broadcast.starting":         "📣 Розсилка запускається…",
+	"manager.broadcast.save_failed":      "❌ Не вдалося зберегти розсилку.",
+	"manager.broadcast.cancel_failed":    "❌ Не вдалося зупинити розсилку.",
+	"manager.broadcast.already_finished": "Розсилку вже завершено.",
+	"manager.broadcast.draft_missing":    "Чернетку розсилки не знайдено, почніть заново.",
+
+	"manager.error.broadcast_button_format":    "рядок «%s»: потрібен формат «Текст | посилання»",
+	"manager.error.broadcast_button_text":      "текст кнопки «%s» довший за %d символів",
+	"manager.error.broadcast_button_link":      "неправильне посилання «%s»",
+	"manager.error.broadcast_no_buttons":       "немає жодної кнопки",
+	"manager.error.broadcast_too_many_buttons": "не більше %d кнопок",
+
+	"manager.premium.full":                  "Усі преміум-місця (%d) у категорії «%s» зайняті.",
+	"manager.premium.free_at":               " Найближче звільниться %s.",
+	"manager.premium.has_free":              "Зараз у категорії є вільне місце.",
+	"manager.premium.load_failed":           "❌ Не вдалося завантажити преміум-місця.",
+	"manager.premium.title":                 "⭐ *Преміум-місця*\n",
+	"manager.premium.category":              "\n*%s* — зайнято %d з %d\n",
+	"manager.premium.active_entry":          "• #%d %s — до %s\n",
+	"manager.premium.queue":                 "Черга:\n",
+	"manager.premium.queue_entry":           "• бронь %d: #%d %s — з %s до %s\n",
+	"manager.premium.queue_hint":            "\nДати в черзі — прогноз: бронь займає перше місце, що звільниться.",
+	"manager.premium.button.cancel_booking": "❌ Скасувати бронь %d (#%d)",
+	"manager.premium.button.asap":           "▶️ Якнайшвидше",
+	"manager.premium.button.until_end":      "До кінця розміщення",
+	"manager.premium.button.slots":          "⭐ Преміум-місця",
+	"manager.premium.cancel_failed":         "❌ Не вдалося скасувати бронь.",
+	"manager.premium.start_prompt":          "📅 *Бронь преміум-місця*\n\n%s\n\nВведіть дату початку у форматі ДД.ММ.РРРР ГГ:ХХ або виберіть «Якнайшвидше».",
+	"manager.premium.start_invalid":         "❌ Неправильний формат. Приклад: 25.12.2025 18:00",
+	"manager.premium.start_past":            "❌ Дата початку вже минула.",
+	"manager.premium.start_after_expiry":    "❌ Оголошення активне лише до %s. Спочатку продовжте його.",
+	"manager.premium.days_prompt":           "📅 Початок: %s\n\nНа який строк забронювати преміум?",
+	"manager.premium.book_failed":           "❌ Не вдалося забронювати преміум.",
+	"manager.premium.booked":                "✅ Бронь %d створено для оголошення #%d.",
+	"manager.premium.expected_start":        "\nОчікуваний початок: %s.",
+	"manager.premium.already_until":         "\nОголошення вже преміум до %s.",
+
+	"manager.error.booking_not_found": "бронь не знайдено або вона вже не активна",
+	"manager.error.ad_not_bookable":   "преміум можна забронювати лише для активного оголошення",
+
+	"manager.blacklist.import_prompt":          "📥 *Імпорт чорного списку*\n\nНадішліть файл CSV або JSON.\n\nCSV: колонки `username,reason,added_at` (причина і дата необов’язкові).\nJSON: масив рядків або об’єктів `{\"username\", \"reason\", \"added_at\"}`.\n\nПеред імпортом буде показано попередній перегляд змін.",
+	"manager.blacklist.import_not_document":    "❌ Надішліть файл CSV або JSON документом.",
+	"manager.blacklist.import_too_large":       "❌ Файл завеликий (максимум 2 МБ).",
+	"manager.blacklist.import_download_failed": "❌ Не вдалося завантажити файл, спробуйте ще раз.",
+	"manager.blacklist.import_parse_failed":    "❌ Не вдалося розібрати файл: %s",
+	"manager.blacklist.import_diff_failed":     "❌ Помилка під час порівняння з чорним списком.",
+	"manager.blacklist.button.import_confirm":  "✅ Імпортувати (%d)",
+	"manager.blacklist.preview":                "📥 *Попередній перегляд імпорту*\n\n",
+	"manager.blacklist.preview_new":            "🆕 Нові: %d\n",
+	"manager.blacklist.preview_existing":       "♻️ Уже в списку: %d\n",
+	"manager.blacklist.preview_invalid":        "⚠️ Некоректні: %d\n",
+	"manager.blacklist.preview_more":           "... і ще %d\n",
+	"manager.blacklist.group_new":              "*Буде додано:*",
+	"manager.blacklist.group_existing":         "*Уже в списку:*",
+	"manager.blacklist.group_invalid":          "*Пропущено:*",
+	"manager.blacklist.nothing_to_import":      "\nНемає чого імпортувати.",
+	"manager.blacklist.import_failed":          "❌ Імпорт не виконано, чорний список не змінено.",
+	"manager.blacklist.imported":               "✅ Імпортовано записів: %d",
+	"manager.blacklist.export_csv_failed":      "❌ Не вдалося сформувати CSV.",
+	"manager.blacklist.export_json_failed":     "❌ Не вдалося сформувати JSON.",
+	"manager.blacklist.export_caption":         "🚫 Чорний список: %d записів",
+}
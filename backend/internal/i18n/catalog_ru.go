@@ -0,0 +1,191 @@
+package i18n
+
+var catalogRU = map[string]string{
+	"language.name":        "Русский",
+	"language.prompt":      "🌐 Выберите язык сообщений бота. Сейчас: %s.",
+	"language.auto":        "Как в Telegram",
+	"language.set":         "✅ Язык сообщений: %s.",
+	"language.save_failed": "❌ Не удалось сохранить язык, попробуйте позже.",
+
+	"unit.days.one":      "%d день",
+	"unit.days.few":      "%d дня",
+	"unit.days.many":     "%d дней",
+	"unit.days.other":    "%d дня",
+	"unit.hours.one":     "%d час",
+	"unit.hours.few":     "%d часа",
+	"unit.hours.many":    "%d часов",
+	"unit.hours.other":   "%d часа",
+	"unit.minutes.one":   "%d минуту",
+	"unit.minutes.few":   "%d минуты",
+	"unit.minutes.many":  "%d минут",
+	"unit.minutes.other": "%d минуты",
+	"unit.days_short":    "%d дн.",
+	"format.date":        "02.01.2006",
+	"format.datetime":    "02.01.2006 15:04",
+	"format.price_none":  "не указана",
+	"format.price":       "%s ₽",
+
+	"error.auth_required":                "требуется авторизация",
+	"error.init_data_required":           "откройте приложение из Telegram",
+	"error.init_data_invalid":            "не удалось проверить данные Telegram, перезапустите приложение",
+	"error.manager_required":             "доступно только менеджерам",
+	"error.rate_limited":                 "слишком много запросов, попробуйте через минуту",
+	"error.invalid_ad_id":                "неверный ID объявления",
+	"error.ad_not_found":                 "объявление не найдено",
+	"error.photo_unavailable":            "не удалось загрузить фото",
+	"error.ads_unavailable":              "не удалось загрузить объявления",
+	"error.username_required":            "укажите username",
+	"error.check_failed":                 "не удалось проверить пользователя",
+	"error.blacklist_unavailable":        "не удалось загрузить чёрный список",
+	"error.federation_disabled":          "федерация выключена",
+	"error.federation_auth_required":     "нужна подпись партнёра федерации",
+	"error.federation_cursor_invalid":    "некорректный токен since",
+	"error.export_format":                "формат должен быть csv или json",
+	"error.export_failed":                "не удалось сформировать выгрузку",
+	"error.bump_failed":                  "не удалось поднять объявление",
+	"error.renew_days":                   "срок продления: 1, 7, 14 или 30 дней",
+	"error.renew_failed":                 "не удалось продлить объявление",
+	"error.deactivate_failed":            "не удалось снять объявление",
+	"error.payment_params_required":      "укажите объявление, товар и срок",
+	"error.payments_unavailable":         "оплата временно недоступна",
+	"error.invoice_failed":               "не удалось выставить счёт, попробуйте позже",
+	"error.premium_calendar_unavailable": "не удалось загрузить календарь премиума",
+	"error.profile_unavailable":          "не удалось загрузить объявления пользователя",
+	"error.ad_not_bumpable":              "поднять можно только активное объявление",
+	"error.bump_cooldown":                "поднять объявление можно не раньше %s",
+	"error.free_renewals_used":           "бесплатные продления закончились — продлите объявление за звёзды",
+	"error.payment_not_found":            "платёж не найден",
+	"error.payment_not_paid":             "платёж ещё не оплачен",
+	"error.ad_not_owned":                 "объявление не найдено или принадлежит другому пользователю",
+	"error.premium_limit":                "все премиум-места заняты, попробуйте позже",
+	"error.invalid_product":              "неизвестный товар",
+	"error.invalid_pay_days":             "неверный срок",
+	"error.ad_not_renewable":             "объявление снято с биржи — для повторной публикации обратитесь к менеджеру",
+	"error.payment_mismatched":           "данные платежа не совпадают со счётом",
+	"error.payment_failed":               "не удалось обработать платёж",
+	"error.search_invalid":               "проверьте рубрику, запрос и диапазон цены",
+	"error.search_limit":                 "достигнут лимит сохранённых поисков",
+	"error.search_not_found":             "поиск не найден",
+	"error.searches_unavailable":         "не удалось загрузить сохранённые поиски",
+	"error.favorite_invalid":             "неверные параметры избранного",
+	"error.favorite_limit":               "в избранном слишком много объявлений",
+	"error.favorites_unavailable":        "не удалось загрузить избранное",
+
+	"scam.listed":  "Осторожно! Мошенник",
+	"scam.blocked": "Осторожно! Мошенник (по данным партнёра %s)",
+	"scam.warned":  "Юзер отмечен партнёром %s как мошенник. Будьте внимательны",
+	"scam.clean":   "Юзер не был замечен в мошеннических схемах",
+
+	"owner.ad_published":              "✅ Ваше объявление «%s» опубликовано до %s.\n\nДля управления обратитесь к %s.",
+	"owner.ad_reposted":               "Ваше объявление «%s» выложено на биржу. Свяжитесь с %s для управления.",
+	"owner.ad_removed":                "Ваше объявление «%s» снято с биржи. Свяжитесь с %s для повторной публикации.",
+	"owner.ad_extended":               "Ваше объявление «%s» продлено до %s.",
+	"owner.premium_started":           "⭐ Ваше объявление «%s» получило премиум-размещение до %s.",
+	"owner.expiry_reminder":           "⏰ Срок действия вашего объявления «%s» истекает через %s — %s.\n\nПродлите размещение кнопками ниже, в приложении или через %s.",
+	"owner.expired":                   "Ваше объявление «%s» больше не отображается на бирже. Продлите его кнопками ниже, в приложении или свяжитесь с %s.",
+	"owner.auto_renewed":              "🔁 Объявление «%s» автоматически продлено на %s — до %s.",
+	"owner.auto_renew_last":           "Это последнее автопродление.",
+	"owner.auto_renew_left":           "Осталось автопродлений: %d.",
+	"owner.auto_renew_disabled":       "⏹ Автопродление объявления «%s» отключено. Оно будет активно до %s.",
+	"owner.auto_renew_disable_failed": "❌ Не удалось отключить автопродление, попробуйте позже.",
+	"owner.renewed":                   "✅ Объявление «%s» продлено на %s — до %s.\nБесплатных продлений в этом месяце осталось: %d.",
+	"owner.removed":                   "❌ Объявление «%s» снято с биржи. Чтобы разместить его снова, обратитесь к %s.",
+	"owner.remove_failed":             "❌ Не удалось снять объявление, попробуйте позже.",
+	"owner.button.renew":              "🔄 %s",
+	"owner.button.remove":             "❌ Снять с биржи",
+	"owner.button.confirm_remove":     "✅ Да, снять",
+	"owner.button.keep":               "↩️ Назад",
+	"owner.button.auto_renew_off":     "⏹ Отключить автопродление",
+
+	"payment.usage":               "Использование: /%s <ID объявления>",
+	"payment.choose_period":       "%s «%s»\nВыберите срок:",
+	"payment.option":              "%s — %d ⭐",
+	"payment.product.renew":       "Продление объявления",
+	"payment.product.premium":     "Премиум-размещение",
+	"payment.invoice_description": "«%s»: +%s",
+	"payment.price_label":         "%s на %s",
+	"payment.invoice_failed":      "❌ Не удалось выставить счёт, попробуйте позже.",
+	"payment.refunded_auto":       "❌ %s. Звёзды возвращены.",
+	"payment.refund_reason_auto":  "автовозврат: %v",
+	"payment.paid":                "✅ Оплата получена. %s «%s» на %s. Объявление активно до %s.",
+	"payment.refunded":            "Вам возвращено %d ⭐ за «%s».",
+	"payment.renew_offer_button":  "⭐ %s — %d ⭐",
+
+	"check.usage":           "Использование: /check @username, /check <ID> или ответ на сообщение пользователя командой /check",
+	"check.failed":          "❌ Не удалось проверить пользователя.",
+	"check.title":           "🔎 *Проверка пользователя*",
+	"check.username_hidden": "ℹ️ Username скрыт — проверка по чёрному списку невозможна.",
+	"check.listed":          "🚫 *В чёрном списке*",
+	"check.reason":          "Причина: %s",
+	"check.clean":           "✅ Не найден в чёрном списке",
+	"check.similar":         "⚠️ Похожие имена в чёрном списке: %s",
+	"check.no_ads":          "📋 Активных объявлений нет",
+	"check.ads":             "📋 *Активные объявления: %d*",
+	"check.more":            "... и ещё %d",
+	"check.ad_until":        "(до %s)",
+	"check.inline_summary":  "%s · активных объявлений: %d",
+	"check.inline_clean":    "Не найден в чёрном списке",
+
+	"guard.welcome": "🛡 *Бот-охранник биржи подключён*\n\n" +
+		"Я предупрежу, если в чате появится пользователь из чёрного списка.\n\n" +
+		"Администраторы могут настроить режим:\n" +
+		"/guard — текущие настройки\n" +
+		"/guard on | off — включить или выключить\n" +
+		"/guard action warn | restrict | ban — только предупреждать, ограничивать или банить (нужны права администратора)\n" +
+		"/guard lang ru | en | uk — язык сообщений в чате\n" +
+		"/check @username — проверить пользователя",
+	"guard.listed":          "⚠️ *Внимание!* %s находится в чёрном списке биржи.",
+	"guard.flagged":         "⚠️ *Внимание!* %s: %s.",
+	"guard.careful":         "Будьте осторожны при сделках.",
+	"guard.no_rights":       "_Не удалось применить ограничение: выдайте боту права администратора._",
+	"guard.banned":          "🚫 Пользователь заблокирован в чате.",
+	"guard.restricted":      "🔇 Пользователь ограничен в отправке сообщений.",
+	"guard.admins_only":     "❌ Настройки охраны доступны только администраторам чата.",
+	"guard.usage":           "Использование: /guard [on | off | action warn | restrict | ban | lang ru | en | uk]",
+	"guard.save_failed":     "❌ Не удалось сохранить настройки.",
+	"guard.enabled":         "включена",
+	"guard.disabled":        "выключена",
+	"guard.action.warn":     "только предупреждение",
+	"guard.action.restrict": "предупреждение и запрет писать",
+	"guard.action.ban":      "предупреждение и бан",
+	"guard.status":          "🛡 *Охрана чата*\n\nСтатус: %s\nДействие: %s\nЯзык: %s",
+
+	"search.alert":              "🔔 Новое объявление по вашему сохранённому поиску:\n\n%s\n%s",
+	"search.button.open":        "Открыть объявление",
+	"search.button.unsubscribe": "🔕 Отписаться от поиска",
+	"search.unsubscribed":       "🔕 Вы отписались от этого поиска. Остальные поиски можно настроить в приложении.",
+	"search.unsubscribe_failed": "❌ Не удалось отписаться, попробуйте позже.",
+	"search.not_found":          "Этот поиск уже удалён.",
+
+	"favorite.renewed":       "🔄 Объявление «%s» из избранного продлено до %s.",
+	"favorite.price_changed": "💰 Цена объявления «%s» из избранного изменилась: %s → %s.",
+	"favorite.expiring":      "⏰ Срок объявления «%s» из избранного скоро закончится — %s.",
+	"favorite.button.mute":   "🔕 Не уведомлять об этом объявлении",
+	"favorite.muted":         "🔕 Уведомления об этом объявлении отключены. Оно осталось в избранном.",
+	"favorite.mute_failed":   "❌ Не удалось отключить уведомления, попробуйте позже.",
+
+	"user.menu":              "👋 Это бот биржи. Здесь можно посмотреть свои объявления, проверить пользователя перед сделкой или подать заявку на размещение.\n\nВыберите действие:",
+	"user.button.my_ads":     "📋 Мои объявления",
+	"user.button.check":      "🔎 Проверить пользователя",
+	"user.button.request":    "📝 Подать заявку на объявление",
+	"user.button.open_app":   "🛒 Открыть биржу",
+	"user.button.menu":       "◀️ Меню",
+	"user.failed":            "❌ Не удалось загрузить данные, попробуйте позже.",
+	"user.my_ads.empty":      "У вас пока нет объявлений. Подайте заявку — менеджер поможет разместить объявление.",
+	"user.my_ads.title":      "📋 Ваши объявления: %d",
+	"user.my_ads.more":       "… и ещё %d — все объявления в приложении.",
+	"user.ad.active":         "✅ Активно до %s",
+	"user.ad.expired":        "⏰ Срок истёк %s",
+	"user.ad.inactive":       "❌ Снято с биржи",
+	"user.ad.stats":          "👁 Просмотры: %d · 📞 Контакты: %d",
+	"user.ad.not_found":      "❌ Объявление не найдено.",
+	"user.check.prompt":      "🔎 Отправьте username пользователя, например @username.",
+	"user.check.invalid":     "❌ Отправьте username в формате @username.",
+	"user.request.prompt":    "📝 Опишите объявление одним сообщением: что предлагаете или ищете, цену и как с вами связаться. Заявку получат менеджеры.",
+	"user.request.too_short": "❌ Опишите объявление подробнее.",
+	"user.request.sent":      "✅ Заявка #%d отправлена менеджерам. Мы напишем, когда её рассмотрят.",
+	"user.request.limit":     "⏳ За сутки можно отправить не больше %d заявок. Попробуйте позже или напишите %s.",
+	"user.request.failed":    "❌ Не удалось отправить заявку, попробуйте позже.",
+	"user.request.accepted":  "✅ Менеджер взял в работу заявку #%d и скоро свяжется с вами.",
+	"user.request.rejected":  "Заявка #%d отклонена. Вопросы можно задать %s.",
+}
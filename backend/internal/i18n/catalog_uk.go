@@ -0,0 +1,191 @@
+package i18n
+
+var catalogUK = map[string]string{
+	"language.name":        "Українська",
+	"language.prompt":      "🌐 Оберіть мову повідомлень бота. Зараз: %s.",
+	"language.auto":        "Як у Telegram",
+	"language.set":         "✅ Мова повідомлень: %s.",
+	"language.save_failed": "❌ Не вдалося зберегти мову, спробуйте пізніше.",
+
+	"unit.days.one":      "%d день",
+	"unit.days.few":      "%d дні",
+	"unit.days.many":     "%d днів",
+	"unit.days.other":    "%d дня",
+	"unit.hours.one":     "%d годину",
+	"unit.hours.few":     "%d години",
+	"unit.hours.many":    "%d годин",
+	"unit.hours.other":   "%d години",
+	"unit.minutes.one":   "%d хвилину",
+	"unit.minutes.few":   "%d хвилини",
+	"unit.minutes.many":  "%d хвилин",
+	"unit.minutes.other": "%d хвилини",
+	"unit.days_short":    "%d дн.",
+	"format.date":        "02.01.2006",
+	"format.datetime":    "02.01.2006 15:04",
+	"format.price_none":  "не вказана",
+	"format.price":       "%s ₽",
+
+	"error.auth_required":                "потрібна авторизація",
+	"error.init_data_required":           "відкрийте застосунок із Telegram",
+	"error.init_data_invalid":            "не вдалося перевірити дані Telegram, перезапустіть застосунок",
+	"error.manager_required":             "доступно лише менеджерам",
+	"error.rate_limited":                 "забагато запитів, спробуйте за хвилину",
+	"error.invalid_ad_id":                "невірний ID оголошення",
+	"error.ad_not_found":                 "оголошення не знайдено",
+	"error.photo_unavailable":            "не вдалося завантажити фото",
+	"error.ads_unavailable":              "не вдалося завантажити оголошення",
+	"error.username_required":            "вкажіть username",
+	"error.check_failed":                 "не вдалося перевірити користувача",
+	"error.blacklist_unavailable":        "не вдалося завантажити чорний список",
+	"error.federation_disabled":          "федерацію вимкнено",
+	"error.federation_auth_required":     "потрібен підпис партнера федерації",
+	"error.federation_cursor_invalid":    "некоректний токен since",
+	"error.export_format":                "формат має бути csv або json",
+	"error.export_failed":                "не вдалося сформувати вивантаження",
+	"error.bump_failed":                  "не вдалося підняти оголошення",
+	"error.renew_days":                   "строк продовження: 1, 7, 14 або 30 днів",
+	"error.renew_failed":                 "не вдалося продовжити оголошення",
+	"error.deactivate_failed":            "не вдалося зняти оголошення",
+	"error.payment_params_required":      "вкажіть оголошення, товар і строк",
+	"error.payments_unavailable":         "оплата тимчасово недоступна",
+	"error.invoice_failed":               "не вдалося виставити рахунок, спробуйте пізніше",
+	"error.premium_calendar_unavailable": "не вдалося завантажити календар преміуму",
+	"error.profile_unavailable":          "не вдалося завантажити оголошення користувача",
+	"error.ad_not_bumpable":              "підняти можна лише активне оголошення",
+	"error.bump_cooldown":                "підняти оголошення можна не раніше %s",
+	"error.free_renewals_used":           "безкоштовні продовження закінчилися — продовжте оголошення за зірки",
+	"error.payment_not_found":            "платіж не знайдено",
+	"error.payment_not_paid":             "платіж ще не оплачено",
+	"error.ad_not_owned":                 "оголошення не знайдено або воно належить іншому користувачу",
+	"error.premium_limit":                "усі преміум-місця зайняті, спробуйте пізніше",
+	"error.invalid_product":              "невідомий товар",
+	"error.invalid_pay_days":             "невірний строк",
+	"error.ad_not_renewable":             "оголошення знято з біржі — для повторної публікації зверніться до менеджера",
+	"error.payment_mismatched":           "дані платежу не збігаються з рахунком",
+	"error.payment_failed":               "не вдалося обробити платіж",
+	"error.search_invalid":               "перевірте рубрику, запит і діапазон ціни",
+	"error.search_limit":                 "досягнуто ліміту збережених пошуків",
+	"error.search_not_found":             "пошук не знайдено",
+	"error.searches_unavailable":         "не вдалося завантажити збережені пошуки",
+	"error.favorite_invalid":             "невірні параметри обраного",
+	"error.favorite_limit":               "в обраному забагато оголошень",
+	"error.favorites_unavailable":        "не вдалося завантажити обране",
+
+	"scam.listed":  "Обережно! Шахрай",
+	"scam.blocked": "Обережно! Шахрай (за даними партнера %s)",
+	"scam.warned":  "Користувача позначено партнером %s як шахрая. Будьте уважні",
+	"scam.clean":   "Користувача не помічено в шахрайських схемах",
+
+	"owner.ad_published":              "✅ Ваше оголошення «%s» опубліковано до %s.\n\nДля керування зверніться до %s.",
+	"owner.ad_reposted":               "Ваше оголошення «%s» викладено на біржу. Зверніться до %s для керування.",
+	"owner.ad_removed":                "Ваше оголошення «%s» знято з біржі. Зверніться до %s для повторної публікації.",
+	"owner.ad_extended":               "Ваше оголошення «%s» продовжено до %s.",
+	"owner.premium_started":           "⭐ Ваше оголошення «%s» отримало преміум-розміщення до %s.",
+	"owner.expiry_reminder":           "⏰ Строк дії вашого оголошення «%s» спливає через %s — %s.\n\nПродовжте розміщення кнопками нижче, у застосунку або через %s.",
+	"owner.expired":                   "Ваше оголошення «%s» більше не показується на біржі. Продовжте його кнопками нижче, у застосунку або зверніться до %s.",
+	"owner.auto_renewed":              "🔁 Оголошення «%s» автоматично продовжено на %s — до %s.",
+	"owner.auto_renew_last":           "Це останнє автопродовження.",
+	"owner.auto_renew_left":           "Залишилося автопродовжень: %d.",
+	"owner.auto_renew_disabled":       "⏹ Автопродовження оголошення «%s» вимкнено. Воно буде активним до %s.",
+	"owner.auto_renew_disable_failed": "❌ Не вдалося вимкнути автопродовження, спробуйте пізніше.",
+	"owner.renewed":                   "✅ Оголошення «%s» продовжено на %s — до %s.\nБезкоштовних продовжень цього місяця залишилося: %d.",
+	"owner.removed":                   "❌ Оголошення «%s» знято з біржі. Щоб розмістити його знову, зверніться до %s.",
+	"owner.remove_failed":             "❌ Не вдалося зняти оголошення, спробуйте пізніше.",
+	"owner.button.renew":              "🔄 %s",
+	"owner.button.remove":             "❌ Зняти з біржі",
+	"owner.button.confirm_remove":     "✅ Так, зняти",
+	"owner.button.keep":               "↩️ Назад",
+	"owner.button.auto_renew_off":     "⏹ Вимкнути автопродовження",
+
+	"payment.usage":               "Використання: /%s <ID оголошення>",
+	"payment.choose_period":       "%s «%s»\nОберіть строк:",
+	"payment.option":              "%s — %d ⭐",
+	"payment.product.renew":       "Продовження оголошення",
+	"payment.product.premium":     "Преміум-розміщення",
+	"payment.invoice_description": "«%s»: +%s",
+	"payment.price_label":         "%s на %s",
+	"payment.invoice_failed":      "❌ Не вдалося виставити рахунок, спробуйте пізніше.",
+	"payment.refunded_auto":       "❌ %s. Зірки повернуто.",
+	"payment.refund_reason_auto":  "автоповернення: %v",
+	"payment.paid":                "✅ Оплату отримано. %s «%s» на %s. Оголошення активне до %s.",
+	"payment.refunded":            "Вам повернуто %d ⭐ за «%s».",
+	"payment.renew_offer_button":  "⭐ %s — %d ⭐",
+
+	"check.usage":           "Використання: /check @username, /check <ID> або відповідь на повідомлення користувача командою /check",
+	"check.failed":          "❌ Не вдалося перевірити користувача.",
+	"check.title":           "🔎 *Перевірка користувача*",
+	"check.username_hidden": "ℹ️ Username приховано — перевірка за чорним списком неможлива.",
+	"check.listed":          "🚫 *У чорному списку*",
+	"check.reason":          "Причина: %s",
+	"check.clean":           "✅ Не знайдено в чорному списку",
+	"check.similar":         "⚠️ Схожі імена в чорному списку: %s",
+	"check.no_ads":          "📋 Активних оголошень немає",
+	"check.ads":             "📋 *Активні оголошення: %d*",
+	"check.more":            "... і ще %d",
+	"check.ad_until":        "(до %s)",
+	"check.inline_summary":  "%s · активних оголошень: %d",
+	"check.inline_clean":    "Не знайдено в чорному списку",
+
+	"guard.welcome": "🛡 *Бот-охоронець біржі підключено*\n\n" +
+		"Я попереджу, якщо в чаті з'явиться користувач із чорного списку.\n\n" +
+		"Адміністратори можуть налаштувати режим:\n" +
+		"/guard — поточні налаштування\n" +
+		"/guard on | off — увімкнути або вимкнути\n" +
+		"/guard action warn | restrict | ban — лише попереджати, обмежувати або банити (потрібні права адміністратора)\n" +
+		"/guard lang ru | en | uk — мова повідомлень у чаті\n" +
+		"/check @username — перевірити користувача",
+	"guard.listed":          "⚠️ *Увага!* %s у чорному списку біржі.",
+	"guard.flagged":         "⚠️ *Увага!* %s: %s.",
+	"guard.careful":         "Будьте обережні під час угод.",
+	"guard.no_rights":       "_Не вдалося застосувати обмеження: надайте боту права адміністратора._",
+	"guard.banned":          "🚫 Користувача заблоковано в чаті.",
+	"guard.restricted":      "🔇 Користувачу обмежено надсилання повідомлень.",
+	"guard.admins_only":     "❌ Налаштування охорони доступні лише адміністраторам чату.",
+	"guard.usage":           "Використання: /guard [on | off | action warn | restrict | ban | lang ru | en | uk]",
+	"guard.save_failed":     "❌ Не вдалося зберегти налаштування.",
+	"guard.enabled":         "увімкнена",
+	"guard.disabled":        "вимкнена",
+	"guard.action.warn":     "лише попередження",
+	"guard.action.restrict": "попередження та заборона писати",
+	"guard.action.ban":      "попередження та бан",
+	"guard.status":          "🛡 *Охорона чату*\n\nСтатус: %s\nДія: %s\nМова: %s",
+
+	"search.alert":              "🔔 Нове оголошення за вашим збереженим пошуком:\n\n%s\n%s",
+	"search.button.open":        "Відкрити оголошення",
+	"search.button.unsubscribe": "🔕 Відписатися від пошуку",
+	"search.unsubscribed":       "🔕 Ви відписалися від цього пошуку. Інші пошуки можна налаштувати в застосунку.",
+	"search.unsubscribe_failed": "❌ Не вдалося відписатися, спробуйте пізніше.",
+	"search.not_found":          "Цей пошук уже видалено.",
+
+	"favorite.renewed":       "🔄 Оголошення «%s» з обраного продовжено до %s.",
+	"favorite.price_changed": "💰 Ціна оголошення «%s» з обраного змінилася: %s → %s.",
+	"favorite.expiring":      "⏰ Строк оголошення «%s» з обраного скоро спливає — %s.",
+	"favorite.button.mute":   "🔕 Не сповіщати про це оголошення",
+	"favorite.muted":         "🔕 Сповіщення про це оголошення вимкнено. Воно залишилося в обраному.",
+	"favorite.mute_failed":   "❌ Не вдалося вимкнути сповіщення, спробуйте пізніше.",
+
+	"user.menu":              "👋 Це бот біржі. Тут можна переглянути свої оголошення, перевірити користувача перед угодою або подати заявку на розміщення.\n\nОберіть дію:",
+	"user.button.my_ads":     "📋 Мої оголошення",
+	"user.button.check":      "🔎 Перевірити користувача",
+	"user.button.request":    "📝 Подати заявку на оголошення",
+	"user.button.open_app":   "🛒 Відкрити біржу",
+	"user.button.menu":       "◀️ Меню",
+	"user.failed":            "❌ Не вдалося завантажити дані, спробуйте пізніше.",
+	"user.my_ads.empty":      "У вас поки немає оголошень. Подайте заявку — менеджер допоможе розмістити оголошення.",
+	"user.my_ads.title":      "📋 Ваші оголошення: %d",
+	"user.my_ads.more":       "… і ще %d — усі оголошення в застосунку.",
+	"user.ad.active":         "✅ Активне до %s",
+	"user.ad.expired":        "⏰ Термін минув %s",
+	"user.ad.inactive":       "❌ Знято з біржі",
+	"user.ad.stats":          "👁 Перегляди: %d · 📞 Контакти: %d",
+	"user.ad.not_found":      "❌ Оголошення не знайдено.",
+	"user.check.prompt":      "🔎 Надішліть username користувача, наприклад @username.",
+	"user.check.invalid":     "❌ Надішліть username у форматі @username.",
+	"user.request.prompt":    "📝 Опишіть оголошення одним повідомленням: що пропонуєте або шукаєте, ціну та як з вами зв'язатися. Заявку отримають менеджери.",
+	"user.request.too_short": "❌ Опишіть оголошення детальніше.",
+	"user.request.sent":      "✅ Заявку #%d надіслано менеджерам. Ми напишемо, коли її розглянуть.",
+	"user.request.limit":     "⏳ За добу можна надіслати не більше %d заявок. Спробуйте пізніше або напишіть %s.",
+	"user.request.failed":    "❌ Не вдалося надіслати заявку, спробуйте пізніше.",
+	"user.request.accepted":  "✅ Менеджер узяв у роботу заявку #%d і незабаром зв'яжеться з вами.",
+	"user.request.rejected":  "Заявку #%d відхилено. Запитання можна поставити %s.",
+}
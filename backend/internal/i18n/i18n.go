@@ -0,0 +1,211 @@
+// Package i18n — каталог сообщений бота и API на русском, английском и украинском.
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	RU = "ru"
+	EN = "en"
+	UK = "uk"
+)
+
+// Supported — поддерживаемые языки в порядке показа пользователю
+var Supported = []string{RU, EN, UK}
+
+var catalogs = map[string]map[string]string{
+	RU: merge(catalogRU, catalogManagerRU),
+	EN: merge(catalogEN, catalogManagerEN),
+	UK: merge(catalogUK, catalogManagerUK),
+}
+
+// merge объединяет части каталога одного языка; ключи в частях не пересекаются
+func merge(parts ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, part := range parts {
+		for key, message := range part {
+			if _, ok := merged[key]; ok {
+				panic("i18n: duplicate message " + key)
+			}
+			merged[key] = message
+		}
+	}
+	return merged
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultLocale = RU
+)
+
+// Default — язык для пользователей, чей язык неизвестен или не поддерживается.
+// Читается из DEFAULT_LOCALE, по умолчанию русский.
+func Default() string {
+	defaultOnce.Do(func() {
+		raw := strings.TrimSpace(os.Getenv("DEFAULT_LOCALE"))
+		if raw == "" {
+			return
+		}
+		if locale := Normalize(raw); locale != "" {
+			defaultLocale = locale
+			return
+		}
+		log.Printf("i18n: invalid DEFAULT_LOCALE=%q, using default %s", raw, defaultLocale)
+	})
+	return defaultLocale
+}
+
+// Normalize приводит код языка Telegram или Accept-Language («en-US», «uk») к поддерживаемому
+// языку. Для неподдерживаемых языков возвращает пустую строку.
+func Normalize(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	switch code {
+	case RU, EN, UK:
+		return code
+	case "be", "kk":
+		// Пользователям из Беларуси и Казахстана понятнее русский, чем английский
+		return RU
+	default:
+		return ""
+	}
+}
+
+// Resolve возвращает первый поддерживаемый язык из списка кандидатов
+// (например, выбранный пользователем, затем язык Telegram) или язык по умолчанию
+func Resolve(candidates ...string) string {
+	for _, candidate := range candidates {
+		if locale := Normalize(candidate); locale != "" {
+			return locale
+		}
+	}
+	return Default()
+}
+
+// T возвращает сообщение key на языке locale, подставляя args через fmt.Sprintf.
+// Если перевода нет, используется язык по умолчанию, затем русский, затем сам ключ.
+func T(locale, key string, args ...interface{}) string {
+	format, ok := lookup(locale, key)
+	if !ok {
+		log.Printf("i18n: missing message %q", key)
+		return key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// N возвращает форму сообщения key для числа n (ключи key.one, key.few, key.many, key.other)
+func N(locale, key string, n int, args ...interface{}) string {
+	locale = Resolve(locale)
+	if format, ok := lookup(locale, key+"."+PluralForm(locale, n)); ok {
+		return fmt.Sprintf(format, args...)
+	}
+	return T(locale, key+".other", args...)
+}
+
+// PluralForm — категория числа по правилам CLDR: one/few/many для русского и украинского,
+// one/other для английского
+func PluralForm(locale string, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	switch locale {
+	case RU, UK:
+		mod10, mod100 := n%10, n%100
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return "one"
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	default:
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	}
+}
+
+// reportedFallbacks — пары «язык/ключ», о переводе которых уже написали в лог
+var reportedFallbacks sync.Map
+
+func lookup(locale, key string) (string, bool) {
+	requested := Normalize(locale)
+	for _, candidate := range []string{requested, Default(), RU} {
+		if format, ok := catalogs[candidate][key]; ok {
+			if candidate != requested && requested != "" {
+				reportFallback(requested, candidate, key)
+			}
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// reportFallback пишет в лог (один раз на язык и ключ), что сообщение показано не на том
+// языке: каталоги проверяет TestCatalogsComplete, но пропуск не должен проходить молча
+func reportFallback(requested, used, key string) {
+	if _, seen := reportedFallbacks.LoadOrStore(requested+"/"+key, struct{}{}); !seen {
+		log.Printf("i18n: message %q has no %s translation, using %s", key, requested, used)
+	}
+}
+
+// Name — название языка на нём самом
+func Name(locale string) string {
+	return T(locale, "language.name")
+}
+
+// Localizer — ошибка или значение, которое умеет описать себя на нужном языке
+type Localizer interface {
+	Localize(locale string) string
+}
+
+// Error — ошибка с ключом сообщения. Error() возвращает текст на языке по умолчанию,
+// чтобы ошибку можно было писать в лог и сравнивать через errors.Is.
+type Error struct {
+	Key  string
+	Args []interface{}
+}
+
+func NewError(key string, args ...interface{}) *Error {
+	return &Error{Key: key, Args: args}
+}
+
+func (e *Error) Error() string {
+	return e.Localize(Default())
+}
+
+func (e *Error) Localize(locale string) string {
+	return T(locale, e.Key, e.Args...)
+}
+
+// Message описывает ошибку на языке locale. Для ошибок без перевода возвращает
+// сообщение fallbackKey, чтобы пользователь не увидел внутренний текст ошибки.
+func Message(locale string, err error, fallbackKey string) string {
+	var localizer Localizer
+	if errors.As(err, &localizer) {
+		return localizer.Localize(locale)
+	}
+	return T(locale, fallbackKey)
+}
+
+// Code — ключ сообщения ошибки для клиентов API (пустая строка для ошибок без ключа)
+func Code(err error) string {
+	var localized *Error
+	if errors.As(err, &localized) {
+		return localized.Key
+	}
+	return ""
+}
@@ -0,0 +1,169 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// pluralForms — формы, которые должен содержать каталог для ключей с числом (см. PluralForm)
+var pluralForms = map[string][]string{
+	RU: {"one", "few", "many", "other"},
+	UK: {"one", "few", "many", "other"},
+	EN: {"one", "other"},
+}
+
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func pluralBase(key string) (string, bool) {
+	for _, form := range []string{".one", ".few", ".many", ".other"} {
+		if strings.HasSuffix(key, form) {
+			return strings.TrimSuffix(key, form), true
+		}
+	}
+	return "", false
+}
+
+// verbs — подстановки fmt в сообщении; переводы должны принимать те же аргументы
+func verbs(format string) []string {
+	var result []string
+	for _, verb := range verbPattern.FindAllString(format, -1) {
+		if verb != "%%" {
+			result = append(result, verb)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestCatalogsComplete(t *testing.T) {
+	plain := make(map[string]bool)
+	plural := make(map[string]bool)
+	for _, catalog := range catalogs {
+		for key := range catalog {
+			if base, ok := pluralBase(key); ok {
+				plural[base] = true
+			} else {
+				plain[key] = true
+			}
+		}
+	}
+
+	for _, locale := range Supported {
+		catalog := catalogs[locale]
+		for key := range plain {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("%s: missing message %q", locale, key)
+			}
+		}
+		for base := range plural {
+			for _, form := range pluralForms[locale] {
+				if _, ok := catalog[base+"."+form]; !ok {
+					t.Errorf("%s: missing plural form %q", locale, base+"."+form)
+				}
+			}
+		}
+	}
+}
+
+func TestCatalogsUseSameArguments(t *testing.T) {
+	for key, format := range catalogs[RU] {
+		if _, ok := pluralBase(key); ok {
+			continue
+		}
+		want := strings.Join(verbs(format), " ")
+		for _, locale := range []string{EN, UK} {
+			translated, ok := catalogs[locale][key]
+			if !ok {
+				continue
+			}
+			if got := strings.Join(verbs(translated), " "); got != want {
+				t.Errorf("%s %q: arguments %q, want %q as in ru", locale, key, got, want)
+			}
+		}
+	}
+}
+
+// TestCodeKeysExist проверяет, что каждый ключ, записанный в коде строковым литералом
+// (i18n.T(locale, "owner.expired"), respondError(c, 404, "error.ad_not_found") и т. п.),
+// есть в каталоге. Литерал считается ключом, если его первая часть — раздел каталога.
+func TestCodeKeysExist(t *testing.T) {
+	namespaces := make(map[string]bool)
+	for key := range catalogs[RU] {
+		namespaces[strings.SplitN(key, ".", 2)[0]] = true
+	}
+	keyPattern := regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z0-9_]+)+$`)
+
+	root := filepath.Join("..", "..")
+	fset := token.NewFileSet()
+	checked := 0
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") ||
+			strings.HasPrefix(filepath.Base(path), "catalog_") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			for _, arg := range call.Args {
+				literal, ok := arg.(*ast.BasicLit)
+				if !ok || literal.Kind != token.STRING {
+					continue
+				}
+				key, err := strconv.Unquote(literal.Value)
+				if err != nil || !keyPattern.MatchString(key) || !namespaces[strings.SplitN(key, ".", 2)[0]] {
+					continue
+				}
+				checked++
+				if _, ok := catalogs[RU][key]; ok {
+					continue
+				}
+				if _, ok := catalogs[RU][key+".other"]; ok {
+					continue
+				}
+				t.Errorf("%s: message %q is not in the catalog", fset.Position(literal.Pos()), key)
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checked == 0 {
+		t.Fatal("no message keys found in the source tree")
+	}
+}
+
+func TestPluralForm(t *testing.T) {
+	cases := []struct {
+		locale string
+		n      int
+		want   string
+	}{
+		{RU, 1, "one"}, {RU, 3, "few"}, {RU, 5, "many"}, {RU, 11, "many"}, {RU, 21, "one"}, {RU, 22, "few"},
+		{UK, 14, "many"}, {UK, 104, "few"},
+		{EN, 1, "one"}, {EN, 0, "other"}, {EN, 2, "other"},
+	}
+	for _, c := range cases {
+		if got := PluralForm(c.locale, c.n); got != c.want {
+			t.Errorf("PluralForm(%s, %d) = %s, want %s", c.locale, c.n, got, c.want)
+		}
+	}
+}
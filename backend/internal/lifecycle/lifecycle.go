@@ -0,0 +1,130 @@
+package lifecycle
+
+import (
+	"context"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultShutdownTimeout — сколько ждём завершения запросов, бота и планировщиков при остановке
+const defaultShutdownTimeout = 30 * time.Second
+
+// Closer — ресурс, который закрывается после остановки всех фоновых задач (БД, Redis)
+type Closer struct {
+	Name  string
+	Close func() error
+}
+
+// Manager управляет фоновыми задачами приложения и порядком их остановки.
+// Задачи получают общий контекст, который отменяется в Stop; после их завершения
+// ресурсы закрываются в порядке регистрации.
+type Manager struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	closers []Closer
+	running map[string]int
+}
+
+// New создаёт менеджер, контекст которого наследуется от parent
+func New(parent context.Context) *Manager {
+	ctx, cancel := context.WithCancel(parent)
+	return &Manager{ctx: ctx, cancel: cancel, running: make(map[string]int)}
+}
+
+// Context возвращает контекст, отменяемый при остановке
+func (m *Manager) Context() context.Context {
+	return m.ctx
+}
+
+// Go запускает фоновую задачу; Stop дожидается её завершения
+func (m *Manager) Go(name string, run func(ctx context.Context)) {
+	m.mu.Lock()
+	m.running[name]++
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		run(m.ctx)
+
+		m.mu.Lock()
+		if m.running[name]--; m.running[name] == 0 {
+			delete(m.running, name)
+		}
+		m.mu.Unlock()
+		log.Printf("lifecycle: %s stopped", name)
+	}()
+}
+
+// Running возвращает имена задач, которые ещё не завершились
+func (m *Manager) Running() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.running))
+	for name := range m.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OnClose регистрирует ресурс, который нужно закрыть после остановки задач
+func (m *Manager) OnClose(name string, close func() error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closers = append(m.closers, Closer{Name: name, Close: close})
+}
+
+// Stop отменяет контекст задач, ждёт их завершения (не дольше дедлайна ctx)
+// и закрывает зарегистрированные ресурсы по порядку. Если какие-то задачи не успели
+// остановиться, ресурсы не закрываются: задачи ещё пишут в БД и Redis, а соединения
+// освободятся при выходе процесса. Возвращает false, если остановка не уложилась в дедлайн.
+func (m *Manager) Stop(ctx context.Context) bool {
+	m.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		running := m.Running()
+		log.Printf("lifecycle: background tasks did not stop in time (%v): %s", ctx.Err(), strings.Join(running, ", "))
+		log.Printf("lifecycle: skipping close of resources while %d tasks are still running", len(running))
+		return false
+	}
+
+	m.mu.Lock()
+	closers := m.closers
+	m.mu.Unlock()
+
+	for _, closer := range closers {
+		if err := closer.Close(); err != nil {
+			log.Printf("lifecycle: failed to close %s: %v", closer.Name, err)
+			continue
+		}
+		log.Printf("lifecycle: %s closed", closer.Name)
+	}
+	return true
+}
+
+// ShutdownTimeout читает SHUTDOWN_TIMEOUT (например 30s), по умолчанию 30 секунд
+func ShutdownTimeout() time.Duration {
+	if raw := strings.TrimSpace(os.Getenv("SHUTDOWN_TIMEOUT")); raw != "" {
+		if timeout, err := time.ParseDuration(raw); err == nil && timeout > 0 {
+			return timeout
+		}
+		log.Printf("lifecycle: invalid SHUTDOWN_TIMEOUT=%q, using default %s", raw, defaultShutdownTimeout)
+	}
+	return defaultShutdownTimeout
+}
@@ -0,0 +1,57 @@
+package lifecycle
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStopClosesResourcesAfterTasks(t *testing.T) {
+	m := New(context.Background())
+
+	var order []string
+	m.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		order = append(order, "worker")
+	})
+	m.OnClose("redis", func() error { order = append(order, "redis"); return nil })
+	m.OnClose("database", func() error { order = append(order, "database"); return nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if !m.Stop(ctx) {
+		t.Fatal("Stop reported a timeout")
+	}
+
+	if want := []string{"worker", "redis", "database"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("order %v, want %v", order, want)
+	}
+	if running := m.Running(); len(running) != 0 {
+		t.Fatalf("tasks still running: %v", running)
+	}
+}
+
+func TestStopSkipsClosersWhenTaskTimesOut(t *testing.T) {
+	m := New(context.Background())
+
+	release := make(chan struct{})
+	defer close(release)
+	m.Go("stuck job", func(ctx context.Context) { <-release })
+	m.Go("worker", func(ctx context.Context) { <-ctx.Done() })
+
+	closed := false
+	m.OnClose("database", func() error { closed = true; return nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if m.Stop(ctx) {
+		t.Fatal("Stop did not report the timeout")
+	}
+	if closed {
+		t.Fatal("database closed while a task was still running")
+	}
+	if running := m.Running(); len(running) == 0 || running[0] != "stuck job" {
+		t.Fatalf("running %v, want stuck job first", running)
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ManagerOnlyMiddleware пропускает только менеджеров биржи.
+// Должен стоять после TMAuthMiddleware, который кладёт user_id в контекст.
+func ManagerOnlyMiddleware(isManager func(int64) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, exists := c.Get("user_id")
+		userID, _ := value.(int64)
+		if !exists || userID == 0 {
+			abortWithError(c, http.StatusUnauthorized, "error.auth_required")
+			return
+		}
+
+		if !isManager(userID) {
+			abortWithError(c, http.StatusForbidden, "error.manager_required")
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -25,16 +25,14 @@ func TMAuthMiddleware() gin.HandlerFunc {
 		}
 
 		if initData == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "init_data is required"})
-			c.Abort()
+			abortWithError(c, http.StatusUnauthorized, "error.init_data_required")
 			return
 		}
 
 		// Валидируем init_data
 		data, valid := telegram.ValidateInitData(initData, botToken)
 		if !valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid init_data"})
-			c.Abort()
+			abortWithError(c, http.StatusUnauthorized, "error.init_data_invalid")
 			return
 		}
 
@@ -49,6 +47,10 @@ func TMAuthMiddleware() gin.HandlerFunc {
 			c.Set("username", username)
 		}
 
+		if language := telegram.ExtractLanguage(data); language != "" {
+			c.Set("language_code", language)
+		}
+
 		// Сохраняем все данные для дальнейшего использования
 		c.Set("init_data", data)
 
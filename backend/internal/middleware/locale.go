@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"strings"
+
+	"youtube-market/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// localeKey — ключ контекста с языком ответа, выбранным LocaleMiddleware
+const localeKey = "locale"
+
+// LocaleMiddleware выбирает язык ответов API: сначала язык, выбранный пользователем в боте,
+// затем language_code из init_data, затем Accept-Language.
+// Должен стоять после TMAuthMiddleware. preference получает Telegram ID и language_code
+// из init_data и возвращает сохранённый язык пользователя (или пустую строку).
+func LocaleMiddleware(preference func(telegramID int64, languageCode string) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		languageCode := c.GetString("language_code")
+		var preferred string
+		if value, ok := c.Get("user_id"); ok {
+			if userID, _ := value.(int64); userID != 0 {
+				preferred = preference(userID, languageCode)
+			}
+		}
+
+		candidates := append([]string{preferred, languageCode}, acceptLanguages(c)...)
+		c.Set(localeKey, i18n.Resolve(candidates...))
+		c.Next()
+	}
+}
+
+// Locale возвращает язык ответа для запроса. До LocaleMiddleware (например, в ошибках
+// авторизации) язык берётся из Accept-Language.
+func Locale(c *gin.Context) string {
+	if locale := c.GetString(localeKey); locale != "" {
+		return locale
+	}
+	return i18n.Resolve(acceptLanguages(c)...)
+}
+
+// acceptLanguages — языки из заголовка Accept-Language в порядке перечисления
+func acceptLanguages(c *gin.Context) []string {
+	header := c.GetHeader("Accept-Language")
+	if header == "" {
+		return nil
+	}
+	var languages []string
+	for _, part := range strings.Split(header, ",") {
+		tag, _, _ := strings.Cut(part, ";")
+		if tag = strings.TrimSpace(tag); tag != "" && tag != "*" {
+			languages = append(languages, tag)
+		}
+	}
+	return languages
+}
+
+// abortWithError прерывает запрос с локализованной ошибкой в формате {"error", "code"}
+func abortWithError(c *gin.Context, status int, key string) {
+	c.AbortWithStatusJSON(status, gin.H{"error": i18n.T(Locale(c), key), "code": key})
+}
@@ -42,43 +42,58 @@ func InitRedis() error {
 	return nil
 }
 
-// RateLimitMiddleware ограничивает количество запросов: 10 запросов в минуту на IP
-func RateLimitMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		if rdb == nil {
-			// Если Redis не инициализирован, пропускаем проверку
-			c.Next()
-			return
-		}
+// Redis возвращает клиент Redis для других модулей (например, буфера статистики)
+func Redis() *redis.Client {
+	return rdb
+}
 
-		// Получаем IP адрес
-		ip := c.ClientIP()
-		key := fmt.Sprintf("ratelimit:%s", ip)
+// CloseRedis закрывает подключение к Redis
+func CloseRedis() error {
+	if rdb == nil {
+		return nil
+	}
+	return rdb.Close()
+}
 
-		ctx := context.Background()
+// RateLimitMiddleware ограничивает количество запросов: 10 запросов в минуту на IP.
+// Пути из exempt не ограничиваются по IP — их обработчики ограничивают запросы сами через Allow.
+func RateLimitMiddleware(exempt ...string) gin.HandlerFunc {
+	skip := make(map[string]bool, len(exempt))
+	for _, path := range exempt {
+		skip[path] = true
+	}
 
-		// Получаем текущее количество запросов
-		count, err := rdb.Incr(ctx, key).Result()
-		if err != nil {
-			// Если ошибка Redis, пропускаем проверку
+	return func(c *gin.Context) {
+		if skip[c.FullPath()] {
 			c.Next()
 			return
 		}
 
-		// Устанавливаем TTL для ключа (1 минута)
-		if count == 1 {
-			rdb.Expire(ctx, key, time.Minute)
-		}
-
-		// Проверяем лимит (10 запросов в минуту)
-		if count > 10 {
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error": "rate limit exceeded",
-			})
-			c.Abort()
+		// Проверяем лимит (10 запросов в минуту на IP)
+		if !Allow(fmt.Sprintf("ratelimit:%s", c.ClientIP()), 10) {
+			abortWithError(c, http.StatusTooManyRequests, "error.rate_limited")
 			return
 		}
 
 		c.Next()
 	}
 }
+
+// Allow учитывает запрос в минутном счётчике key и сообщает, не превышен ли limit.
+// Если Redis не инициализирован или недоступен, запрос пропускается.
+func Allow(key string, limit int64) bool {
+	if rdb == nil {
+		return true
+	}
+
+	ctx := context.Background()
+	count, err := rdb.Incr(ctx, key).Result()
+	if err != nil {
+		return true
+	}
+	// Устанавливаем TTL для ключа (1 минута)
+	if count == 1 {
+		rdb.Expire(ctx, key, time.Minute)
+	}
+	return count <= limit
+}
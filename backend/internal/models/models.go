@@ -6,33 +6,47 @@ import (
 )
 
 type User struct {
-	ID        int64          `gorm:"primaryKey" json:"id"`
-	Username  string         `gorm:"uniqueIndex;size:64" json:"username"`
-	IsScammer bool           `json:"is_scammer"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID            int64          `gorm:"primaryKey" json:"id"`
+	Username      string         `gorm:"size:64;uniqueIndex:idx_users_username_set,where:username <> ''" json:"username"`
+	TelegramID    *int64         `gorm:"uniqueIndex" json:"telegram_id,omitempty"`
+	IsScammer     bool           `json:"is_scammer"`
+	ScamReason    string         `gorm:"size:512" json:"scam_reason,omitempty"`
+	BlacklistedAt *time.Time     `json:"blacklisted_at,omitempty"`
+	Language      string         `gorm:"size:8" json:"language,omitempty"`      // язык, выбранный командой /language
+	LanguageCode  string         `gorm:"size:8" json:"language_code,omitempty"` // последний language_code из Telegram
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 type Ad struct {
-	ID                uint           `gorm:"primaryKey" json:"id"`
-	UserID            int64          `json:"user_id"`
-	ClientID          string         `gorm:"size:64;index" json:"client_id"`
-	Username          string         `gorm:"size:64;index" json:"username"`
-	Title             string         `gorm:"size:128" json:"title"`
-	Desc              string         `gorm:"size:2048" json:"desc"`
-	PhotoID           string         `gorm:"size:256" json:"-"`
-	PhotoPath         string         `gorm:"size:512" json:"-"`
-	Category          string         `gorm:"size:32;index" json:"category"`
-	Mode              string         `gorm:"size:16;index" json:"mode"`
-	Tag               string         `gorm:"size:64;index" json:"tag"`
-	IsPremium         bool           `json:"is_premium"`
-	Status            string         `gorm:"size:16;index" json:"status"`
-	ExpiresAt         time.Time      `gorm:"index" json:"expires_at"`
-	PreExpiryNotified bool           `json:"-"`
-	CreatedAt         time.Time      `json:"created_at"`
-	UpdatedAt         time.Time      `json:"updated_at"`
-	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+	ID               uint           `gorm:"primaryKey" json:"id"`
+	OwnerID          *int64         `gorm:"index" json:"owner_id,omitempty"`
+	Owner            *User          `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+	Username         string         `gorm:"size:64;index" json:"username"`
+	Title            string         `gorm:"size:128" json:"title"`
+	Desc             string         `gorm:"size:2048" json:"desc"`
+	PhotoID          string         `gorm:"size:256" json:"-"`
+	PhotoPath        string         `gorm:"size:512" json:"-"`
+	Category         string         `gorm:"size:32;index" json:"category"`
+	Mode             string         `gorm:"size:16;index" json:"mode"`
+	Tag              string         `gorm:"size:64;index" json:"tag"`
+	Price            int64          `json:"price"` // цена в рублях, 0 — не указана
+	IsPremium        bool           `json:"is_premium"`
+	PremiumUntil     *time.Time     `json:"premium_until,omitempty"`
+	Status           string         `gorm:"size:16;index" json:"status"`
+	ExpiresAt        time.Time      `gorm:"index" json:"expires_at"`
+	BumpedAt         *time.Time     `gorm:"index" json:"bumped_at,omitempty"`
+	AutoRenewDays    int            `json:"auto_renew_days"`
+	AutoRenewUntil   *time.Time     `json:"auto_renew_until,omitempty"`
+	AutoRenewLeft    *int           `json:"auto_renew_left,omitempty"`
+	ChannelChatID    int64          `json:"-"`
+	ChannelMessageID int            `json:"-"`
+	ChannelPhotoID   string         `gorm:"size:256" json:"-"`
+	RemovedAt        *time.Time     `gorm:"index" json:"removed_at,omitempty"` // когда объявление снято с биржи
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 const (
@@ -40,3 +54,396 @@ const (
 	AdStatusExpired  = "expired"
 	AdStatusInactive = "inactive"
 )
+
+// PeerBlacklistEntry — запись чёрного списка, полученная от партнёрской биржи.
+// Хранится отдельно от собственного списка (User.IsScammer).
+type PeerBlacklistEntry struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	Peer      string     `gorm:"size:64;uniqueIndex:idx_peer_username" json:"peer"`
+	Username  string     `gorm:"size:64;uniqueIndex:idx_peer_username" json:"username"`
+	Reason    string     `gorm:"size:512" json:"reason,omitempty"`
+	AddedAt   *time.Time `json:"added_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// FederationPeerState хранит курсор синхронизации фида партнёра
+type FederationPeerState struct {
+	Peer       string    `gorm:"primaryKey;size:64" json:"peer"`
+	Cursor     string    `gorm:"size:128" json:"cursor"`
+	LastSyncAt time.Time `json:"last_sync_at"`
+	LastError  string    `gorm:"size:512" json:"last_error,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// GroupGuardSettings — настройки режима охраны для группы, куда добавлен бот
+type GroupGuardSettings struct {
+	ChatID    int64     `gorm:"primaryKey;autoIncrement:false" json:"chat_id"`
+	Title     string    `gorm:"size:256" json:"title"`
+	Enabled   bool      `json:"enabled"`
+	Action    string    `gorm:"size:16" json:"action"`
+	Language  string    `gorm:"size:8" json:"language"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Действия режима охраны при обнаружении пользователя из чёрного списка
+const (
+	GuardActionWarn     = "warn"
+	GuardActionRestrict = "restrict"
+	GuardActionBan      = "ban"
+)
+
+// Payment — платёж в Telegram Stars за продление или премиум-размещение объявления
+type Payment struct {
+	ID                      uint       `gorm:"primaryKey" json:"id"`
+	Payload                 string     `gorm:"size:64;uniqueIndex" json:"-"`
+	UserID                  int64      `gorm:"index" json:"user_id"`
+	AdID                    uint       `gorm:"index" json:"ad_id"`
+	Product                 string     `gorm:"size:16" json:"product"`
+	Days                    int        `json:"days"`
+	Amount                  int        `json:"amount"`
+	Currency                string     `gorm:"size:8" json:"currency"`
+	Status                  string     `gorm:"size:16;index" json:"status"`
+	TelegramPaymentChargeID string     `gorm:"size:128;index" json:"-"`
+	ProviderPaymentChargeID string     `gorm:"size:128" json:"-"`
+	PaidAt                  *time.Time `json:"paid_at,omitempty"`
+	FulfilledAt             *time.Time `json:"fulfilled_at,omitempty"`
+	RefundedAt              *time.Time `json:"refunded_at,omitempty"`
+	RefundReason            string     `gorm:"size:256" json:"refund_reason,omitempty"`
+	CreatedAt               time.Time  `json:"created_at"`
+	UpdatedAt               time.Time  `json:"updated_at"`
+}
+
+// AdBump — запись о поднятии объявления в ленте
+type AdBump struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	AdID      uint      `gorm:"index" json:"ad_id"`
+	BumpedBy  int64     `json:"bumped_by"`
+	Source    string    `gorm:"size:16" json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const (
+	BumpSourceOwner   = "owner"
+	BumpSourceManager = "manager"
+)
+
+// ManagerAction — запись журнала действий менеджера в боте (для статистики по менеджерам)
+type ManagerAction struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ManagerID int64     `gorm:"index" json:"manager_id"`
+	Action    string    `gorm:"size:32;index" json:"action"`
+	AdID      uint      `json:"ad_id,omitempty"`
+	Username  string    `gorm:"size:64" json:"username,omitempty"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+const (
+	ManagerActionAdCreated       = "ad_created"
+	ManagerActionAdEdited        = "ad_edited"
+	ManagerActionAdRestored      = "ad_restored"
+	ManagerActionAdRenewed       = "ad_renewed"
+	ManagerActionAdRemoved       = "ad_removed"
+	ManagerActionAdBumped        = "ad_bumped"
+	ManagerActionPremiumBooked   = "premium_booked"
+	ManagerActionBlacklisted     = "blacklisted"
+	ManagerActionUnblacklisted   = "unblacklisted"
+	ManagerActionBlacklistImport = "blacklist_import"
+	ManagerActionBroadcast       = "broadcast"
+	ManagerActionAdRequest       = "ad_request"
+)
+
+// AdRenewal — запись о продлении объявления (владельцем, менеджером или после оплаты)
+type AdRenewal struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	AdID      uint      `gorm:"index" json:"ad_id"`
+	UserID    int64     `gorm:"index" json:"user_id"`
+	Days      int       `json:"days"`
+	Source    string    `gorm:"size:16;index" json:"source"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+const (
+	RenewalSourceOwner   = "owner"
+	RenewalSourceManager = "manager"
+	RenewalSourcePayment = "payment"
+	RenewalSourceAuto    = "auto"
+)
+
+// PremiumBooking — бронь премиум-места: объявление станет премиум не раньше StartsAt,
+// как только в его категории освободится место. Days = 0 — до окончания срока объявления.
+type PremiumBooking struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	AdID        uint       `gorm:"index" json:"ad_id"`
+	Category    string     `gorm:"size:32;index" json:"category"`
+	StartsAt    time.Time  `gorm:"index" json:"starts_at"`
+	Days        int        `json:"days"`
+	Status      string     `gorm:"size:16;index" json:"status"`
+	BookedBy    int64      `json:"booked_by"`
+	ActivatedAt *time.Time `json:"activated_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+const (
+	PremiumBookingScheduled = "scheduled"
+	PremiumBookingActivated = "activated"
+	PremiumBookingCancelled = "cancelled"
+)
+
+const (
+	PaymentProductRenew   = "renew"
+	PaymentProductPremium = "premium"
+)
+
+const (
+	PaymentStatusPending   = "pending"
+	PaymentStatusPaid      = "paid"
+	PaymentStatusFulfilled = "fulfilled"
+	// PaymentStatusRefunding — товар уже откатан, возврат звёзд в Telegram ещё не подтверждён
+	PaymentStatusRefunding = "refunding"
+	PaymentStatusRefunded  = "refunded"
+)
+
+// JobRun — запись об одном запуске фоновой задачи планировщика
+type JobRun struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	Job        string     `gorm:"size:64;index" json:"job"`
+	Instance   string     `gorm:"size:128" json:"instance"`
+	Status     string     `gorm:"size:16;index" json:"status"`
+	Error      string     `gorm:"size:512" json:"error,omitempty"`
+	StartedAt  time.Time  `gorm:"index" json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs int64      `json:"duration_ms"`
+}
+
+const (
+	JobRunSucceeded = "succeeded"
+	JobRunFailed    = "failed"
+)
+
+// AdNotification — уведомление владельцу об объявлении. Запись привязана к сроку ExpiresAt,
+// поэтому после продления напоминания отправляются заново. LeadSeconds — за сколько
+// до окончания срока отправлено напоминание (0 — уведомление об истечении).
+type AdNotification struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	AdID        uint      `gorm:"uniqueIndex:idx_ad_notifications_stage" json:"ad_id"`
+	Kind        string    `gorm:"size:32;uniqueIndex:idx_ad_notifications_stage" json:"kind"`
+	LeadSeconds int64     `gorm:"uniqueIndex:idx_ad_notifications_stage" json:"lead_seconds"`
+	ExpiresAt   time.Time `gorm:"uniqueIndex:idx_ad_notifications_stage" json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+const (
+	AdNotificationExpiryReminder = "expiry_reminder"
+	AdNotificationExpired        = "expired"
+	AdNotificationAutoRenewed    = "auto_renewed"
+)
+
+// AdRevision — сохранённая версия содержимого объявления. Срок, статус и премиум
+// в версию не входят: откат меняет только текст, фото, контакт, цену и рубрику.
+type AdRevision struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	AdID         uint      `gorm:"uniqueIndex:idx_ad_revisions_version" json:"ad_id"`
+	Version      int       `gorm:"uniqueIndex:idx_ad_revisions_version" json:"version"`
+	AuthorID     int64     `json:"author_id"`
+	RestoredFrom *int      `json:"restored_from,omitempty"`
+	OwnerID      *int64    `json:"owner_id,omitempty"`
+	Username     string    `gorm:"size:64" json:"username"`
+	Title        string    `gorm:"size:128" json:"title"`
+	Desc         string    `gorm:"size:2048" json:"desc"`
+	PhotoID      string    `gorm:"size:256" json:"-"`
+	PhotoPath    string    `gorm:"size:512" json:"-"`
+	Category     string    `gorm:"size:32" json:"category"`
+	Mode         string    `gorm:"size:16" json:"mode"`
+	Tag          string    `gorm:"size:64" json:"tag"`
+	Price        int64     `json:"price"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TaxonomyCategory — категория объявлений. Key хранится в ads.category, поэтому не меняется;
+// неактивные категории не предлагаются при создании объявления и скрыты в Mini App.
+type TaxonomyCategory struct {
+	Key       string    `gorm:"primaryKey;size:32" json:"key"`
+	Label     string    `gorm:"size:64;not null" json:"label"`
+	Position  int       `gorm:"not null" json:"position"`
+	Active    bool      `gorm:"not null" json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TaxonomyOption — режим или тег внутри категории (ads.mode и ads.tag)
+type TaxonomyOption struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Category  string    `gorm:"size:32;not null;uniqueIndex:idx_taxonomy_options_key" json:"category"`
+	Kind      string    `gorm:"size:8;not null;uniqueIndex:idx_taxonomy_options_key" json:"kind"`
+	Key       string    `gorm:"size:32;not null;uniqueIndex:idx_taxonomy_options_key" json:"key"`
+	Label     string    `gorm:"size:64;not null" json:"label"`
+	Position  int       `gorm:"not null" json:"position"`
+	Active    bool      `gorm:"not null" json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+const (
+	TaxonomyKindMode = "mode"
+	TaxonomyKindTag  = "tag"
+)
+
+// SavedSearch — сохранённый поиск покупателя. Когда менеджер публикует подходящее
+// объявление, бот присылает подписчику ссылку на него. Пустые Mode и Tag — любые,
+// PriceMin/PriceMax = nil — без ограничения цены.
+type SavedSearch struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    int64     `gorm:"index" json:"user_id"` // Telegram ID подписчика
+	Category  string    `gorm:"size:32;index" json:"category"`
+	Mode      string    `gorm:"size:16" json:"mode"`
+	Tag       string    `gorm:"size:64" json:"tag"`
+	Query     string    `gorm:"size:128" json:"query"`
+	PriceMin  *int64    `json:"price_min,omitempty"`
+	PriceMax  *int64    `json:"price_max,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SavedSearchAlert — отправленное подписчику уведомление о новом объявлении. Об одном
+// объявлении пользователь получает не больше одного уведомления, даже если совпало
+// несколько поисков; по этим же записям считается лимит уведомлений в час.
+type SavedSearchAlert struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    int64     `gorm:"uniqueIndex:idx_saved_search_alerts_user_ad;index:idx_saved_search_alerts_user_time,priority:1" json:"user_id"`
+	AdID      uint      `gorm:"uniqueIndex:idx_saved_search_alerts_user_ad" json:"ad_id"`
+	SearchID  uint      `gorm:"index" json:"search_id"`
+	CreatedAt time.Time `gorm:"index:idx_saved_search_alerts_user_time,priority:2" json:"created_at"`
+}
+
+// Favorite — объявление в избранном у пользователя Mini App. Notify — присылать ли
+// уведомления о продлении, смене цены и скором окончании срока.
+type Favorite struct {
+	ID     uint  `gorm:"primaryKey" json:"id"`
+	UserID int64 `gorm:"uniqueIndex:idx_favorites_user_ad" json:"user_id"` // Telegram ID
+	AdID   uint  `gorm:"uniqueIndex:idx_favorites_user_ad;index" json:"ad_id"`
+	Notify bool  `gorm:"not null" json:"notify"`
+	// ExpiryNotifiedFor — срок объявления, о скором окончании которого уже предупредили
+	ExpiryNotifiedFor *time.Time `json:"-"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// AdStatDaily — счётчики объявления за день: показы в ленте, открытия и нажатия
+// «Связаться». Каждый зритель учитывается не больше одного раза в день для каждого события.
+type AdStatDaily struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	AdID        uint      `gorm:"uniqueIndex:idx_ad_stats_daily_ad_day" json:"ad_id"`
+	Day         time.Time `gorm:"type:date;uniqueIndex:idx_ad_stats_daily_ad_day;index" json:"day"`
+	Impressions int64     `gorm:"not null;default:0" json:"impressions"`
+	Views       int64     `gorm:"not null;default:0" json:"views"`
+	Contacts    int64     `gorm:"not null;default:0" json:"contacts"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (AdStatDaily) TableName() string {
+	return "ad_stats_daily"
+}
+
+// AdStatFlush — пачка счётчиков из Redis, уже перенесённая в ad_stats_daily. Запись
+// создаётся в одной транзакции с прибавлением счётчиков, поэтому пачка, сброс которой
+// прервался после коммита, при повторе не учитывается второй раз.
+type AdStatFlush struct {
+	BatchID   string    `gorm:"primaryKey;size:64" json:"batch_id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// Broadcast — рассылка менеджера владельцам объявлений. Текст хранится вместе с
+// разметкой Telegram (Entities — JSON), кнопки — JSON-массив {text, url}.
+type Broadcast struct {
+	ID                uint       `gorm:"primaryKey" json:"id"`
+	CreatedBy         int64      `gorm:"index" json:"created_by"`
+	Text              string     `gorm:"size:4096" json:"text"`
+	Entities          string     `gorm:"type:text" json:"-"`
+	PhotoID           string     `gorm:"size:256" json:"-"`
+	Buttons           string     `gorm:"type:text" json:"-"`
+	Segment           string     `gorm:"size:16" json:"segment"`
+	Category          string     `gorm:"size:32" json:"category,omitempty"`
+	Status            string     `gorm:"size:16;index" json:"status"`
+	Total             int        `json:"total"`
+	Sent              int        `json:"sent"`
+	Failed            int        `json:"failed"`
+	ProgressChatID    int64      `json:"-"`
+	ProgressMessageID int        `json:"-"`
+	FinishedAt        *time.Time `json:"finished_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+const (
+	BroadcastSegmentAll      = "all"
+	BroadcastSegmentActive   = "active"
+	BroadcastSegmentCategory = "category"
+)
+
+const (
+	BroadcastSending   = "sending"
+	BroadcastCompleted = "completed"
+	BroadcastCancelled = "cancelled"
+)
+
+// BroadcastDelivery — результат доставки рассылки одному получателю
+type BroadcastDelivery struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	BroadcastID uint      `gorm:"uniqueIndex:idx_broadcast_deliveries_chat" json:"broadcast_id"`
+	ChatID      int64     `gorm:"uniqueIndex:idx_broadcast_deliveries_chat" json:"chat_id"`
+	Status      string    `gorm:"size:16;index" json:"status"`
+	Error       string    `gorm:"size:256" json:"error,omitempty"`
+	Attempts    int       `json:"attempts"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+const (
+	BroadcastDeliverySent   = "sent"
+	BroadcastDeliveryFailed = "failed"
+)
+
+// OutboundMessage — запрос к Telegram Bot API в очереди исходящих сообщений.
+// Params — JSON с параметрами метода, как их отправляет tgbotapi.
+type OutboundMessage struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	ChatID        string     `gorm:"size:64;index" json:"chat_id"`
+	Method        string     `gorm:"size:32" json:"method"`
+	Params        string     `gorm:"type:text" json:"-"`
+	Discardable   bool       `gorm:"not null" json:"discardable"` // при ошибке Telegram не переносить в dead letter
+	Status        string     `gorm:"size:16;index:idx_outbound_messages_due,priority:1" json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"index:idx_outbound_messages_due,priority:2" json:"next_attempt_at"`
+	LastError     string     `gorm:"size:512" json:"last_error,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+const (
+	OutboundPending = "pending"
+	OutboundSent    = "sent"
+	OutboundDead    = "dead"
+	OutboundDropped = "dropped"
+)
+
+// AdRequest — заявка пользователя на размещение объявления, отправленная из бота
+type AdRequest struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	TelegramID int64      `gorm:"not null;index" json:"telegram_id"`
+	Username   string     `gorm:"size:64" json:"username,omitempty"`
+	Text       string     `gorm:"size:4096;not null" json:"text"`
+	Status     string     `gorm:"size:16;not null;index" json:"status"`
+	HandledBy  int64      `json:"handled_by,omitempty"`
+	HandledAt  *time.Time `json:"handled_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+const (
+	AdRequestNew      = "new"
+	AdRequestAccepted = "accepted"
+	AdRequestRejected = "rejected"
+)
@@ -0,0 +1,193 @@
+package outbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	defaultGlobalRate = 25
+	maxGlobalRate     = 30
+	// syncMaxAttempts — попыток для запроса, ответа на который ждёт вызывающий код
+	syncMaxAttempts = 3
+	// syncMaxRetryAfter — дольше этого синхронный запрос не ждёт: ошибка 429 возвращается
+	// вызывающему коду, а очередь переносит сообщение сама
+	syncMaxRetryAfter = 30 * time.Second
+)
+
+var (
+	limiterOnce   sync.Once
+	sharedLimiter *Limiter
+)
+
+// globalRate — запросов в секунду на бота (TELEGRAM_GLOBAL_RATE, не больше 30)
+func globalRate() int {
+	if raw := strings.TrimSpace(os.Getenv("TELEGRAM_GLOBAL_RATE")); raw != "" {
+		if rate, err := strconv.Atoi(raw); err == nil && rate > 0 && rate <= maxGlobalRate {
+			return rate
+		}
+		log.Printf("outbox: invalid TELEGRAM_GLOBAL_RATE=%q, using default %d", raw, defaultGlobalRate)
+	}
+	return defaultGlobalRate
+}
+
+// limiter — общий лимитер процесса: его делят синхронные запросы и очередь
+func limiter() *Limiter {
+	limiterOnce.Do(func() {
+		sharedLimiter = NewLimiter(globalRate())
+	})
+	return sharedLimiter
+}
+
+// isLimited — методы, которые меняют сообщения в чатах и учитываются в общем лимите.
+// Получение обновлений, ответы на callback и платежи не ограничиваются.
+func isLimited(method string) bool {
+	for _, prefix := range []string{"send", "edit", "delete", "copy", "forward", "pin", "unpin", "banChat", "restrictChat"} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMessageMethod — методы, которые создают новое сообщение: для них действует лимит чата
+func isMessageMethod(method string) bool {
+	return strings.HasPrefix(method, "send") || method == "copyMessage" || method == "forwardMessage"
+}
+
+// Client — HTTP-клиент для tgbotapi: пропускает запросы через лимитер, при ответе 429
+// ждёт retry_after и повторяет запрос, при ошибке сервера Telegram повторяет с паузой
+type Client struct {
+	base tgbotapi.HTTPClient
+}
+
+// NewClient оборачивает HTTP-клиент бота
+func NewClient(base tgbotapi.HTTPClient) *Client {
+	return &Client{base: base}
+}
+
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	method := path.Base(req.URL.Path)
+	if !isLimited(method) {
+		resp, err := c.base.Do(req)
+		requestsTotal.WithLabelValues(method, requestResult(resp, err)).Inc()
+		return resp, err
+	}
+	chatID := requestChatID(req)
+	replayable := req.GetBody != nil
+
+	for attempt := 1; ; attempt++ {
+		if wait := limiter().Reserve(chatID, isMessageMethod(method)); wait > 0 {
+			rateLimitWait.Observe(wait.Seconds())
+			if err := sleep(req, wait); err != nil {
+				return nil, err
+			}
+		}
+		if attempt > 1 {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := c.base.Do(req)
+		if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+			retryAfter := readRetryAfter(resp)
+			limiter().Pause(chatID, retryAfter)
+			if replayable && attempt < syncMaxAttempts && retryAfter <= syncMaxRetryAfter {
+				resp.Body.Close()
+				retriesTotal.WithLabelValues("rate_limited").Inc()
+				continue
+			}
+		}
+		if err == nil && resp.StatusCode >= http.StatusInternalServerError && replayable && attempt < syncMaxAttempts {
+			resp.Body.Close()
+			retriesTotal.WithLabelValues("server_error").Inc()
+			if err := sleep(req, time.Duration(attempt)*time.Second); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		requestsTotal.WithLabelValues(method, requestResult(resp, err)).Inc()
+		return resp, err
+	}
+}
+
+// requestResult — метка результата для telegram_requests_total
+func requestResult(resp *http.Response, err error) string {
+	switch {
+	case err != nil:
+		return "network_error"
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return "rate_limited"
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return "server_error"
+	case resp.StatusCode >= http.StatusBadRequest:
+		return "client_error"
+	default:
+		return "ok"
+	}
+}
+
+func sleep(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// requestChatID достаёт chat_id из тела запроса. Загрузка файлов (multipart) учитывается
+// только в общем лимите.
+func requestChatID(req *http.Request) string {
+	if req.GetBody == nil || !strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return ""
+	}
+	return values.Get("chat_id")
+}
+
+// readRetryAfter читает retry_after из ответа 429 и возвращает тело на место
+func readRetryAfter(resp *http.Response) time.Duration {
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return time.Second
+	}
+
+	var apiResp tgbotapi.APIResponse
+	if err := json.Unmarshal(data, &apiResp); err != nil || apiResp.Parameters == nil || apiResp.Parameters.RetryAfter <= 0 {
+		return time.Second
+	}
+	return time.Duration(apiResp.Parameters.RetryAfter) * time.Second
+}
@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeHTTP отвечает по очереди заданными ответами Bot API
+type fakeHTTP struct {
+	responses []string
+	calls     int
+}
+
+func (f *fakeHTTP) Do(req *http.Request) (*http.Response, error) {
+	body := f.responses[len(f.responses)-1]
+	if f.calls < len(f.responses) {
+		body = f.responses[f.calls]
+	}
+	f.calls++
+
+	status := http.StatusOK
+	switch {
+	case strings.Contains(body, `"error_code":429`):
+		status = http.StatusTooManyRequests
+	case strings.Contains(body, `"error_code":502`):
+		status = http.StatusBadGateway
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func newRequest(t *testing.T, method, chatID string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, "https://api.telegram.org/bottest/"+method, strings.NewReader("chat_id="+chatID+"&text=hi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+// freshLimiter подменяет общий лимитер новым: пауза после 429 в одном тесте
+// не должна задерживать следующие
+func freshLimiter(t *testing.T) {
+	t.Helper()
+	limiterOnce.Do(func() {})
+	sharedLimiter = NewLimiter(defaultGlobalRate)
+	t.Cleanup(func() { sharedLimiter = NewLimiter(defaultGlobalRate) })
+}
+
+const (
+	okBody          = `{"ok":true,"result":true}`
+	tooManyRequests = `{"ok":false,"error_code":429,"description":"Too Many Requests","parameters":{"retry_after":%d}}`
+)
+
+func TestClientWaitsRetryAfter(t *testing.T) {
+	freshLimiter(t)
+	base := &fakeHTTP{responses: []string{fmt.Sprintf(tooManyRequests, 1), okBody}}
+	client := NewClient(base)
+
+	started := time.Now()
+	resp, err := client.Do(newRequest(t, "sendMessage", "-1001"))
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || base.calls != 2 {
+		t.Fatalf("status=%d calls=%d, want 200 after 2 calls", resp.StatusCode, base.calls)
+	}
+	if waited := time.Since(started); waited < time.Second {
+		t.Fatalf("retried after %v, want at least retry_after=1s", waited)
+	}
+}
+
+func TestClientReturnsLongRetryAfter(t *testing.T) {
+	freshLimiter(t)
+	base := &fakeHTTP{responses: []string{fmt.Sprintf(tooManyRequests, 60)}}
+	client := NewClient(base)
+
+	resp, err := client.Do(newRequest(t, "sendMessage", "-1002"))
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+	// Дольше syncMaxRetryAfter синхронный запрос не ждёт: сообщение переносит очередь
+	if resp.StatusCode != http.StatusTooManyRequests || base.calls != 1 {
+		t.Fatalf("status=%d calls=%d, want 429 after 1 call", resp.StatusCode, base.calls)
+	}
+	if delay := limiter().ChatDelay("-1002"); delay < 50*time.Second {
+		t.Fatalf("chat delay = %v, want the chat paused for retry_after", delay)
+	}
+}
+
+func TestClientDoesNotRetryUnlimitedMethods(t *testing.T) {
+	freshLimiter(t)
+	base := &fakeHTTP{responses: []string{fmt.Sprintf(tooManyRequests, 1), okBody}}
+	client := NewClient(base)
+
+	resp, err := client.Do(newRequest(t, "answerCallbackQuery", "-1003"))
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || base.calls != 1 {
+		t.Fatalf("status=%d calls=%d, want 429 after 1 call", resp.StatusCode, base.calls)
+	}
+}
+
+func TestClientGivesUpAfterSyncAttempts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("waits for server error backoff")
+	}
+	freshLimiter(t)
+	base := &fakeHTTP{responses: []string{`{"ok":false,"error_code":502,"description":"Bad Gateway"}`}}
+	client := NewClient(base)
+
+	resp, err := client.Do(newRequest(t, "editMessageText", "-1004"))
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway || base.calls != syncMaxAttempts {
+		t.Fatalf("status=%d calls=%d, want 502 after %d calls", resp.StatusCode, base.calls, syncMaxAttempts)
+	}
+}
@@ -0,0 +1,148 @@
+package outbox
+
+import (
+	"sync"
+	"time"
+)
+
+// Лимиты Telegram: около 30 сообщений в секунду на бота, около одного сообщения
+// в секунду в личный чат и 20 в минуту в группу или канал. Короткие всплески допустимы.
+const (
+	privateChatRate = 1.0
+	groupChatRate   = 20.0 / 60
+	chatBurst       = 3
+	// chatIdleTTL — через сколько забывать корзину чата без запросов
+	chatIdleTTL = 10 * time.Minute
+)
+
+// bucket — корзина токенов с резервированием: токены уходят в минус, и следующий
+// запрос ждёт, пока корзина не наполнится обратно
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func (b *bucket) refill(now time.Time, rate, burst float64) {
+	if b.updated.IsZero() {
+		b.tokens = burst
+		b.updated = now
+		return
+	}
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * rate
+		if b.tokens > burst {
+			b.tokens = burst
+		}
+		b.updated = now
+	}
+}
+
+// reserve занимает токен и возвращает момент, когда запрос можно отправить
+func (b *bucket) reserve(now time.Time, rate, burst float64) time.Time {
+	b.refill(now, rate, burst)
+	b.tokens--
+	if b.tokens >= 0 {
+		return now
+	}
+	return now.Add(time.Duration(-b.tokens / rate * float64(time.Second)))
+}
+
+// pause откладывает следующий запрос не раньше until
+func (b *bucket) pause(now, until time.Time, rate, burst float64) {
+	b.refill(now, rate, burst)
+	if debt := 1 - until.Sub(now).Seconds()*rate; b.tokens > debt {
+		b.tokens = debt
+	}
+}
+
+// Limiter ограничивает запросы к Telegram: общий лимит на бота и лимит на каждый чат
+type Limiter struct {
+	mu         sync.Mutex
+	globalRate float64
+	global     bucket
+	chats      map[string]*bucket
+	lastPrune  time.Time
+}
+
+// NewLimiter — лимитер на globalRate запросов в секунду
+func NewLimiter(globalRate int) *Limiter {
+	return &Limiter{globalRate: float64(globalRate), chats: make(map[string]*bucket)}
+}
+
+// chatRate — лимит чата: у групп и каналов ID отрицательный или @username
+func chatRate(chatID string) float64 {
+	if chatID == "" || chatID[0] == '-' || chatID[0] == '@' {
+		return groupChatRate
+	}
+	return privateChatRate
+}
+
+// Reserve занимает место для запроса и возвращает, сколько нужно подождать.
+// perChat — учитывать лимит чата (только для новых сообщений).
+func (l *Limiter) Reserve(chatID string, perChat bool) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.prune(now)
+	at := l.global.reserve(now, l.globalRate, l.globalRate)
+	if perChat && chatID != "" {
+		if chatAt := l.chat(chatID).reserve(now, chatRate(chatID), chatBurst); chatAt.After(at) {
+			at = chatAt
+		}
+	}
+	return at.Sub(now)
+}
+
+// ChatDelay — сколько ждать, прежде чем чат примет новое сообщение; место не занимается
+func (l *Limiter) ChatDelay(chatID string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.chats[chatID]
+	if !ok || b.tokens >= 1 {
+		return 0
+	}
+	rate := chatRate(chatID)
+	ready := b.updated.Add(time.Duration((1 - b.tokens) / rate * float64(time.Second)))
+	if delay := time.Until(ready); delay > 0 {
+		return delay
+	}
+	return 0
+}
+
+// Pause останавливает запросы после ответа 429: в чат и, поскольку флуд-контроль
+// Telegram общий для бота, все остальные
+func (l *Limiter) Pause(chatID string, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	until := now.Add(retryAfter)
+	l.global.pause(now, until, l.globalRate, l.globalRate)
+	if chatID != "" {
+		l.chat(chatID).pause(now, until, chatRate(chatID), chatBurst)
+	}
+}
+
+func (l *Limiter) chat(chatID string) *bucket {
+	b, ok := l.chats[chatID]
+	if !ok {
+		b = &bucket{}
+		l.chats[chatID] = b
+	}
+	return b
+}
+
+// prune забывает чаты, корзины которых давно полны
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < chatIdleTTL {
+		return
+	}
+	l.lastPrune = now
+	for chatID, b := range l.chats {
+		if now.Sub(b.updated) > chatIdleTTL {
+			delete(l.chats, chatID)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package outbox
+
+import (
+	"context"
+	"log"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "telegram_requests_total",
+		Help: "Запросы к Telegram Bot API по методу и результату (ok, rate_limited, client_error, server_error, network_error)",
+	}, []string{"method", "result"})
+	retriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "telegram_request_retries_total",
+		Help: "Повторы запросов к Telegram по причине (rate_limited, server_error)",
+	}, []string{"reason"})
+	rateLimitWait = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "telegram_rate_limit_wait_seconds",
+		Help:    "Ожидание запроса в лимитере перед отправкой в Telegram",
+		Buckets: prometheus.ExponentialBuckets(0.01, 3, 9),
+	})
+	messagesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbox_messages_total",
+		Help: "Сообщения очереди по исходу (enqueued, sent, retried, dead, dropped)",
+	}, []string{"result"})
+)
+
+var queueSizeDesc = prometheus.NewDesc("outbox_messages",
+	"Сообщения в очереди: ожидающие отправки (pending) и в dead letter (dead)", []string{"status"}, nil)
+
+// queueCollector считает размер очереди из БД при каждом запросе /metrics
+type queueCollector struct{}
+
+func init() {
+	prometheus.MustRegister(queueCollector{})
+}
+
+func (queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueSizeDesc
+}
+
+func (queueCollector) Collect(ch chan<- prometheus.Metric) {
+	if db.DB == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	if err := db.DB.WithContext(ctx).Model(&models.OutboundMessage{}).
+		Select("status, COUNT(*) AS count").
+		Where("status IN ?", []string{models.OutboundPending, models.OutboundDead}).
+		Group("status").Scan(&rows).Error; err != nil {
+		log.Printf("outbox: failed to count queue: %v", err)
+		return
+	}
+	counts := map[string]int64{models.OutboundPending: 0, models.OutboundDead: 0}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(queueSizeDesc, prometheus.GaugeValue, float64(count), status)
+	}
+}
@@ -0,0 +1,358 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	pollInterval = time.Second
+	claimBatch   = 50
+	// claimLease — на сколько откладывается взятое в работу сообщение: если экземпляр
+	// упадёт, не отправив его, сообщение заберёт другой
+	claimLease         = 2 * time.Minute
+	defaultMaxAttempts = 8
+	retryBaseDelay     = 5 * time.Second
+	maxRetryDelay      = 30 * time.Minute
+	scrapeTimeout      = 5 * time.Second
+)
+
+// errUploadNotQueued — загрузку файлов нельзя сохранить в очередь, её отправляют сразу
+var errUploadNotQueued = errors.New("outbox: file uploads cannot be queued")
+
+// wake будит обработчик очереди после постановки сообщения в этом процессе
+var wake = make(chan struct{}, 1)
+
+// maxAttempts — попыток до переноса в dead letter (OUTBOX_MAX_ATTEMPTS)
+func maxAttempts() int {
+	if raw := strings.TrimSpace(os.Getenv("OUTBOX_MAX_ATTEMPTS")); raw != "" {
+		if attempts, err := strconv.Atoi(raw); err == nil && attempts > 0 {
+			return attempts
+		}
+		log.Printf("outbox: invalid OUTBOX_MAX_ATTEMPTS=%q, using default %d", raw, defaultMaxAttempts)
+	}
+	return defaultMaxAttempts
+}
+
+// Enqueue ставит запрос в очередь. Сообщение будет отправлено с учётом лимитов
+// Telegram, а после OUTBOX_MAX_ATTEMPTS неудач окажется в dead letter.
+func Enqueue(c tgbotapi.Chattable) error {
+	return enqueue(c, false)
+}
+
+// EnqueueDiscardable ставит в очередь запрос, который не жалко потерять (например,
+// удаление служебного сообщения): ошибку Telegram он не переносит в dead letter
+func EnqueueDiscardable(c tgbotapi.Chattable) error {
+	return enqueue(c, true)
+}
+
+func enqueue(c tgbotapi.Chattable, discardable bool) error {
+	if db.DB == nil {
+		return errors.New("outbox: database is not initialized")
+	}
+	method, params, err := capture(c)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	message := models.OutboundMessage{
+		ChatID:        params["chat_id"],
+		Method:        method,
+		Params:        string(payload),
+		Discardable:   discardable,
+		Status:        models.OutboundPending,
+		NextAttemptAt: time.Now(),
+	}
+	if err := db.DB.Create(&message).Error; err != nil {
+		return err
+	}
+	messagesTotal.WithLabelValues("enqueued").Inc()
+
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// captureClient перехватывает запрос tgbotapi вместо отправки: так любой Chattable
+// превращается в метод и параметры, которые потом можно передать в MakeRequest
+type captureClient struct {
+	method string
+	params tgbotapi.Params
+}
+
+func (c *captureClient) Do(req *http.Request) (*http.Response, error) {
+	var data []byte
+	if req.Body != nil {
+		var err error
+		// Тело читается целиком, чтобы завершилась горутина, которая пишет multipart
+		data, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return nil, errUploadNotQueued
+	}
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	c.method = path.Base(req.URL.Path)
+	c.params = make(tgbotapi.Params, len(values))
+	for key := range values {
+		c.params[key] = values.Get(key)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"ok":true,"result":true}`)),
+	}, nil
+}
+
+func capture(c tgbotapi.Chattable) (string, tgbotapi.Params, error) {
+	client := &captureClient{}
+	bot := &tgbotapi.BotAPI{Token: "outbox", Client: client}
+	bot.SetAPIEndpoint(tgbotapi.APIEndpoint)
+	if _, err := bot.Request(c); err != nil {
+		return "", nil, err
+	}
+	return client.method, client.params, nil
+}
+
+// Run отправляет сообщения из очереди, пока не отменён ctx. Очередь можно обрабатывать
+// с нескольких экземпляров: сообщения разбираются через FOR UPDATE SKIP LOCKED.
+func Run(ctx context.Context, bot *tgbotapi.BotAPI) {
+	attempts := maxAttempts()
+	for {
+		processed, err := processBatch(ctx, bot, attempts)
+		if err != nil {
+			log.Printf("outbox: failed to process queue: %v", err)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if processed == claimBatch {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-wake:
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// claim забирает готовые к отправке сообщения, откладывая их на claimLease
+func claim(ctx context.Context, now time.Time) ([]models.OutboundMessage, error) {
+	var messages []models.OutboundMessage
+	err := db.DB.WithContext(ctx).Raw(`
+		UPDATE outbound_messages SET next_attempt_at = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM outbound_messages
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at, id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(claimLease), now, models.OutboundPending, now, claimBatch).Scan(&messages).Error
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	return messages, err
+}
+
+func processBatch(ctx context.Context, bot *tgbotapi.BotAPI, attempts int) (int, error) {
+	messages, err := claim(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	for _, message := range messages {
+		if ctx.Err() != nil {
+			// Недоставленные сообщения вернутся в работу по истечении аренды
+			return len(messages), nil
+		}
+		// Чат, куда уже много отправлено, не должен задерживать остальные сообщения
+		if isMessageMethod(message.Method) {
+			if delay := limiter().ChatDelay(message.ChatID); delay > pollInterval {
+				reschedule(message, time.Now().Add(delay), message.Attempts, "")
+				continue
+			}
+		}
+		deliver(ctx, bot, message, attempts)
+	}
+	return len(messages), nil
+}
+
+func deliver(ctx context.Context, bot *tgbotapi.BotAPI, message models.OutboundMessage, attempts int) {
+	var params tgbotapi.Params
+	if err := json.Unmarshal([]byte(message.Params), &params); err != nil {
+		fail(message, fmt.Errorf("invalid params: %w", err))
+		return
+	}
+
+	_, err := bot.MakeRequest(message.Method, params)
+	if err != nil && ctx.Err() != nil {
+		// Сообщение вернётся в работу по истечении аренды
+		return
+	}
+	now := time.Now()
+	next := nextState(message, err, now, attempts)
+	switch next.status {
+	case models.OutboundSent:
+		update(message.ID, map[string]interface{}{
+			"status":     models.OutboundSent,
+			"attempts":   next.attempts,
+			"sent_at":    now,
+			"last_error": "",
+		})
+		messagesTotal.WithLabelValues("sent").Inc()
+	case models.OutboundPending:
+		reschedule(message, next.nextAttemptAt, next.attempts, err.Error())
+		messagesTotal.WithLabelValues("retried").Inc()
+	default:
+		fail(message, err)
+	}
+}
+
+// transition — состояние сообщения после попытки отправки
+type transition struct {
+	status        string
+	attempts      int
+	nextAttemptAt time.Time // для status=pending
+}
+
+// nextState решает, что стало с сообщением после попытки отправки, закончившейся ошибкой err:
+// флуд-контроль откладывает его на retry_after без траты попытки, ошибки клиента сразу
+// переносят в dead letter (служебные запросы — в dropped), остальные ошибки повторяются
+// с экспоненциальной паузой, пока не кончатся attempts попыток
+func nextState(message models.OutboundMessage, err error, now time.Time, attempts int) transition {
+	attempt := message.Attempts + 1
+	if err == nil || notModified(err) {
+		return transition{status: models.OutboundSent, attempts: attempt}
+	}
+
+	failed := transition{status: models.OutboundDead, attempts: attempt}
+	if message.Discardable {
+		failed.status = models.OutboundDropped
+	}
+
+	var apiErr *tgbotapi.Error
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.RetryAfter > 0:
+			// Флуд-контроль — не вина сообщения, попытка не засчитывается
+			return transition{
+				status:        models.OutboundPending,
+				attempts:      message.Attempts,
+				nextAttemptAt: now.Add(time.Duration(apiErr.RetryAfter) * time.Second),
+			}
+		case apiErr.Code < http.StatusInternalServerError:
+			// Бот заблокирован, чат не найден, неверные параметры — повтор не поможет
+			return failed
+		}
+	}
+
+	if attempt >= attempts {
+		return failed
+	}
+	delay := retryBaseDelay << (attempt - 1)
+	if delay > maxRetryDelay || delay <= 0 {
+		delay = maxRetryDelay
+	}
+	return transition{status: models.OutboundPending, attempts: attempt, nextAttemptAt: now.Add(delay)}
+}
+
+// Retryable — ошибка, которую может исправить повтор: сбой сети, флуд-контроль
+// или ошибка сервера Telegram. Остальные ответы Telegram (бот заблокирован, чат
+// не найден, неверные параметры) повтор не исправит.
+func Retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *tgbotapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter > 0 || apiErr.Code >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// notModified — правка не изменила сообщение: результат тот же, что при успехе
+func notModified(err error) bool {
+	return strings.Contains(err.Error(), "message is not modified")
+}
+
+func reschedule(message models.OutboundMessage, at time.Time, attempts int, lastError string) {
+	updates := map[string]interface{}{"next_attempt_at": at, "attempts": attempts}
+	if lastError != "" {
+		updates["last_error"] = truncate(lastError, 512)
+	}
+	update(message.ID, updates)
+}
+
+// fail переносит сообщение в dead letter; служебные запросы просто отбрасываются
+func fail(message models.OutboundMessage, err error) {
+	status := models.OutboundDead
+	if message.Discardable {
+		status = models.OutboundDropped
+	} else {
+		log.Printf("outbox: %s to chat %s moved to dead letter after %d attempts: %v",
+			message.Method, message.ChatID, message.Attempts+1, err)
+	}
+	update(message.ID, map[string]interface{}{
+		"status":     status,
+		"attempts":   message.Attempts + 1,
+		"last_error": truncate(err.Error(), 512),
+	})
+	messagesTotal.WithLabelValues(status).Inc()
+}
+
+func update(id uint, updates map[string]interface{}) {
+	if err := db.DB.Model(&models.OutboundMessage{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		log.Printf("outbox: failed to update message %d: %v", id, err)
+	}
+}
+
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit])
+}
+
+// Prune удаляет отправленные и отброшенные сообщения старше keepSent и dead letter старше keepDead
+func Prune(ctx context.Context, keepSent, keepDead time.Duration) error {
+	now := time.Now()
+	tx := db.DB.WithContext(ctx)
+	if err := tx.Where("status IN ? AND updated_at < ?", []string{models.OutboundSent, models.OutboundDropped}, now.Add(-keepSent)).
+		Delete(&models.OutboundMessage{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("status = ? AND updated_at < ?", models.OutboundDead, now.Add(-keepDead)).
+		Delete(&models.OutboundMessage{}).Error
+}
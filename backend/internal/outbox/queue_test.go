@@ -0,0 +1,284 @@
+package outbox
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/db/dbtest"
+	"youtube-market/internal/models"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestNextState(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	networkErr := errors.New("dial tcp: connection refused")
+
+	tests := []struct {
+		name        string
+		message     models.OutboundMessage
+		err         error
+		attempts    int
+		wantStatus  string
+		wantAttempt int
+		wantAt      time.Time
+	}{
+		{
+			name:        "sent",
+			message:     models.OutboundMessage{Attempts: 2},
+			wantStatus:  models.OutboundSent,
+			wantAttempt: 3,
+		},
+		{
+			name:        "edit without changes counts as sent",
+			err:         &tgbotapi.Error{Code: 400, Message: "Bad Request: message is not modified"},
+			wantStatus:  models.OutboundSent,
+			wantAttempt: 1,
+		},
+		{
+			name:        "retry_after does not spend an attempt",
+			message:     models.OutboundMessage{Attempts: 3},
+			err:         &tgbotapi.Error{Code: 429, Message: "Too Many Requests", ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 7}},
+			wantStatus:  models.OutboundPending,
+			wantAttempt: 3,
+			wantAt:      now.Add(7 * time.Second),
+		},
+		{
+			name:        "client error goes to dead letter",
+			err:         &tgbotapi.Error{Code: 403, Message: "Forbidden: bot was blocked by the user"},
+			wantStatus:  models.OutboundDead,
+			wantAttempt: 1,
+		},
+		{
+			name:        "discardable request is dropped",
+			message:     models.OutboundMessage{Discardable: true},
+			err:         &tgbotapi.Error{Code: 400, Message: "Bad Request: message to delete not found"},
+			wantStatus:  models.OutboundDropped,
+			wantAttempt: 1,
+		},
+		{
+			name:        "server error is retried",
+			err:         &tgbotapi.Error{Code: 502, Message: "Bad Gateway"},
+			wantStatus:  models.OutboundPending,
+			wantAttempt: 1,
+			wantAt:      now.Add(retryBaseDelay),
+		},
+		{
+			name:        "network error backs off exponentially",
+			message:     models.OutboundMessage{Attempts: 2},
+			err:         networkErr,
+			wantStatus:  models.OutboundPending,
+			wantAttempt: 3,
+			wantAt:      now.Add(4 * retryBaseDelay),
+		},
+		{
+			name:        "backoff is capped",
+			message:     models.OutboundMessage{Attempts: 20},
+			err:         networkErr,
+			attempts:    100,
+			wantStatus:  models.OutboundPending,
+			wantAttempt: 21,
+			wantAt:      now.Add(maxRetryDelay),
+		},
+		{
+			name:        "last attempt goes to dead letter",
+			message:     models.OutboundMessage{Attempts: defaultMaxAttempts - 1},
+			err:         networkErr,
+			wantStatus:  models.OutboundDead,
+			wantAttempt: defaultMaxAttempts,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := tt.attempts
+			if attempts == 0 {
+				attempts = defaultMaxAttempts
+			}
+			got := nextState(tt.message, tt.err, now, attempts)
+			if got.status != tt.wantStatus || got.attempts != tt.wantAttempt || !got.nextAttemptAt.Equal(tt.wantAt) {
+				t.Fatalf("nextState = %+v, want status=%s attempts=%d next=%v", got, tt.wantStatus, tt.wantAttempt, tt.wantAt)
+			}
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("connection reset"), true},
+		{&tgbotapi.Error{Code: 429, ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 3}}, true},
+		{&tgbotapi.Error{Code: 500}, true},
+		{&tgbotapi.Error{Code: 403}, false},
+	}
+	for _, tt := range tests {
+		if got := Retryable(tt.err); got != tt.want {
+			t.Fatalf("Retryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func createOutbound(t *testing.T, message models.OutboundMessage) models.OutboundMessage {
+	t.Helper()
+	if message.Method == "" {
+		message.Method = "sendMessage"
+	}
+	if message.Params == "" {
+		message.Params = `{"chat_id":"1","text":"hi"}`
+	}
+	if message.Status == "" {
+		message.Status = models.OutboundPending
+	}
+	if err := db.DB.Create(&message).Error; err != nil {
+		t.Fatalf("create message: %v", err)
+	}
+	return message
+}
+
+func loadOutbound(t *testing.T, id uint) models.OutboundMessage {
+	t.Helper()
+	var message models.OutboundMessage
+	if err := db.DB.First(&message, id).Error; err != nil {
+		t.Fatalf("load message %d: %v", id, err)
+	}
+	return message
+}
+
+func TestClaimLeasesMessages(t *testing.T) {
+	dbtest.Open(t, "outbound_messages")
+
+	now := time.Now().Truncate(time.Second)
+	due := createOutbound(t, models.OutboundMessage{ChatID: "1", NextAttemptAt: now.Add(-time.Minute)})
+	createOutbound(t, models.OutboundMessage{ChatID: "2", NextAttemptAt: now.Add(time.Hour)})
+	createOutbound(t, models.OutboundMessage{ChatID: "3", NextAttemptAt: now.Add(-time.Minute), Status: models.OutboundSent})
+
+	claimed, err := claim(t.Context(), now)
+	if err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != due.ID {
+		t.Fatalf("claimed %+v, want only message %d", claimed, due.ID)
+	}
+	if leased := loadOutbound(t, due.ID); !leased.NextAttemptAt.Equal(now.Add(claimLease)) {
+		t.Fatalf("next_attempt_at = %v, want lease until %v", leased.NextAttemptAt, now.Add(claimLease))
+	}
+
+	// Пока аренда не истекла, сообщение не достаётся другому обработчику
+	if again, err := claim(t.Context(), now.Add(time.Second)); err != nil || len(again) != 0 {
+		t.Fatalf("second claim = %+v, %v; want nothing", again, err)
+	}
+	// Экземпляр, взявший сообщение, упал — после аренды его забирает другой
+	if expired, err := claim(t.Context(), now.Add(claimLease+time.Second)); err != nil || len(expired) != 1 {
+		t.Fatalf("claim after lease = %+v, %v; want message %d", expired, err, due.ID)
+	}
+}
+
+// fakeBotAPI отвечает на все методы Bot API телом body
+type fakeBotAPI struct {
+	mu    sync.Mutex
+	body  string
+	calls int
+}
+
+func (f *fakeBotAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(f.body))
+}
+
+func startFakeBotAPI(t *testing.T, body string) (*tgbotapi.BotAPI, *fakeBotAPI) {
+	t.Helper()
+	fake := &fakeBotAPI{body: body}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	bot := &tgbotapi.BotAPI{Token: "test", Client: server.Client()}
+	bot.SetAPIEndpoint(server.URL + "/bot%s/%s")
+	return bot, fake
+}
+
+func TestDeliverTransitions(t *testing.T) {
+	dbtest.Open(t, "outbound_messages")
+
+	tests := []struct {
+		name         string
+		body         string
+		discardable  bool
+		wantStatus   string
+		wantAttempts int
+		wantDelay    time.Duration
+	}{
+		{
+			name:         "sent",
+			body:         `{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":1}}}`,
+			wantStatus:   models.OutboundSent,
+			wantAttempts: 1,
+		},
+		{
+			name:         "rate limited",
+			body:         `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 30","parameters":{"retry_after":30}}`,
+			wantStatus:   models.OutboundPending,
+			wantAttempts: 0,
+			wantDelay:    30 * time.Second,
+		},
+		{
+			name:         "server error",
+			body:         `{"ok":false,"error_code":502,"description":"Bad Gateway"}`,
+			wantStatus:   models.OutboundPending,
+			wantAttempts: 1,
+			wantDelay:    retryBaseDelay,
+		},
+		{
+			name:         "blocked",
+			body:         `{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`,
+			wantStatus:   models.OutboundDead,
+			wantAttempts: 1,
+		},
+		{
+			name:         "discardable",
+			body:         `{"ok":false,"error_code":400,"description":"Bad Request: message to delete not found"}`,
+			discardable:  true,
+			wantStatus:   models.OutboundDropped,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot, fake := startFakeBotAPI(t, tt.body)
+			message := createOutbound(t, models.OutboundMessage{ChatID: "1", NextAttemptAt: time.Now(), Discardable: tt.discardable})
+
+			before := time.Now()
+			deliver(t.Context(), bot, message, defaultMaxAttempts)
+
+			got := loadOutbound(t, message.ID)
+			if fake.calls != 1 {
+				t.Fatalf("Bot API called %d times, want 1", fake.calls)
+			}
+			if got.Status != tt.wantStatus || got.Attempts != tt.wantAttempts {
+				t.Fatalf("status=%s attempts=%d, want %s/%d", got.Status, got.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if tt.wantStatus == models.OutboundSent && got.SentAt == nil {
+				t.Fatal("sent_at is not set")
+			}
+			if tt.wantDelay > 0 {
+				if got.NextAttemptAt.Before(before.Add(tt.wantDelay)) || got.NextAttemptAt.After(time.Now().Add(tt.wantDelay)) {
+					t.Fatalf("next_attempt_at = %v, want about %v from now", got.NextAttemptAt, tt.wantDelay)
+				}
+				if got.LastError == "" {
+					t.Fatal("last_error is empty")
+				}
+			}
+		})
+	}
+}
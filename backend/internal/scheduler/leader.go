@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+)
+
+// Leader решает, какой из экземпляров приложения выполняет общие задачи
+type Leader interface {
+	// Refresh пытается получить или подтвердить лидерство и возвращает текущий статус
+	Refresh(ctx context.Context) bool
+	// Release отдаёт лидерство
+	Release()
+}
+
+// PostgresLeader держит сессионную advisory-блокировку Postgres на отдельном соединении.
+// Если соединение рвётся, блокировка снимается сервером и лидером становится другой экземпляр.
+type PostgresLeader struct {
+	db   *sql.DB
+	key  int64
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+// NewPostgresLeader создаёт выбор лидера по advisory-блокировке с ключом key
+func NewPostgresLeader(db *sql.DB, key int64) *PostgresLeader {
+	return &PostgresLeader{db: db, key: key}
+}
+
+func (l *PostgresLeader) Refresh(ctx context.Context) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		err := l.conn.PingContext(ctx)
+		if err == nil {
+			return true
+		}
+		log.Printf("scheduler: lost leader connection: %v", err)
+		l.conn.Close()
+		l.conn = nil
+		return false
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		log.Printf("scheduler: failed to get connection for leader lock: %v", err)
+		return false
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&acquired); err != nil {
+		log.Printf("scheduler: failed to acquire leader lock: %v", err)
+		conn.Close()
+		return false
+	}
+	if !acquired {
+		conn.Close()
+		return false
+	}
+
+	l.conn = conn
+	return true
+}
+
+func (l *PostgresLeader) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return
+	}
+	if _, err := l.conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		log.Printf("scheduler: failed to release leader lock: %v", err)
+	}
+	l.conn.Close()
+	l.conn = nil
+}
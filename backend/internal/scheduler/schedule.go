@@ -0,0 +1,180 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule определяет момент следующего запуска задачи
+type Schedule interface {
+	Next(now time.Time) time.Time
+}
+
+// ScheduleFunc позволяет вычислять следующий запуск динамически (например, по данным из БД)
+type ScheduleFunc func(now time.Time) time.Time
+
+func (f ScheduleFunc) Next(now time.Time) time.Time {
+	return f(now)
+}
+
+// Every — запуск с фиксированным интервалом
+type Every time.Duration
+
+func (e Every) Next(now time.Time) time.Time {
+	return now.Add(time.Duration(e))
+}
+
+// cronSchedule — расписание в формате cron из пяти полей: минута, час, день месяца, месяц, день недели
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+type cronField struct {
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{0, 59}, // минута
+	{0, 23}, // час
+	{1, 31}, // день месяца
+	{1, 12}, // месяц
+	{0, 6},  // день недели (0 — воскресенье)
+}
+
+// Parse разбирает спецификацию расписания: "@every 30m", "@hourly", "@daily"
+// или cron из пяти полей ("0 4 * * *") с поддержкой списков, диапазонов и шагов
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	switch {
+	case strings.HasPrefix(spec, "@every "):
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid interval in %q", spec)
+		}
+		return Every(interval), nil
+	case spec == "@hourly":
+		spec = "0 * * * *"
+	case spec == "@daily" || spec == "@midnight":
+		spec = "0 0 * * *"
+	case spec == "@weekly":
+		spec = "0 0 * * 0"
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("cron spec %q must have %d fields", spec, len(cronFields))
+	}
+
+	var masks [5]uint64
+	for i, part := range parts {
+		mask, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron spec %q: %w", spec, err)
+		}
+		masks[i] = mask
+	}
+
+	return &cronSchedule{
+		minute: masks[0],
+		hour:   masks[1],
+		dom:    masks[2],
+		month:  masks[3],
+		dow:    masks[4],
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}, nil
+}
+
+// MustParse как Parse, но паникует на некорректной спецификации (для расписаний в коде)
+func MustParse(spec string) Schedule {
+	schedule, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return schedule
+}
+
+func parseCronField(field string, bounds cronField) (uint64, error) {
+	var mask uint64
+	for _, item := range strings.Split(field, ",") {
+		step := 1
+		if rangePart, stepPart, ok := strings.Cut(item, "/"); ok {
+			value, err := strconv.Atoi(stepPart)
+			if err != nil || value <= 0 {
+				return 0, fmt.Errorf("invalid step %q", item)
+			}
+			item, step = rangePart, value
+		}
+
+		low, high := bounds.min, bounds.max
+		if item != "*" {
+			lowPart, highPart, isRange := strings.Cut(item, "-")
+			value, err := strconv.Atoi(lowPart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", item)
+			}
+			low, high = value, value
+			if isRange {
+				if high, err = strconv.Atoi(highPart); err != nil {
+					return 0, fmt.Errorf("invalid range %q", item)
+				}
+			} else if step > 1 {
+				high = bounds.max
+			}
+		}
+		if low < bounds.min || high > bounds.max || low > high {
+			return 0, fmt.Errorf("value %q out of range %d-%d", item, bounds.min, bounds.max)
+		}
+
+		for value := low; value <= high; value += step {
+			mask |= 1 << uint(value)
+		}
+	}
+	return mask, nil
+}
+
+// Next перебирает минуты вперёд, пропуская целые месяцы, дни и часы, которые не подходят
+func (s *cronSchedule) Next(now time.Time) time.Time {
+	t := now.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return limit
+}
+
+// dayMatches следует правилу cron: если заданы и день месяца, и день недели, достаточно любого
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
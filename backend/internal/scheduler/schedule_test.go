@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseNext(t *testing.T) {
+	// 2026-03-04 — среда
+	now := time.Date(2026, 3, 4, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"@every 30m", now.Add(30 * time.Minute)},
+		{"@hourly", time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"@midnight", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 4 * * *", time.Date(2026, 3, 5, 4, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)},
+		{"5,20,40 * * * *", time.Date(2026, 3, 4, 10, 20, 0, 0, time.UTC)},
+		{"0 9-11 * * *", time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1-5", time.Date(2026, 3, 5, 8, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Заданы и день месяца, и день недели — подходит любой из них
+		{"0 0 10 * 5", time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{"  0 4 * * *  ", time.Date(2026, 3, 5, 4, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.spec, err)
+			}
+			if got := schedule.Next(now); !got.Equal(tt.want) {
+				t.Fatalf("Next = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"@every",
+		"@every -5m",
+		"@every soon",
+		"@yearly",
+		"0 4 * *",
+		"0 4 * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 7",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestMustParsePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustParse did not panic on an invalid spec")
+		}
+	}()
+	MustParse("not a schedule")
+}
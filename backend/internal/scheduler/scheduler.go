@@ -0,0 +1,229 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"youtube-market/internal/db"
+	"youtube-market/internal/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// leaderRefreshInterval — как часто экземпляр подтверждает или пытается получить лидерство
+	leaderRefreshInterval = 15 * time.Second
+	// jobRunTimeout ограничивает один запуск задачи
+	jobRunTimeout = 10 * time.Minute
+)
+
+var (
+	jobRunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduler_job_runs_total",
+		Help: "Количество запусков фоновых задач по результату",
+	}, []string{"job", "status"})
+	jobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "scheduler_job_duration_seconds",
+		Help:    "Длительность запусков фоновых задач",
+		Buckets: prometheus.ExponentialBuckets(0.01, 4, 8),
+	}, []string{"job"})
+	jobLastSuccess = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "scheduler_job_last_success_timestamp_seconds",
+		Help: "Время последнего успешного запуска задачи (unix)",
+	}, []string{"job"})
+	jobNextRun = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "scheduler_job_next_run_timestamp_seconds",
+		Help: "Запланированное время следующего запуска задачи (unix)",
+	}, []string{"job"})
+	leaderGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "scheduler_leader",
+		Help: "1, если экземпляр выполняет общие задачи",
+	})
+)
+
+// Job — именованная фоновая задача
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+	// EveryInstance — задача обслуживает память процесса и выполняется на каждом экземпляре,
+	// а не только на лидере; такие запуски не пишутся в историю
+	EveryInstance bool
+}
+
+// Scheduler запускает задачи по расписанию. Общие задачи выполняет только экземпляр,
+// удерживающий лидерство, поэтому несколько реплик не дублируют напоминания и рассылки.
+type Scheduler struct {
+	leader   Leader
+	instance string
+	jobs     []Job
+	isLeader atomic.Bool
+}
+
+// New создаёт планировщик; leader == nil — экземпляр всегда считается лидером
+func New(leader Leader) *Scheduler {
+	instance, err := os.Hostname()
+	if err != nil || instance == "" {
+		instance = "unknown"
+	}
+	instance = fmt.Sprintf("%s/%d", instance, os.Getpid())
+	return &Scheduler{leader: leader, instance: instance}
+}
+
+// Add регистрирует задачу; вызывать до Run
+func (s *Scheduler) Add(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Run выполняет задачи до отмены ctx и дожидается завершения начатых запусков
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	s.refreshLeader(ctx)
+	if s.leader != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.keepLeadership(ctx)
+		}()
+	}
+
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			s.runJob(ctx, job)
+		}(job)
+	}
+
+	wg.Wait()
+}
+
+func (s *Scheduler) keepLeadership(ctx context.Context) {
+	ticker := time.NewTicker(leaderRefreshInterval)
+	defer ticker.Stop()
+	defer func() {
+		s.leader.Release()
+		s.isLeader.Store(false)
+		leaderGauge.Set(0)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refreshLeader(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) refreshLeader(ctx context.Context) {
+	leader := true
+	if s.leader != nil {
+		leader = s.leader.Refresh(ctx)
+	}
+	if leader != s.isLeader.Swap(leader) {
+		if leader {
+			log.Printf("scheduler: %s became leader", s.instance)
+		} else {
+			log.Printf("scheduler: %s is no longer leader", s.instance)
+		}
+	}
+	if leader {
+		leaderGauge.Set(1)
+	} else {
+		leaderGauge.Set(0)
+	}
+}
+
+// runJob ждёт момента запуска по расписанию. Пока экземпляр не лидер, расписание
+// перепроверяется не реже раза в leaderRefreshInterval, но задача не выполняется.
+func (s *Scheduler) runJob(ctx context.Context, job Job) {
+	for {
+		now := time.Now()
+		leader := job.EveryInstance || s.isLeader.Load()
+
+		next := now.Add(leaderRefreshInterval)
+		if leader {
+			next = job.Schedule.Next(now)
+			jobNextRun.WithLabelValues(job.Name).Set(float64(next.Unix()))
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if job.EveryInstance || s.isLeader.Load() {
+			s.execute(ctx, job)
+		}
+	}
+}
+
+// execute выполняет один запуск, обновляет метрики и пишет историю для общих задач
+func (s *Scheduler) execute(ctx context.Context, job Job) {
+	// Начатый запуск доводим до конца даже при остановке приложения
+	runCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jobRunTimeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	err := s.safeRun(runCtx, job)
+	finishedAt := time.Now()
+	duration := finishedAt.Sub(startedAt)
+
+	status := models.JobRunSucceeded
+	if err != nil {
+		status = models.JobRunFailed
+		log.Printf("scheduler: job %s failed after %s: %v", job.Name, duration, err)
+	} else {
+		jobLastSuccess.WithLabelValues(job.Name).Set(float64(finishedAt.Unix()))
+	}
+	jobRunsTotal.WithLabelValues(job.Name, status).Inc()
+	jobDuration.WithLabelValues(job.Name).Observe(duration.Seconds())
+
+	if job.EveryInstance {
+		return
+	}
+
+	run := models.JobRun{
+		Job:        job.Name,
+		Instance:   s.instance,
+		Status:     status,
+		StartedAt:  startedAt,
+		FinishedAt: &finishedAt,
+		DurationMs: duration.Milliseconds(),
+	}
+	if err != nil {
+		run.Error = err.Error()
+		if runes := []rune(run.Error); len(runes) > 512 {
+			run.Error = string(runes[:512])
+		}
+	}
+	if err := db.DB.Create(&run).Error; err != nil {
+		log.Printf("scheduler: failed to save run of %s: %v", job.Name, err)
+	}
+}
+
+func (s *Scheduler) safeRun(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+// PruneRuns удаляет историю запусков старше keep
+func PruneRuns(ctx context.Context, keep time.Duration) error {
+	return db.DB.WithContext(ctx).Where("started_at < ?", time.Now().Add(-keep)).Delete(&models.JobRun{}).Error
+}
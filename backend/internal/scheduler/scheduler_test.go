@@ -0,0 +1,65 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// staticLeader — выбор лидера с заранее заданным результатом
+type staticLeader struct {
+	leader   bool
+	released atomic.Bool
+}
+
+func (l *staticLeader) Refresh(ctx context.Context) bool { return l.leader }
+func (l *staticLeader) Release()                         { l.released.Store(true) }
+
+func TestNonLeaderSkipsSharedJobs(t *testing.T) {
+	leader := &staticLeader{leader: false}
+	s := New(leader)
+
+	var shared, local atomic.Int32
+	s.Add(Job{
+		Name:     "shared",
+		Schedule: Every(5 * time.Millisecond),
+		Run: func(ctx context.Context) error {
+			shared.Add(1)
+			return nil
+		},
+	})
+	s.Add(Job{
+		Name:          "local",
+		Schedule:      Every(5 * time.Millisecond),
+		EveryInstance: true,
+		Run: func(ctx context.Context) error {
+			local.Add(1)
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	s.Run(ctx)
+
+	if got := shared.Load(); got != 0 {
+		t.Fatalf("leader-only job ran %d times on a non-leader", got)
+	}
+	if local.Load() == 0 {
+		t.Fatal("EveryInstance job did not run on a non-leader")
+	}
+	if !leader.released.Load() {
+		t.Fatal("leadership was not released on stop")
+	}
+}
+
+func TestSafeRunRecoversPanic(t *testing.T) {
+	s := New(nil)
+	err := s.safeRun(context.Background(), Job{Name: "panics", Run: func(ctx context.Context) error {
+		panic("boom")
+	}})
+	if err == nil || err.Error() != "panic: boom" {
+		t.Fatalf("safeRun = %v, want panic error", err)
+	}
+}
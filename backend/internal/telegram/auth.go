@@ -100,6 +100,9 @@ func ValidateInitData(initData, botToken string) (map[string]string, bool) {
 			if user.Username != "" {
 				result["username"] = user.Username
 			}
+			if user.Language != "" {
+				result["language_code"] = user.Language
+			}
 		}
 	}
 
@@ -133,3 +136,7 @@ func ExtractUsername(data map[string]string) string {
 	return data["username"]
 }
 
+// ExtractLanguage извлекает language_code пользователя из валидированных данных
+func ExtractLanguage(data map[string]string) string {
+	return data["language_code"]
+}
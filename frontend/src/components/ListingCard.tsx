@@ -2,43 +2,11 @@ import { useState, useEffect, useRef, type ReactNode } from 'react';
 import { Flame, Clock, ChevronDown, ChevronUp } from 'lucide-react';
 import { ImageWithFallback } from './figma/ImageWithFallback';
 import { Button } from './ui/button';
+import { useTaxonomy } from '../utils/taxonomy';
+import { apiFetch } from '../utils/telegram';
 
 const MANAGER_LINK = 'https://t.me/birzha_manager';
 
-const categoryLabels: Record<string, string> = {
-  services: 'Услуги',
-  buysell: 'Купля / Продажа',
-  other: 'Другое',
-};
-
-const modeLabels: Record<string, string> = {
-  offer: 'Предлагаю',
-  search: 'Ищу',
-  sell: 'Продаю',
-  buy: 'Покупаю',
-  general: 'Объявление',
-};
-
-const tagLabels: Record<string, string> = {
-  all: 'Все',
-  designer: 'Дизайн',
-  script: 'Сценарий',
-  voice: 'Озвучивание',
-  other: 'Другое',
-  konechka: 'Конечка',
-  channel: 'Канал',
-  video: 'Видео',
-  adsense: 'AdSense',
-  templates: 'Шаблоны',
-  education: 'Обучение',
-  courses: 'Курсы',
-  cheats: 'Читы',
-  mods: 'Моды',
-  niche: 'Ниша',
-  schemes: 'Схемы',
-  boost: 'Накрутка',
-};
-
 export interface ListingCardData {
   id: number;
   title: string;
@@ -50,6 +18,7 @@ export interface ListingCardData {
   tag?: string;
   status?: 'active' | 'expired' | 'inactive';
   expiresAt?: string;
+  nextBumpAt?: string | null;
   photoUrl?: string | null;
 }
 
@@ -62,11 +31,17 @@ interface ListingCardProps {
 
 const MAX_DESCRIPTION_LENGTH = 150; // Примерная длина для 3 строк
 
+// Статистика для продавца: открытие объявления и нажатие на контакт. Ошибки не показываем.
+function trackAdEvent(url: string, method: 'GET' | 'POST') {
+  apiFetch(url, { method, keepalive: true }).catch(() => {});
+}
+
 export function ListingCard({ listing, footer, showExpiryDate = false, showFullDescription = false }: ListingCardProps) {
   const [isExpanded, setIsExpanded] = useState(false); // По умолчанию всегда свернуто
   const [shouldShowExpand, setShouldShowExpand] = useState(false);
   const descriptionRef = useRef<HTMLParagraphElement>(null);
   const cardRef = useRef<HTMLDivElement>(null);
+  const { taxonomy } = useTaxonomy();
 
   const isExpired = listing.status === 'expired';
   const isInactive = listing.status === 'inactive';
@@ -174,9 +149,14 @@ export function ListingCard({ listing, footer, showExpiryDate = false, showFullD
       })
     : null;
 
-  const categoryLabel = listing.category ? categoryLabels[listing.category] ?? listing.category : null;
-  const modeLabel = listing.mode ? modeLabels[listing.mode] ?? listing.mode : null;
-  const tagLabel = listing.tag && listing.tag !== 'all' ? tagLabels[listing.tag] ?? listing.tag : null;
+  const labels = taxonomy?.labels;
+  const categoryLabel = listing.category ? labels?.categories[listing.category] ?? listing.category : null;
+  const modeLabel =
+    listing.category && listing.mode ? labels?.modes[listing.category]?.[listing.mode] ?? listing.mode : null;
+  const tagLabel =
+    listing.category && listing.tag && listing.tag !== 'all'
+      ? labels?.tags[listing.category]?.[listing.tag] ?? listing.tag
+      : null;
 
   return (
     <div ref={cardRef} className={`bg-card rounded-2xl shadow-md overflow-hidden transition-all hover:shadow-lg ${borderClass}`}>
@@ -245,6 +225,9 @@ export function ListingCard({ listing, footer, showExpiryDate = false, showFullD
                 onClick={(e) => {
                   e.preventDefault();
                   e.stopPropagation();
+                  if (!isExpanded) {
+                    trackAdEvent(`/api/ads/${listing.id}`, 'GET');
+                  }
                   setIsExpanded(!isExpanded);
                 }}
                 onMouseDown={(e) => {
@@ -283,6 +266,7 @@ export function ListingCard({ listing, footer, showExpiryDate = false, showFullD
           target="_blank"
           rel="noopener noreferrer"
           className="text-[#FF0000] hover:underline inline-block font-medium"
+          onClick={() => trackAdEvent(`/api/ads/${listing.id}/contact`, 'POST')}
         >
           {listing.username}
         </a>
@@ -4,44 +4,64 @@ import { Tabs, TabsList, TabsTrigger } from './ui/tabs';
 import { Button } from './ui/button';
 import { FilterScroll } from './FilterScroll';
 import { apiFetch } from '../utils/telegram';
+import { useTaxonomy, type TaxonomyCategory } from '../utils/taxonomy';
 
-type MainCategory = 'services' | 'buysell' | 'other';
+// Режим выбирается только там, где их несколько (в «Другое» режим один)
+function hasModeChoice(category: TaxonomyCategory | undefined): boolean {
+  return (category?.modes.length ?? 0) > 1;
+}
 
 export function ListingsTab() {
-  const [mainCategory, setMainCategory] = useState<MainCategory>('services');
-  const [serviceFilter, setServiceFilter] = useState<string>('offer');
-  const [serviceType, setServiceType] = useState<string>('all');
-  const [buysellFilter, setBuysellFilter] = useState<string>('sell');
-  const [buysellType, setBuysellType] = useState<string>('all');
-  const [otherType, setOtherType] = useState<string>('all');
+  const { taxonomy, error: taxonomyError } = useTaxonomy();
+  const [mainCategory, setMainCategory] = useState<string | null>(null);
+  const [modeFilter, setModeFilter] = useState<string>('');
+  const [tagFilter, setTagFilter] = useState<string>('all');
   const [listings, setListings] = useState<ListingCardData[]>([]);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState<string | null>(null);
 
+  const categories = taxonomy?.categories ?? [];
+  const category = categories.find((item) => item.key === mainCategory);
+
+  // Сбрасываем фильтры при смене категории
+  const selectCategory = (key: string) => {
+    const next = categories.find((item) => item.key === key);
+    setMainCategory(key);
+    setModeFilter(hasModeChoice(next) ? next!.modes[0].key : '');
+    setTagFilter('all');
+  };
+
+  useEffect(() => {
+    if (taxonomy && mainCategory === null && taxonomy.categories.length > 0) {
+      selectCategory(taxonomy.categories[0].key);
+    }
+  }, [taxonomy]);
+
+  useEffect(() => {
+    if (taxonomyError) {
+      setError('Не удалось загрузить объявления. Попробуйте обновить позже.');
+      setLoading(false);
+    }
+  }, [taxonomyError]);
+
   useEffect(() => {
-    fetchListings();
-  }, [mainCategory, serviceFilter, serviceType, buysellFilter, buysellType, otherType]);
+    if (mainCategory !== null) {
+      fetchListings();
+    }
+  }, [mainCategory, modeFilter, tagFilter]);
 
   const fetchListings = async () => {
     setLoading(true);
     setError(null);
     try {
       const params = new URLSearchParams();
-      params.set('cat', mainCategory);
-
-      if (mainCategory === 'services' && serviceType !== 'all') {
-        params.set('tag', serviceType);
-      } else if (mainCategory === 'buysell' && buysellType !== 'all') {
-        params.set('tag', buysellType);
-      } else if (mainCategory === 'other' && otherType !== 'all') {
-        params.set('tag', otherType);
-      }
+      params.set('cat', mainCategory!);
 
-      if (mainCategory === 'services') {
-        params.set('mode', serviceFilter);
+      if (tagFilter !== 'all') {
+        params.set('tag', tagFilter);
       }
-      if (mainCategory === 'buysell') {
-        params.set('mode', buysellFilter);
+      if (hasModeChoice(category) && modeFilter) {
+        params.set('mode', modeFilter);
       }
 
       const response = await apiFetch(`/api/ads?${params.toString()}`);
@@ -82,340 +102,67 @@ export function ListingsTab() {
       </div>
 
       {/* Main Category Tabs */}
-      <div className="px-4 pt-4">
-        <Tabs value={mainCategory} onValueChange={(v) => setMainCategory(v as MainCategory)}>
-          <TabsList className="w-full bg-muted p-1 rounded-xl">
-            <TabsTrigger
-              value="services"
-              className="flex-1 rounded-lg data-[state=active]:bg-background data-[state=active]:text-[#FF0000]"
-            >
-              Услуги
-            </TabsTrigger>
-            <TabsTrigger
-              value="buysell"
-              className="flex-1 rounded-lg data-[state=active]:bg-background data-[state=active]:text-[#FF0000]"
-            >
-              Купля/Продажа
-            </TabsTrigger>
-            <TabsTrigger
-              value="other"
-              className="flex-1 rounded-lg data-[state=active]:bg-background data-[state=active]:text-[#FF0000]"
-            >
-              Другое
-            </TabsTrigger>
-          </TabsList>
-        </Tabs>
-      </div>
+      {categories.length > 0 && (
+        <div className="px-4 pt-4">
+          <Tabs value={mainCategory ?? undefined} onValueChange={selectCategory}>
+            <TabsList className="w-full bg-muted p-1 rounded-xl">
+              {categories.map((item) => (
+                <TabsTrigger
+                  key={item.key}
+                  value={item.key}
+                  className="flex-1 rounded-lg data-[state=active]:bg-background data-[state=active]:text-[#FF0000]"
+                >
+                  {item.label}
+                </TabsTrigger>
+              ))}
+            </TabsList>
+          </Tabs>
+        </div>
+      )}
 
       {/* Filters */}
-      <div className="px-4 pt-4 space-y-3">
-        {mainCategory === 'services' && (
-          <>
-            <div className="flex gap-2">
-              <Button
-                onClick={() => {
-                  setServiceFilter('offer');
-                  setServiceType('all');
-                }}
-                variant={serviceFilter === 'offer' ? 'default' : 'outline'}
-                className={`rounded-full whitespace-nowrap ${
-                  serviceFilter === 'offer'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border hover:border-[#FF0000]'
-                }`}
-              >
-                Предлагаю услугу
-              </Button>
-              <Button
-                onClick={() => {
-                  setServiceFilter('search');
-                  setServiceType('all');
-                }}
-                variant={serviceFilter === 'search' ? 'default' : 'outline'}
-                className={`rounded-full whitespace-nowrap ${
-                  serviceFilter === 'search'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border hover:border-[#FF0000]'
-                }`}
-              >
-                Ищу услугу
-              </Button>
-            </div>
-            <FilterScroll>
-              <Button
-                onClick={() => setServiceType('all')}
-                variant={serviceType === 'all' ? 'default' : 'outline'}
-                size="sm"
-                className={`rounded-full ${
-                  serviceType === 'all'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border'
-                }`}
-              >
-                Все
-              </Button>
-              <Button
-                onClick={() => setServiceType('designer')}
-                variant={serviceType === 'designer' ? 'default' : 'outline'}
-                size="sm"
-                className={`rounded-full ${
-                  serviceType === 'designer'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border'
-                }`}
-              >
-                Дизайнер
-              </Button>
-              <Button
-                onClick={() => setServiceType('script')}
-                variant={serviceType === 'script' ? 'default' : 'outline'}
-                size="sm"
-                className={`rounded-full ${
-                  serviceType === 'script'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border'
-                }`}
-              >
-                Сценарист
-              </Button>
-              <Button
-                onClick={() => setServiceType('voice')}
-                variant={serviceType === 'voice' ? 'default' : 'outline'}
-                size="sm"
-                className={`rounded-full ${
-                  serviceType === 'voice'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border'
-                }`}
-              >
-                Озвучивание
-              </Button>
-              <Button
-                onClick={() => setServiceType('other')}
-                variant={serviceType === 'other' ? 'default' : 'outline'}
-                size="sm"
-                className={`rounded-full ${
-                  serviceType === 'other'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border'
-                }`}
-              >
-                Другое
-              </Button>
-            </FilterScroll>
-          </>
-        )}
-
-        {mainCategory === 'buysell' && (
-          <>
+      {category && (
+        <div className="px-4 pt-4 space-y-3">
+          {hasModeChoice(category) && (
             <div className="flex gap-2">
-              <Button
-                onClick={() => {
-                  setBuysellFilter('sell');
-                  setBuysellType('all');
-                }}
-                variant={buysellFilter === 'sell' ? 'default' : 'outline'}
-                className={`rounded-full whitespace-nowrap ${
-                  buysellFilter === 'sell'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border hover:border-[#FF0000]'
-                }`}
-              >
-                Продам
-              </Button>
-              <Button
-                onClick={() => {
-                  setBuysellFilter('buy');
-                  setBuysellType('all');
-                }}
-                variant={buysellFilter === 'buy' ? 'default' : 'outline'}
-                className={`rounded-full whitespace-nowrap ${
-                  buysellFilter === 'buy'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border hover:border-[#FF0000]'
-                }`}
-              >
-                Куплю
-              </Button>
+              {category.modes.map((mode) => (
+                <Button
+                  key={mode.key}
+                  onClick={() => {
+                    setModeFilter(mode.key);
+                    setTagFilter('all');
+                  }}
+                  variant={modeFilter === mode.key ? 'default' : 'outline'}
+                  className={`rounded-full whitespace-nowrap ${
+                    modeFilter === mode.key
+                      ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
+                      : 'border-border hover:border-[#FF0000]'
+                  }`}
+                >
+                  {mode.label}
+                </Button>
+              ))}
             </div>
-            <FilterScroll>
-              <Button
-                onClick={() => setBuysellType('all')}
-                variant={buysellType === 'all' ? 'default' : 'outline'}
-                size="sm"
-                className={`rounded-full ${
-                  buysellType === 'all'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border'
-                }`}
-              >
-                Все
-              </Button>
-              <Button
-                onClick={() => setBuysellType('konechka')}
-                variant={buysellType === 'konechka' ? 'default' : 'outline'}
-                size="sm"
-                className={`rounded-full ${
-                  buysellType === 'konechka'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border'
-                }`}
-              >
-                Конечка
-              </Button>
-              <Button
-                onClick={() => setBuysellType('channel')}
-                variant={buysellType === 'channel' ? 'default' : 'outline'}
-                size="sm"
-                className={`rounded-full ${
-                  buysellType === 'channel'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border'
-                }`}
-              >
-                Канал
-              </Button>
-              <Button
-                onClick={() => setBuysellType('video')}
-                variant={buysellType === 'video' ? 'default' : 'outline'}
-                size="sm"
-                className={`rounded-full ${
-                  buysellType === 'video'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border'
-                }`}
-              >
-                Видео
-              </Button>
-              <Button
-                onClick={() => setBuysellType('adsense')}
-                variant={buysellType === 'adsense' ? 'default' : 'outline'}
-                size="sm"
-                className={`rounded-full ${
-                  buysellType === 'adsense'
-                    ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                    : 'border-border'
-                }`}
-              >
-                Адсенс
-              </Button>
+          )}
+          <FilterScroll>
+            {[{ key: 'all', label: 'Все' }, ...category.tags.filter((tag) => tag.key !== 'all')].map((tag) => (
               <Button
-                onClick={() => setBuysellType('templates')}
-                variant={buysellType === 'templates' ? 'default' : 'outline'}
+                key={tag.key}
+                onClick={() => setTagFilter(tag.key)}
+                variant={tagFilter === tag.key ? 'default' : 'outline'}
                 size="sm"
                 className={`rounded-full ${
-                  buysellType === 'templates'
+                  tagFilter === tag.key
                     ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
                     : 'border-border'
                 }`}
               >
-                Шаблоны
+                {tag.label}
               </Button>
-            </FilterScroll>
-          </>
-        )}
-
-        {mainCategory === 'other' && (
-          <FilterScroll>
-            <Button
-              onClick={() => setOtherType('all')}
-              variant={otherType === 'all' ? 'default' : 'outline'}
-              size="sm"
-              className={`rounded-full ${
-                otherType === 'all'
-                  ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                  : 'border-border'
-              }`}
-            >
-              Все
-            </Button>
-            <Button
-              onClick={() => setOtherType('education')}
-              variant={otherType === 'education' ? 'default' : 'outline'}
-              size="sm"
-              className={`rounded-full ${
-                otherType === 'education'
-                  ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                  : 'border-border'
-              }`}
-            >
-              Обучение
-            </Button>
-            <Button
-              onClick={() => setOtherType('courses')}
-              variant={otherType === 'courses' ? 'default' : 'outline'}
-              size="sm"
-              className={`rounded-full ${
-                otherType === 'courses'
-                  ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                  : 'border-border'
-              }`}
-            >
-              Курсы
-            </Button>
-            <Button
-              onClick={() => setOtherType('cheats')}
-              variant={otherType === 'cheats' ? 'default' : 'outline'}
-              size="sm"
-              className={`rounded-full ${
-                otherType === 'cheats'
-                  ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                  : 'border-border'
-              }`}
-            >
-              Читы
-            </Button>
-            <Button
-              onClick={() => setOtherType('mods')}
-              variant={otherType === 'mods' ? 'default' : 'outline'}
-              size="sm"
-              className={`rounded-full ${
-                otherType === 'mods'
-                  ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                  : 'border-border'
-              }`}
-            >
-              Моды
-            </Button>
-            <Button
-              onClick={() => setOtherType('niche')}
-              variant={otherType === 'niche' ? 'default' : 'outline'}
-              size="sm"
-              className={`rounded-full ${
-                otherType === 'niche'
-                  ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                  : 'border-border'
-              }`}
-            >
-              Ниша
-            </Button>
-            <Button
-              onClick={() => setOtherType('schemes')}
-              variant={otherType === 'schemes' ? 'default' : 'outline'}
-              size="sm"
-              className={`rounded-full ${
-                otherType === 'schemes'
-                  ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                  : 'border-border'
-              }`}
-            >
-              Схемы
-            </Button>
-            <Button
-              onClick={() => setOtherType('boost')}
-              variant={otherType === 'boost' ? 'default' : 'outline'}
-              size="sm"
-              className={`rounded-full ${
-                otherType === 'boost'
-                  ? 'bg-[#FF0000] hover:bg-[#CC0000] text-white'
-                  : 'border-border'
-              }`}
-            >
-              Накрутка
-            </Button>
+            ))}
           </FilterScroll>
-        )}
-      </div>
+        </div>
+      )}
 
       {/* Listings Grid */}
       <div className="px-4 pt-4 space-y-4">
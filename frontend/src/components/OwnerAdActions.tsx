@@ -0,0 +1,229 @@
+import { useState } from 'react';
+import { ArrowUp, RefreshCw, XCircle } from 'lucide-react';
+import { Button } from './ui/button';
+import {
+  AlertDialog,
+  AlertDialogAction,
+  AlertDialogCancel,
+  AlertDialogContent,
+  AlertDialogDescription,
+  AlertDialogFooter,
+  AlertDialogHeader,
+  AlertDialogTitle,
+  AlertDialogTrigger,
+} from './ui/alert-dialog';
+import { MANAGER_LINK, type ListingCardData } from './ListingCard';
+import { apiFetch, openInvoice } from '../utils/telegram';
+
+// Сроки продления — те же, что принимает POST /api/ads/:id/renew
+const RENEW_DAYS = [1, 7, 14, 30];
+
+interface OwnerAdActionsProps {
+  listing: ListingCardData;
+  onChanged: () => void;
+}
+
+type Action = 'bump' | 'renew' | 'deactivate';
+
+function formatDateTime(value: string) {
+  return new Date(value).toLocaleString('ru-RU', {
+    day: '2-digit',
+    month: '2-digit',
+    hour: '2-digit',
+    minute: '2-digit',
+  });
+}
+
+// Сообщение об ошибке приходит с сервера уже на языке пользователя
+async function readError(response: Response, fallback: string) {
+  try {
+    const data = await response.json();
+    return { message: (data.error as string) || fallback, data };
+  } catch {
+    return { message: fallback, data: {} as Record<string, unknown> };
+  }
+}
+
+// Действия владельца в «Моих объявлениях»: поднять, продлить (бесплатно или за звёзды) и снять с биржи
+export function OwnerAdActions({ listing, onChanged }: OwnerAdActionsProps) {
+  const [days, setDays] = useState(7);
+  const [pending, setPending] = useState<Action | null>(null);
+  const [message, setMessage] = useState<string | null>(null);
+  const [error, setError] = useState<string | null>(null);
+
+  const isActive = listing.status === 'active';
+  const isInactive = listing.status === 'inactive';
+  const bumpLockedUntil =
+    listing.nextBumpAt && new Date(listing.nextBumpAt) > new Date() ? listing.nextBumpAt : null;
+
+  const run = async (action: Action, request: () => Promise<void>) => {
+    setPending(action);
+    setMessage(null);
+    setError(null);
+    try {
+      await request();
+    } catch (err) {
+      console.error(`OwnerAdActions: ${action} failed`, err);
+      setError('Не удалось выполнить действие. Попробуйте позже.');
+    } finally {
+      setPending(null);
+    }
+  };
+
+  const handleBump = () =>
+    run('bump', async () => {
+      const response = await apiFetch(`/api/ads/${listing.id}/bump`, { method: 'POST' });
+      if (!response.ok) {
+        setError((await readError(response, 'Не удалось поднять объявление.')).message);
+        return;
+      }
+      setMessage('Объявление поднято в ленте.');
+      onChanged();
+    });
+
+  // Если бесплатные продления закончились (402), выставляем счёт в звёздах
+  const payForRenewal = async () => {
+    const response = await apiFetch('/api/payments/invoice', {
+      method: 'POST',
+      body: JSON.stringify({ ad_id: listing.id, product: 'renew', days }),
+    });
+    if (!response.ok) {
+      setError((await readError(response, 'Не удалось выставить счёт.')).message);
+      return;
+    }
+    const data = await response.json();
+    const status = await openInvoice(data.invoice_link);
+    if (status === 'paid') {
+      setMessage('Оплата получена, объявление продлено.');
+      onChanged();
+    } else if (status === 'failed') {
+      setError('Оплата не прошла.');
+    }
+  };
+
+  const handleRenew = () =>
+    run('renew', async () => {
+      const response = await apiFetch(`/api/ads/${listing.id}/renew`, {
+        method: 'POST',
+        body: JSON.stringify({ days }),
+      });
+      if (response.status === 402) {
+        await payForRenewal();
+        return;
+      }
+      if (!response.ok) {
+        setError((await readError(response, 'Не удалось продлить объявление.')).message);
+        return;
+      }
+      const data = await response.json();
+      setMessage(
+        `Объявление продлено до ${formatDateTime(data.ad.expires_at)}. Бесплатных продлений осталось: ${data.free_renewals_left}.`
+      );
+      onChanged();
+    });
+
+  const handleDeactivate = () =>
+    run('deactivate', async () => {
+      const response = await apiFetch(`/api/ads/${listing.id}/deactivate`, { method: 'POST' });
+      if (!response.ok) {
+        setError((await readError(response, 'Не удалось снять объявление.')).message);
+        return;
+      }
+      setMessage('Объявление снято с биржи.');
+      onChanged();
+    });
+
+  if (isInactive) {
+    return (
+      <div className="flex flex-col gap-2">
+        <p className="text-sm text-muted-foreground">
+          Объявление снято с биржи. Чтобы опубликовать его вновь, свяжитесь с менеджером.
+        </p>
+        <Button asChild className="bg-[#FF0000] hover:bg-[#CC0000] text-white">
+          <a href={MANAGER_LINK} target="_blank" rel="noopener noreferrer">
+            Обратитесь к менеджеру
+          </a>
+        </Button>
+      </div>
+    );
+  }
+
+  return (
+    <div className="flex flex-col gap-3">
+      {!isActive && (
+        <p className="text-sm text-muted-foreground">
+          Срок объявления истёк, оно не показывается на бирже. Продлите его, чтобы вернуть в ленту.
+        </p>
+      )}
+
+      <div className="flex flex-col gap-2">
+        <div className="flex gap-2">
+          {RENEW_DAYS.map((value) => (
+            <button
+              key={value}
+              type="button"
+              onClick={() => setDays(value)}
+              className={`flex-1 rounded-lg border px-2 py-1 text-sm transition-colors ${
+                days === value ? 'border-[#FF0000] text-[#FF0000]' : 'border-border text-muted-foreground'
+              }`}
+            >
+              {value} дн.
+            </button>
+          ))}
+        </div>
+        <Button
+          onClick={handleRenew}
+          disabled={pending !== null}
+          className="bg-[#FF0000] hover:bg-[#CC0000] text-white"
+        >
+          <RefreshCw size={16} />
+          {pending === 'renew' ? 'Продлеваем...' : `Продлить на ${days} дн.`}
+        </Button>
+      </div>
+
+      {isActive && (
+        <div className="flex gap-2">
+          <Button
+            variant="outline"
+            className="flex-1"
+            onClick={handleBump}
+            disabled={pending !== null || bumpLockedUntil !== null}
+          >
+            <ArrowUp size={16} />
+            {pending === 'bump'
+              ? 'Поднимаем...'
+              : bumpLockedUntil
+                ? `Поднять с ${formatDateTime(bumpLockedUntil)}`
+                : 'Поднять'}
+          </Button>
+
+          <AlertDialog>
+            <AlertDialogTrigger asChild>
+              <Button variant="outline" className="flex-1" disabled={pending !== null}>
+                <XCircle size={16} />
+                {pending === 'deactivate' ? 'Снимаем...' : 'Снять'}
+              </Button>
+            </AlertDialogTrigger>
+            <AlertDialogContent>
+              <AlertDialogHeader>
+                <AlertDialogTitle>Снять объявление?</AlertDialogTitle>
+                <AlertDialogDescription>
+                  «{listing.title}» пропадёт из ленты и канала. Вернуть его сможет только менеджер.
+                </AlertDialogDescription>
+              </AlertDialogHeader>
+              <AlertDialogFooter>
+                <AlertDialogCancel>Отмена</AlertDialogCancel>
+                <AlertDialogAction onClick={handleDeactivate} className="bg-[#FF0000] hover:bg-[#CC0000] text-white">
+                  Снять
+                </AlertDialogAction>
+              </AlertDialogFooter>
+            </AlertDialogContent>
+          </AlertDialog>
+        </div>
+      )}
+
+      {message && <p className="text-sm text-green-600">{message}</p>}
+      {error && <p className="text-sm text-red-500">{error}</p>}
+    </div>
+  );
+}
@@ -1,5 +1,6 @@
 import { useState, useEffect } from 'react';
 import { ListingCard, MANAGER_LINK, type ListingCardData } from './ListingCard';
+import { OwnerAdActions } from './OwnerAdActions';
 import { Button } from './ui/button';
 import { User, Moon, Sun } from 'lucide-react';
 import { apiFetch } from '../utils/telegram';
@@ -42,16 +43,19 @@ export function ProfileTab({ isDark, toggleTheme }: ProfileTabProps) {
     }
   }, [userId]);
 
-  const fetchMyAds = async () => {
+  // silent — обновить список после действия владельца, не показывая загрузку
+  const fetchMyAds = async (silent = false) => {
     if (!userId) {
       console.log('ProfileTab: userId не найден, пропускаем запрос');
       return;
     }
     
     console.log('ProfileTab: запрос объявлений для user_id=', userId);
-    setLoading(true);
+    if (!silent) {
+      setLoading(true);
+    }
     try {
-      const response = await apiFetch(`/api/myads?user_id=${userId}`);
+      const response = await apiFetch('/api/myads');
       console.log('ProfileTab: получен ответ', response.status, response.statusText);
       const data = await response.json();
       console.log('ProfileTab: получено объявлений', data.length);
@@ -67,6 +71,7 @@ export function ProfileTab({ isDark, toggleTheme }: ProfileTabProps) {
         tag: ad.tag,
         status: (ad.status ?? 'active') as 'active' | 'expired' | 'inactive',
         expiresAt: ad.expires_at,
+        nextBumpAt: ad.next_bump_at ?? null,
         photoUrl: ad.photo_url ?? null,
       }));
       
@@ -144,35 +149,14 @@ export function ProfileTab({ isDark, toggleTheme }: ProfileTabProps) {
             </Button>
           </div>
           
-          {listings.map((listing) => {
-            const isExpired = listing.status === 'expired';
-            const isInactive = listing.status === 'inactive';
-
-            return (
-              <ListingCard
-                key={listing.id}
-                listing={listing}
-                showExpiryDate={true}
-                footer={
-                  (isExpired || isInactive) && (
-                    <div className="flex flex-col gap-2">
-                      <p className="text-sm text-muted-foreground">
-                        Объявление не показывается на бирже. Свяжитесь с менеджером, чтобы поднять его вновь.
-                      </p>
-                      <Button
-                        asChild
-                        className="bg-[#FF0000] hover:bg-[#CC0000] text-white"
-                      >
-                        <a href={MANAGER_LINK} target="_blank" rel="noopener noreferrer">
-                          Обратитесь к менеджеру
-                        </a>
-                      </Button>
-                    </div>
-                  )
-                }
-              />
-            );
-          })}
+          {listings.map((listing) => (
+            <ListingCard
+              key={listing.id}
+              listing={listing}
+              showExpiryDate={true}
+              footer={<OwnerAdActions listing={listing} onChanged={() => fetchMyAds(true)} />}
+            />
+          ))}
         </div>
       ) : null}
     </div>
@@ -0,0 +1,70 @@
+import { useEffect, useState } from 'react';
+import { apiFetch } from './telegram';
+
+export interface TaxonomyOption {
+  key: string;
+  label: string;
+}
+
+export interface TaxonomyCategory {
+  key: string;
+  label: string;
+  modes: TaxonomyOption[];
+  tags: TaxonomyOption[];
+}
+
+export interface Taxonomy {
+  // Активные категории в порядке отображения
+  categories: TaxonomyCategory[];
+  // Названия всех рубрик, включая скрытые, — для старых объявлений
+  labels: {
+    categories: Record<string, string>;
+    modes: Record<string, Record<string, string>>;
+    tags: Record<string, Record<string, string>>;
+  };
+}
+
+let taxonomyRequest: Promise<Taxonomy> | null = null;
+
+/**
+ * Загружает рубрики один раз за сессию Mini App
+ */
+export function loadTaxonomy(): Promise<Taxonomy> {
+  if (!taxonomyRequest) {
+    taxonomyRequest = apiFetch('/api/taxonomy')
+      .then((response) => {
+        if (!response.ok) {
+          throw new Error('Ошибка загрузки рубрик');
+        }
+        return response.json() as Promise<Taxonomy>;
+      })
+      .catch((error) => {
+        // Следующий вызов попробует загрузить рубрики заново
+        taxonomyRequest = null;
+        throw error;
+      });
+  }
+  return taxonomyRequest;
+}
+
+export function useTaxonomy(): { taxonomy: Taxonomy | null; error: boolean } {
+  const [taxonomy, setTaxonomy] = useState<Taxonomy | null>(null);
+  const [error, setError] = useState(false);
+
+  useEffect(() => {
+    let cancelled = false;
+    loadTaxonomy()
+      .then((data) => {
+        if (!cancelled) setTaxonomy(data);
+      })
+      .catch((err) => {
+        console.error('Failed to fetch taxonomy:', err);
+        if (!cancelled) setError(true);
+      });
+    return () => {
+      cancelled = true;
+    };
+  }, []);
+
+  return { taxonomy, error };
+}
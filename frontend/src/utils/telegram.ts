@@ -1,5 +1,7 @@
 // Утилиты для работы с Telegram Mini App
 
+export type InvoiceStatus = 'paid' | 'cancelled' | 'failed' | 'pending';
+
 declare global {
   interface Window {
     Telegram?: {
@@ -19,6 +21,7 @@ declare global {
         ready: () => void;
         expand: () => void;
         close: () => void;
+        openInvoice?: (url: string, callback?: (status: InvoiceStatus) => void) => void;
         version: string;
         platform: string;
       };
@@ -107,3 +110,18 @@ export async function apiFetch(
   });
 }
 
+
+/**
+ * Открывает счёт в Telegram Stars и ждёт его статуса
+ */
+export function openInvoice(link: string): Promise<InvoiceStatus> {
+  return new Promise((resolve) => {
+    const tg = window.Telegram?.WebApp;
+    if (!tg?.openInvoice) {
+      window.open(link, '_blank');
+      resolve('pending');
+      return;
+    }
+    tg.openInvoice(link, resolve);
+  });
+}